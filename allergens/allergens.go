@@ -0,0 +1,152 @@
+// Package allergens derives the allergens of a recipe from the names of its ingredients.
+package allergens
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/PfMartin/wegonice-api/db"
+)
+
+type catalogueEntry struct {
+	allergen db.Allergen
+	// keywords are matched as whole words in the ingredient names, optionally in plural.
+	keywords []string
+	// exceptions are phrases, which contain or directly precede a keyword without the allergen,
+	// e.g. "rice flour" or "gluten-free pasta". Only these occurrences of the keywords are
+	// excused, so "rice flour and wheat flour" still contains gluten.
+	exceptions []string
+}
+
+// catalogue of the allergens with the ingredients, which contain them.
+var catalogue = []catalogueEntry{
+	{
+		allergen: db.Gluten,
+		keywords: []string{
+			"wheat", "flour", "barley", "rye", "spelt", "semolina", "couscous", "bulgur", "seitan",
+			"bread", "breadcrumbs", "pasta", "spaghetti", "noodle", "malt", "farro", "soy sauce",
+		},
+		exceptions: []string{
+			"gluten-free", "gluten free", "almond flour", "rice flour", "coconut flour", "corn flour",
+			"buckwheat", "chickpea flour", "rice noodle", "glass noodle",
+		},
+	},
+	{
+		allergen: db.Nuts,
+		keywords: []string{
+			"nut", "almond", "hazelnut", "walnut", "cashew", "pecan", "pistachio", "macadamia",
+			"pine nut", "brazil nut", "peanut", "praline", "marzipan",
+		},
+	},
+	{
+		allergen: db.Soy,
+		keywords: []string{"soy", "soya", "soybean", "tofu", "tempeh", "edamame", "miso", "tamari"},
+	},
+	{
+		allergen: db.Sesame,
+		keywords: []string{"sesame", "tahini", "gomasio"},
+	},
+}
+
+type matcher struct {
+	allergen   db.Allergen
+	keywords   *regexp.Regexp
+	exceptions []string
+}
+
+var matchers = newMatchers()
+
+func newMatchers() []matcher {
+	matchers := make([]matcher, 0, len(catalogue))
+	for _, entry := range catalogue {
+		quotedKeywords := make([]string, 0, len(entry.keywords))
+		for _, keyword := range entry.keywords {
+			quotedKeywords = append(quotedKeywords, regexp.QuoteMeta(keyword))
+		}
+
+		matchers = append(matchers, matcher{
+			allergen:   entry.allergen,
+			keywords:   regexp.MustCompile(`\b(` + strings.Join(quotedKeywords, "|") + `)(s|es)?\b`),
+			exceptions: entry.exceptions,
+		})
+	}
+
+	return matchers
+}
+
+func (matcher matcher) matches(ingredientName string) bool {
+	for _, keywordMatch := range matcher.keywords.FindAllStringIndex(ingredientName, -1) {
+		if !matcher.isException(ingredientName, keywordMatch[0], keywordMatch[1]) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isException checks, if the keyword between start and end is part of an exception or directly
+// follows one.
+func (matcher matcher) isException(ingredientName string, start int, end int) bool {
+	for _, exception := range matcher.exceptions {
+		offset := 0
+		for {
+			index := strings.Index(ingredientName[offset:], exception)
+			if index < 0 {
+				break
+			}
+
+			exceptionStart := offset + index
+			exceptionEnd := exceptionStart + len(exception)
+			if exceptionStart < end && start < exceptionEnd {
+				return true
+			}
+			if exceptionEnd <= start && strings.TrimSpace(ingredientName[exceptionEnd:start]) == "" {
+				return true
+			}
+
+			offset = exceptionStart + 1
+		}
+	}
+
+	return false
+}
+
+// Detect returns the allergens, which are contained in the ingredients, in the order of the
+// catalogue. The result is empty, but not nil, if no allergen was found.
+func Detect(ingredients []db.Ingredient) []db.Allergen {
+	detectedAllergens := []db.Allergen{}
+
+	for _, matcher := range matchers {
+		for _, ingredient := range ingredients {
+			if matcher.matches(strings.ToLower(ingredient.Name)) {
+				detectedAllergens = append(detectedAllergens, matcher.allergen)
+				break
+			}
+		}
+	}
+
+	return detectedAllergens
+}
+
+// dietaryLabelAllergens are the allergens, which a recipe with the dietary label must not
+// contain.
+var dietaryLabelAllergens = map[db.DietaryLabel]db.Allergen{
+	db.GlutenFree: db.Gluten,
+	db.NutFree:    db.Nuts,
+	db.SoyFree:    db.Soy,
+}
+
+// CheckDietaryLabels returns an error, if one of the dietary labels contradicts the detected
+// allergens, e.g. a nut-free recipe with almonds.
+func CheckDietaryLabels(labels []db.DietaryLabel, detectedAllergens []db.Allergen) error {
+	for _, label := range labels {
+		allergen, ok := dietaryLabelAllergens[label]
+		if ok && slices.Contains(detectedAllergens, allergen) {
+			return fmt.Errorf("dietary label %s contradicts the allergen %s, which was detected in the ingredients", label, allergen)
+		}
+	}
+
+	return nil
+}
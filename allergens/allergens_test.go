@@ -0,0 +1,102 @@
+package allergens
+
+import (
+	"testing"
+
+	"github.com/PfMartin/wegonice-api/db"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnitDetect(t *testing.T) {
+	testCases := []struct {
+		name              string
+		ingredientNames   []string
+		expectedAllergens []db.Allergen
+	}{
+		{
+			name:              "No allergens",
+			ingredientNames:   []string{"Oat milk", "banana", "Nutmeg", "coconut", "butternut squash"},
+			expectedAllergens: []db.Allergen{},
+		},
+		{
+			name:              "Allergens in catalogue order without duplicates",
+			ingredientNames:   []string{"Toasted sesame oil", "Tofu", "Whole wheat flour", "Spaghetti", "Almonds"},
+			expectedAllergens: []db.Allergen{db.Gluten, db.Nuts, db.Soy, db.Sesame},
+		},
+		{
+			name:              "Plural keywords",
+			ingredientNames:   []string{"chopped walnuts", "rice noodles"},
+			expectedAllergens: []db.Allergen{db.Nuts},
+		},
+		{
+			name:              "Gluten-free exceptions",
+			ingredientNames:   []string{"almond flour", "gluten-free pasta", "Buckwheat flour"},
+			expectedAllergens: []db.Allergen{db.Nuts},
+		},
+		{
+			name:              "Exceptions only excuse their own keyword",
+			ingredientNames:   []string{"rice flour and wheat flour", "gluten-free pasta with breadcrumbs"},
+			expectedAllergens: []db.Allergen{db.Gluten},
+		},
+		{
+			name:              "Exceptions of several keywords",
+			ingredientNames:   []string{"rice flour and corn flour", "gluten free bread"},
+			expectedAllergens: []db.Allergen{},
+		},
+		{
+			name:              "Ingredients with several allergens",
+			ingredientNames:   []string{"soy sauce", "peanut butter"},
+			expectedAllergens: []db.Allergen{db.Gluten, db.Nuts, db.Soy},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var ingredients []db.Ingredient
+			for _, name := range tc.ingredientNames {
+				ingredients = append(ingredients, db.Ingredient{Name: name, Amount: 1, Unit: db.Piece})
+			}
+
+			require.Equal(t, tc.expectedAllergens, Detect(ingredients))
+		})
+	}
+}
+
+func TestUnitCheckDietaryLabels(t *testing.T) {
+	testCases := []struct {
+		name              string
+		labels            []db.DietaryLabel
+		detectedAllergens []db.Allergen
+		expectErr         bool
+	}{
+		{
+			name:              "Labels without detected allergens",
+			labels:            []db.DietaryLabel{db.GlutenFree, db.NutFree, db.SoyFree, db.Raw},
+			detectedAllergens: []db.Allergen{db.Sesame},
+		},
+		{
+			name:              "Labels without allergens",
+			labels:            []db.DietaryLabel{db.Raw, db.HighProtein},
+			detectedAllergens: []db.Allergen{db.Gluten, db.Nuts, db.Soy, db.Sesame},
+		},
+		{
+			name:              "Nut-free label with nuts",
+			labels:            []db.DietaryLabel{db.GlutenFree, db.NutFree},
+			detectedAllergens: []db.Allergen{db.Nuts},
+			expectErr:         true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := CheckDietaryLabels(tc.labels, tc.detectedAllergens)
+
+			if tc.expectErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
@@ -3,9 +3,10 @@ package api
 import (
 	"fmt"
 	"net/http"
-	"strings"
 
+	"github.com/PfMartin/wegonice-api/conversion"
 	"github.com/PfMartin/wegonice-api/db"
+	"github.com/PfMartin/wegonice-api/token"
 	"github.com/gin-gonic/gin"
 	"github.com/rs/zerolog/log"
 )
@@ -13,18 +14,21 @@ import (
 // listAuthors
 //
 // @Summary			List all authors
-// @Description	All authors are listed in a paginated manner
+// @Description	All authors are listed in a paginated manner. They are sorted by name, if no sorting is provided.
 // @ID					authors-list-authors
 // @Tags				authors
 // @Accept			json
 // @Produce			json
 // @Param				authorization	header			string							false	"Authorization header for bearer token"
-// @Param				page_id				query 			int									true	"Offset for the pagination"
+// @Param				page_id				query 			int									false	"Offset for the pagination, required without cursor"
 // @Param				page_size			query 			int									true	"Number of elements in one page"
-// @Success			200						{array}			AuthorResponse						"List of authors matching the given pagination parameters"
-// @Failure			400						{object}		ErrorBadRequest						"Bad Request"
-// @Failure			401						{object}		ErrorUnauthorized					"Unauthorized"
-// @Failure 		500						{object}		ErrorInternalServerError	"Internal Server Error"
+// @Param				cursor				query 			string							false	"Cursor of the next page, which was returned with the previous page"
+// @Param				sort					query 			[]string						false	"Keys to sort by"	collectionFormat(multi)	Enums(name, firstName, lastName, recipeCount, createdAt, modifiedAt)
+// @Param				order					query 			[]string						false	"Sort order per key"	collectionFormat(multi)	Enums(asc, desc)
+// @Success			200						{object}		AuthorListResponse				"Page of authors matching the given pagination parameters"
+// @Failure			400						{object}		ProblemDetails						"Bad Request"
+// @Failure			401						{object}		ProblemDetails						"Unauthorized"
+// @Failure 		500						{object}		ProblemDetails						"Internal Server Error"
 // @Router			/authors			[get]
 func (server *Server) listAuthors(ctx *gin.Context) {
 	var pagination db.Pagination
@@ -33,21 +37,25 @@ func (server *Server) listAuthors(ctx *gin.Context) {
 		return
 	}
 
-	// TODO: Add sorting
+	var sorting db.Sorting
+	if err := ctx.ShouldBindQuery(&sorting); err != nil {
+		NewErrorBadRequest(err).Send(ctx)
+		return
+	}
 
-	authors, err := server.store.GetAllAuthors(ctx, pagination)
+	authors, pageInfo, err := server.store.GetAllAuthors(ctx, pagination, sorting)
 	if err != nil {
-		NewErrorInternalServerError(err).Send(ctx)
+		newErrorFromDB(err).Send(ctx)
 		return
 	}
 
-	ctx.JSON(http.StatusOK, authors)
+	ctx.JSON(http.StatusOK, newListResponse(ctx, authors, pagination, pageInfo))
 }
 
 // createAuthor
 //
 // @Summary			Create new author
-// @Description	Creates a new author
+// @Description	Creates a new author, which belongs to the logged in user
 // @ID					authors-create-author
 // @Tags				authors
 // @Accept			json
@@ -55,9 +63,11 @@ func (server *Server) listAuthors(ctx *gin.Context) {
 // @Param				authorization		header			string							false	"Authorization header for bearer token"
 // @Param				data						body 				AuthorToCreate			true	"Data for the author to create"
 // @Success			201							string			string										"ID of the created author"
-// @Failure			400							{object}		ErrorBadRequest						"Bad Request"
-// @Failure			401							{object}		ErrorUnauthorized					"Unauthorized"
-// @Failure 		500							{object}		ErrorInternalServerError	"Internal Server Error"
+// @Failure			400							{object}		ProblemDetails						"Bad Request"
+// @Failure			401							{object}		ProblemDetails						"Unauthorized"
+// @Failure			403							{object}		ProblemDetails						"Forbidden"
+// @Failure			409							{object}		ProblemDetails						"Conflict, an author with this name already exists"
+// @Failure 		500							{object}		ProblemDetails						"Internal Server Error"
 // @Router			/authors				[post]
 func (server *Server) createAuthor(ctx *gin.Context) {
 	var authorBody db.AuthorToCreate
@@ -67,18 +77,16 @@ func (server *Server) createAuthor(ctx *gin.Context) {
 		return
 	}
 
+	payload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	authorBody.UserID = payload.UserID
+
 	if authorBody.ImageName != "" {
 		authorBody.ImageName = server.imageManager.CreateUniqueName(authorBody.ImageName)
 	}
 
 	authorID, err := server.store.CreateAuthor(ctx, authorBody)
 	if err != nil {
-		if strings.HasPrefix(err.Error(), "author with name") { // TODO: Find better way to check error types (enum?)
-			NewErrorBadRequest(err).Send(ctx)
-			return
-		}
-
-		NewErrorInternalServerError(err).Send(ctx)
+		newErrorFromDB(err).Send(ctx)
 		return
 	}
 
@@ -96,10 +104,10 @@ func (server *Server) createAuthor(ctx *gin.Context) {
 // @Param				authorization		header			string							false	"Authorization header for bearer token"
 // @Param				id							path 				int									true	"ID of the desired author"
 // @Success			200							{object}		AuthorResponse						"Author that matches the ID"
-// @Failure			400							{object}		ErrorBadRequest						"Bad Request"
-// @Failure			401							{object}		ErrorUnauthorized					"Unauthorized"
-// @Failure			404							{object}		ErrorNotFound							"Not Found"
-// @Failure 		500							{object}		ErrorInternalServerError	"Internal Server Error"
+// @Failure			400							{object}		ProblemDetails						"Bad Request"
+// @Failure			401							{object}		ProblemDetails						"Unauthorized"
+// @Failure			404							{object}		ProblemDetails						"Not Found"
+// @Failure 		500							{object}		ProblemDetails						"Internal Server Error"
 // @Router			/authors/{id}		[get]
 func (server *Server) getAuthorByID(ctx *gin.Context) {
 	var uriParam getByIDRequest
@@ -110,22 +118,80 @@ func (server *Server) getAuthorByID(ctx *gin.Context) {
 
 	author, err := server.store.GetAuthorByID(ctx, uriParam.ID)
 	if err != nil {
-		if strings.HasPrefix(err.Error(), "failed to find author") { // TODO: Find better method to distinguish between error types (enum?)
-			NewErrorNotFound(err).Send(ctx)
-			return
-		}
-
-		NewErrorInternalServerError(err).Send(ctx)
+		newErrorFromDB(err).Send(ctx)
 		return
 	}
 
 	ctx.JSON(http.StatusOK, author)
 }
 
+// listAuthorRecipes
+//
+// @Summary			List all recipes of one author
+// @Description	All recipes of the author, which matches the ID, are listed in a paginated manner. They are sorted by name, if no sorting is provided.
+// @ID					authors-list-author-recipes
+// @Tags				authors
+// @Accept			json
+// @Produce			json
+// @Param				authorization					header			string							false	"Authorization header for bearer token"
+// @Param				id										path 				string							true	"ID of the desired author"
+// @Param				page_id								query 			int									false	"Offset for the pagination, required without cursor"
+// @Param				page_size							query 			int									true	"Number of elements in one page"
+// @Param				cursor								query 			string							false	"Cursor of the next page, which was returned with the previous page"
+// @Param				sort									query 			[]string						false	"Keys to sort by"	collectionFormat(multi)	Enums(name, timeM, category, averageRating, ratingCount, createdAt, modifiedAt)
+// @Param				order									query 			[]string						false	"Sort order per key"	collectionFormat(multi)	Enums(asc, desc)
+// @Param				units									query 			string							false	"Unit system to convert the ingredient amounts to"	Enums(metric, imperial)
+// @Success			200										{object}		RecipeListResponse				"Page of recipes of the author"
+// @Failure			400										{object}		ProblemDetails						"Bad Request"
+// @Failure			401										{object}		ProblemDetails						"Unauthorized"
+// @Failure			404										{object}		ProblemDetails						"Not Found"
+// @Failure 		500										{object}		ProblemDetails						"Internal Server Error"
+// @Router			/authors/{id}/recipes	[get]
+func (server *Server) listAuthorRecipes(ctx *gin.Context) {
+	var uriParam getByIDRequest
+	if err := ctx.ShouldBindUri(&uriParam); err != nil {
+		NewErrorBadRequest(err).Send(ctx)
+		return
+	}
+
+	var pagination db.Pagination
+	if err := ctx.ShouldBindQuery(&pagination); err != nil {
+		NewErrorBadRequest(err).Send(ctx)
+		return
+	}
+
+	var sorting db.Sorting
+	if err := ctx.ShouldBindQuery(&sorting); err != nil {
+		NewErrorBadRequest(err).Send(ctx)
+		return
+	}
+
+	var units unitsQuery
+	if err := ctx.ShouldBindQuery(&units); err != nil {
+		NewErrorBadRequest(err).Send(ctx)
+		return
+	}
+
+	if _, err := server.store.GetAuthorByID(ctx, uriParam.ID); err != nil {
+		newErrorFromDB(err).Send(ctx)
+		return
+	}
+
+	recipes, pageInfo, err := server.store.GetRecipesByAuthorID(ctx, uriParam.ID, pagination, sorting)
+	if err != nil {
+		newErrorFromDB(err).Send(ctx)
+		return
+	}
+
+	recipes = conversion.ConvertRecipes(recipes, units.Units)
+
+	ctx.JSON(http.StatusOK, newListResponse(ctx, recipes, pagination, pageInfo))
+}
+
 // patchAuthorByID
 //
 // @Summary			Patch one author by ID
-// @Description	One author, which matches the ID, is modified with the provided patch
+// @Description	One author, which matches the ID, is modified with the provided patch. Only the user, who created the author, or an admin is allowed to patch it.
 // @ID					authors-patch-author-by-id
 // @Tags				authors
 // @Accept			json
@@ -134,9 +200,12 @@ func (server *Server) getAuthorByID(ctx *gin.Context) {
 // @Param				id							path 				int									true	"ID of the desired author to patch"
 // @Param				data						body 				AuthorUpdate				true	"Patch for modifying the author"
 // @Success			200
-// @Failure			400							{object}		ErrorBadRequest						"Bad Request"
-// @Failure			401							{object}		ErrorUnauthorized					"Unauthorized"
-// @Failure			404							{object}		ErrorNotFound							"Not Found"
+// @Failure			400							{object}		ProblemDetails						"Bad Request"
+// @Failure			401							{object}		ProblemDetails						"Unauthorized"
+// @Failure			403							{object}		ProblemDetails						"Forbidden"
+// @Failure			404							{object}		ProblemDetails						"Not Found"
+// @Failure			409							{object}		ProblemDetails						"Conflict, an author with this name already exists"
+// @Failure 		500							{object}		ProblemDetails						"Internal Server Error"
 // @Router			/authors/{id}		[patch]
 func (server *Server) patchAuthorByID(ctx *gin.Context) {
 	var uriParam getByIDRequest
@@ -168,12 +237,13 @@ func (server *Server) patchAuthorByID(ctx *gin.Context) {
 
 	existingAuthor, err := server.store.GetAuthorByID(ctx, uriParam.ID)
 	if err != nil {
-		if strings.HasPrefix(err.Error(), "failed to find author") {
-			NewErrorNotFound(err).Send(ctx)
-			return
-		}
+		newErrorFromDB(err).Send(ctx)
+		return
+	}
 
-		NewErrorBadRequest(err).Send(ctx)
+	payload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if !isOwnerOrPermitted(payload, existingAuthor.UserCreated.ID, permissionManageContent) {
+		NewErrorForbidden(fmt.Errorf("not allowed to modify author with ID: %s", uriParam.ID)).Send(ctx)
 		return
 	}
 
@@ -183,7 +253,7 @@ func (server *Server) patchAuthorByID(ctx *gin.Context) {
 
 	modifiedCount, err := server.store.UpdateAuthorByID(ctx, uriParam.ID, authorPatch)
 	if err != nil {
-		NewErrorBadRequest(err).Send(ctx)
+		newErrorFromDB(err).Send(ctx)
 		return
 	}
 
@@ -202,7 +272,7 @@ func (server *Server) patchAuthorByID(ctx *gin.Context) {
 // deleteAuthorByID
 //
 // @Summary			Delete one author by ID
-// @Description	One author, which matches the ID, is deleted
+// @Description	One author, which matches the ID, is deleted. Only the user, who created the author, or an admin is allowed to delete it.
 // @ID					authors-delete-author-by-id
 // @Tags				authors
 // @Accept			json
@@ -210,9 +280,12 @@ func (server *Server) patchAuthorByID(ctx *gin.Context) {
 // @Param				authorization		header			string							false	"Authorization header for bearer token"
 // @Param				id							path 				int									true	"ID of the desired author to patch"
 // @Success			200
-// @Failure			400							{object}		ErrorBadRequest						"Bad Request"
-// @Failure			401							{object}		ErrorUnauthorized					"Unauthorized"
-// @Failure			404							{object}		ErrorNotFound							"Not Found"
+// @Failure			400							{object}		ProblemDetails						"Bad Request"
+// @Failure			401							{object}		ProblemDetails						"Unauthorized"
+// @Failure			403							{object}		ProblemDetails						"Forbidden"
+// @Failure			404							{object}		ProblemDetails						"Not Found"
+// @Failure			409							{object}		ProblemDetails						"Conflict, the author is still referenced by at least one recipe"
+// @Failure 		500							{object}		ProblemDetails						"Internal Server Error"
 // @Router			/authors/{id}		[delete]
 func (server *Server) deleteAuthorByID(ctx *gin.Context) {
 	var uriParam getByIDRequest
@@ -223,18 +296,19 @@ func (server *Server) deleteAuthorByID(ctx *gin.Context) {
 
 	existingAuthor, err := server.store.GetAuthorByID(ctx, uriParam.ID)
 	if err != nil {
-		if strings.HasPrefix(err.Error(), "failed to find author") {
-			NewErrorNotFound(err).Send(ctx)
-			return
-		}
+		newErrorFromDB(err).Send(ctx)
+		return
+	}
 
-		NewErrorBadRequest(err).Send(ctx)
+	payload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if !isOwnerOrPermitted(payload, existingAuthor.UserCreated.ID, permissionManageContent) {
+		NewErrorForbidden(fmt.Errorf("not allowed to delete author with ID: %s", uriParam.ID)).Send(ctx)
 		return
 	}
 
 	_, err = server.store.DeleteAuthorByID(ctx, uriParam.ID)
 	if err != nil {
-		NewErrorBadRequest(err).Send(ctx)
+		newErrorFromDB(err).Send(ctx)
 		return
 	}
 
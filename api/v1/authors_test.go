@@ -29,9 +29,9 @@ func randomAuthor(t *testing.T) (db.Author, primitive.ObjectID) {
 		FirstName:    util.RandomString(6),
 		LastName:     util.RandomString(6),
 		Name:         util.RandomString(6),
-		WebsiteURL:   util.RandomString(10),
-		InstagramURL: util.RandomString(10),
-		YoutubeURL:   util.RandomString(10),
+		WebsiteURL:   util.RandomURL(),
+		InstagramURL: util.RandomURL(),
+		YoutubeURL:   util.RandomURL(),
 		ImageName:    util.RandomString(10),
 		RecipeCount:  int(util.RandomInt(0, 100)),
 		UserID:       userID,
@@ -50,6 +50,7 @@ func TestUnitListAuthors(t *testing.T) {
 
 		authors = append(authors, author)
 	}
+	authors[0].RecipeCount = 0
 
 	testCases := []struct {
 		name          string
@@ -66,16 +67,22 @@ func TestUnitListAuthors(t *testing.T) {
 					PageSize: 10,
 				}
 
-				store.EXPECT().GetAllAuthors(gomock.Any(), pagination).Times(1).Return(authors, nil)
+				store.EXPECT().GetAllAuthors(gomock.Any(), pagination, db.Sorting{}).Times(1).Return(authors, db.PageInfo{TotalCount: 25, NextCursor: "next-cursor"}, nil)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Contains(t, recorder.Body.String(), `"recipeCount":0`)
 
-				var gotAuthors []AuthorResponse
-				err := json.NewDecoder(recorder.Body).Decode(&gotAuthors)
+				var gotPage AuthorListResponse
+				err := json.NewDecoder(recorder.Body).Decode(&gotPage)
 				require.NoError(t, err)
 
+				gotAuthors := gotPage.Items
 				require.Equal(t, 10, len(gotAuthors))
+				require.Equal(t, int64(25), gotPage.TotalCount)
+				require.True(t, gotPage.HasNext)
+				require.Equal(t, "/api/v1/authors?page_id=2&page_size=10", gotPage.Links.Next)
+				require.Empty(t, gotPage.Links.Prev)
 
 				for i, expectedAuthor := range authors {
 					requireAuthorComparison(t, expectedAuthor, gotAuthors[i])
@@ -91,22 +98,89 @@ func TestUnitListAuthors(t *testing.T) {
 					PageSize: 10,
 				}
 
-				store.EXPECT().GetAllAuthors(gomock.Any(), pagination).Times(1).Return(authors[4:], nil)
+				store.EXPECT().GetAllAuthors(gomock.Any(), pagination, db.Sorting{}).Times(1).Return(authors[4:], db.PageInfo{TotalCount: 46}, nil)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusOK, recorder.Code)
 
-				var gotAuthors []AuthorResponse
-				err := json.NewDecoder(recorder.Body).Decode(&gotAuthors)
+				var gotPage AuthorListResponse
+				err := json.NewDecoder(recorder.Body).Decode(&gotPage)
 				require.NoError(t, err)
 
+				gotAuthors := gotPage.Items
 				require.Equal(t, 6, len(gotAuthors))
+				require.Equal(t, int64(46), gotPage.TotalCount)
+				require.False(t, gotPage.HasNext)
+				require.Equal(t, "/api/v1/authors?page_id=4&page_size=10", gotPage.Links.Prev)
 
 				for i, expectedAuthor := range authors[4:] {
 					requireAuthorComparison(t, expectedAuthor, gotAuthors[i])
 				}
 			},
 		},
+		{
+			name:  "Success with comma-separated sorting",
+			query: "?page_id=1&page_size=10&sort=lastName,firstName&order=desc,asc",
+			buildStubs: func(store *mock_db.MockDBStore) {
+				pagination := db.Pagination{
+					PageID:   1,
+					PageSize: 10,
+				}
+
+				sorting := db.Sorting{
+					Sort:  []string{"lastName,firstName"},
+					Order: []string{"desc,asc"},
+				}
+
+				store.EXPECT().GetAllAuthors(gomock.Any(), pagination, sorting).Times(1).Return(authors, db.PageInfo{TotalCount: 25, NextCursor: "next-cursor"}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:  "Success with sorting by recipe count",
+			query: "?page_id=1&page_size=10&sort=recipeCount&order=desc",
+			buildStubs: func(store *mock_db.MockDBStore) {
+				pagination := db.Pagination{
+					PageID:   1,
+					PageSize: 10,
+				}
+
+				sorting := db.Sorting{
+					Sort:  []string{"recipeCount"},
+					Order: []string{"desc"},
+				}
+
+				store.EXPECT().GetAllAuthors(gomock.Any(), pagination, sorting).Times(1).Return(authors, db.PageInfo{TotalCount: 10}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var gotPage AuthorListResponse
+				err := json.NewDecoder(recorder.Body).Decode(&gotPage)
+				require.NoError(t, err)
+
+				for i, expectedAuthor := range authors {
+					require.Equal(t, expectedAuthor.RecipeCount, gotPage.Items[i].RecipeCount)
+				}
+			},
+		},
+		{
+			name:  "Fail with invalid sort order",
+			query: "?page_id=1&page_size=10&sort=name&order=up",
+			buildStubs: func(store *mock_db.MockDBStore) {
+				sorting := db.Sorting{
+					Sort:  []string{"name"},
+					Order: []string{"up"},
+				}
+
+				store.EXPECT().GetAllAuthors(gomock.Any(), gomock.Any(), sorting).Times(1).Return(nil, db.PageInfo{}, db.ErrInvalidSort)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
 		{
 			name:  "Fail with missing page_size",
 			query: "?page_id=5",
@@ -115,7 +189,7 @@ func TestUnitListAuthors(t *testing.T) {
 					PageID: 5,
 				}
 
-				store.EXPECT().GetAllAuthors(gomock.Any(), pagination).Times(0)
+				store.EXPECT().GetAllAuthors(gomock.Any(), pagination, db.Sorting{}).Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusBadRequest, recorder.Code)
@@ -129,7 +203,17 @@ func TestUnitListAuthors(t *testing.T) {
 					PageSize: 10,
 				}
 
-				store.EXPECT().GetAllAuthors(gomock.Any(), pagination).Times(0)
+				store.EXPECT().GetAllAuthors(gomock.Any(), pagination, db.Sorting{}).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "Fail with negative page_id",
+			query: "?page_id=-1&page_size=10",
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().GetAllAuthors(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusBadRequest, recorder.Code)
@@ -143,6 +227,7 @@ func TestUnitListAuthors(t *testing.T) {
 			defer ctrl.Finish()
 
 			store := mock_db.NewMockDBStore(ctrl)
+			expectActiveSession(store)
 			tc.buildStubs(store)
 
 			server := newTestServer(t, store)
@@ -152,7 +237,7 @@ func TestUnitListAuthors(t *testing.T) {
 			request, err := http.NewRequest(http.MethodGet, url, nil)
 			require.NoError(t, err)
 
-			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Email, time.Minute)
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Email, db.UserRole, time.Minute)
 
 			server.router.ServeHTTP(recorder, request)
 			tc.checkResponse(recorder)
@@ -168,6 +253,9 @@ func TestUnitGetAuthorByID(t *testing.T) {
 		authors = append(authors, author)
 	}
 
+	authorWithoutRecipes, _ := randomAuthor(t)
+	authorWithoutRecipes.RecipeCount = 0
+
 	testCases := []struct {
 		name          string
 		id            string
@@ -190,11 +278,22 @@ func TestUnitGetAuthorByID(t *testing.T) {
 				requireAuthorComparison(t, authors[1], gotAuthor)
 			},
 		},
+		{
+			name: "Success getting an author without recipes",
+			id:   authorWithoutRecipes.ID,
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().GetAuthorByID(gomock.Any(), authorWithoutRecipes.ID).Times(1).Return(authorWithoutRecipes, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Contains(t, recorder.Body.String(), `"recipeCount":0`)
+			},
+		},
 		{
 			name: "Fail with non-existent ID",
 			id:   "notexisting",
 			buildStubs: func(store *mock_db.MockDBStore) {
-				store.EXPECT().GetAuthorByID(gomock.Any(), "notexisting").Times(1).Return(db.Author{}, fmt.Errorf("failed to find author with authorID: notexisting"))
+				store.EXPECT().GetAuthorByID(gomock.Any(), "notexisting").Times(1).Return(db.Author{}, db.ErrNotFound)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusNotFound, recorder.Code)
@@ -204,10 +303,10 @@ func TestUnitGetAuthorByID(t *testing.T) {
 			name: "Fail with non-parsable ID",
 			id:   "notexisting",
 			buildStubs: func(store *mock_db.MockDBStore) {
-				store.EXPECT().GetAuthorByID(gomock.Any(), "notexisting").Times(1).Return(db.Author{}, fmt.Errorf("failed to parse authorID"))
+				store.EXPECT().GetAuthorByID(gomock.Any(), "notexisting").Times(1).Return(db.Author{}, db.ErrInvalidID)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
 			},
 		},
 	}
@@ -218,6 +317,7 @@ func TestUnitGetAuthorByID(t *testing.T) {
 			defer ctrl.Finish()
 
 			store := mock_db.NewMockDBStore(ctrl)
+			expectActiveSession(store)
 			tc.buildStubs(store)
 
 			server := newTestServer(t, store)
@@ -227,7 +327,120 @@ func TestUnitGetAuthorByID(t *testing.T) {
 			request, err := http.NewRequest(http.MethodGet, url, nil)
 			require.NoError(t, err)
 
-			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Email, time.Minute)
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Email, db.UserRole, time.Minute)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestUnitListAuthorRecipes(t *testing.T) {
+	user, _ := randomUser(t)
+	author, _ := randomAuthor(t)
+
+	var recipes []db.Recipe
+	for i := 0; i < 3; i++ {
+		recipe, _ := randomRecipe(t)
+		recipe.Author = author
+		recipes = append(recipes, recipe)
+	}
+
+	testCases := []struct {
+		name          string
+		id            string
+		query         string
+		buildStubs    func(store *mock_db.MockDBStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "Success with pagination and sorting",
+			id:    author.ID,
+			query: "?page_id=1&page_size=10&sort=timeM&order=desc",
+			buildStubs: func(store *mock_db.MockDBStore) {
+				pagination := db.Pagination{
+					PageID:   1,
+					PageSize: 10,
+				}
+
+				sorting := db.Sorting{
+					Sort:  []string{"timeM"},
+					Order: []string{"desc"},
+				}
+
+				store.EXPECT().GetAuthorByID(gomock.Any(), author.ID).Times(1).Return(author, nil)
+				store.EXPECT().GetRecipesByAuthorID(gomock.Any(), author.ID, pagination, sorting).Times(1).Return(recipes, db.PageInfo{TotalCount: 3}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var gotPage RecipeListResponse
+				err := json.NewDecoder(recorder.Body).Decode(&gotPage)
+				require.NoError(t, err)
+
+				require.Equal(t, 3, len(gotPage.Items))
+				require.Equal(t, int64(3), gotPage.TotalCount)
+
+				for i, expectedRecipe := range recipes {
+					requireRecipeComparison(t, expectedRecipe, gotPage.Items[i])
+				}
+			},
+		},
+		{
+			name:  "Fail with non-existent author",
+			id:    author.ID,
+			query: "?page_id=1&page_size=10",
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().GetAuthorByID(gomock.Any(), author.ID).Times(1).Return(db.Author{}, db.ErrNotFound)
+				store.EXPECT().GetRecipesByAuthorID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:  "Fail with missing pagination",
+			id:    author.ID,
+			query: "",
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().GetAuthorByID(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().GetRecipesByAuthorID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "Fail with invalid sorting",
+			id:    author.ID,
+			query: "?page_id=1&page_size=10&sort=email",
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().GetAuthorByID(gomock.Any(), author.ID).Times(1).Return(author, nil)
+				store.EXPECT().GetRecipesByAuthorID(gomock.Any(), author.ID, gomock.Any(), gomock.Any()).Times(1).Return(nil, db.PageInfo{}, db.ErrInvalidSort)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mock_db.NewMockDBStore(ctrl)
+			expectActiveSession(store)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/api/v1/authors/%s/recipes%s", tc.id, tc.query)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.ID, db.UserRole, time.Minute)
 
 			server.router.ServeHTTP(recorder, request)
 			tc.checkResponse(recorder)
@@ -252,7 +465,6 @@ func TestUnitCreateAuthor(t *testing.T) {
 				"firstName": author.FirstName,
 				"lastName":  author.LastName,
 				"imageName": author.ImageName,
-				"userId":    author.UserID,
 			},
 			buildStubs: func(store *mock_db.MockDBStore) {
 				store.EXPECT().CreateAuthor(gomock.Any(), db.AuthorToCreate{
@@ -260,7 +472,7 @@ func TestUnitCreateAuthor(t *testing.T) {
 					FirstName: author.FirstName,
 					LastName:  author.LastName,
 					ImageName: "unique-" + author.ImageName,
-					UserID:    author.UserID,
+					UserID:    user.ID,
 				}).Times(1).Return(primitiveID, nil)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
@@ -272,7 +484,6 @@ func TestUnitCreateAuthor(t *testing.T) {
 			body: gin.H{
 				"firstName": author.FirstName,
 				"lastName":  author.LastName,
-				"userId":    author.UserID,
 			},
 			buildStubs: func(store *mock_db.MockDBStore) {
 				store.EXPECT().CreateAuthor(gomock.Any(), gomock.Any()).Times(0)
@@ -282,17 +493,25 @@ func TestUnitCreateAuthor(t *testing.T) {
 			},
 		},
 		{
-			name: "Fail due to missing userID",
+			name: "Success ignoring a client supplied userId",
 			body: gin.H{
 				"name":      author.Name,
 				"firstName": author.FirstName,
 				"lastName":  author.LastName,
+				"imageName": author.ImageName,
+				"userId":    primitive.NewObjectID().Hex(),
 			},
 			buildStubs: func(store *mock_db.MockDBStore) {
-				store.EXPECT().CreateAuthor(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().CreateAuthor(gomock.Any(), db.AuthorToCreate{
+					Name:      author.Name,
+					FirstName: author.FirstName,
+					LastName:  author.LastName,
+					ImageName: "unique-" + author.ImageName,
+					UserID:    user.ID,
+				}).Times(1).Return(primitiveID, nil)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Equal(t, http.StatusOK, recorder.Code)
 			},
 		},
 		{
@@ -302,7 +521,6 @@ func TestUnitCreateAuthor(t *testing.T) {
 				"firstName": author.FirstName,
 				"lastName":  author.LastName,
 				"imageName": author.ImageName,
-				"userId":    author.UserID,
 			},
 			buildStubs: func(store *mock_db.MockDBStore) {
 				store.EXPECT().CreateAuthor(gomock.Any(), db.AuthorToCreate{
@@ -310,11 +528,11 @@ func TestUnitCreateAuthor(t *testing.T) {
 					FirstName: author.FirstName,
 					LastName:  author.LastName,
 					ImageName: "unique-" + author.ImageName,
-					UserID:    author.UserID,
-				}).Times(1).Return(primitive.NilObjectID, fmt.Errorf("author with name %s already exists", author.Name))
+					UserID:    user.ID,
+				}).Times(1).Return(primitive.NilObjectID, db.ErrDuplicateKey)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Equal(t, http.StatusConflict, recorder.Code)
 			},
 		},
 	}
@@ -325,6 +543,7 @@ func TestUnitCreateAuthor(t *testing.T) {
 			defer ctrl.Finish()
 
 			store := mock_db.NewMockDBStore(ctrl)
+			expectActiveSession(store)
 			tc.buildStubs(store)
 
 			server := newTestServer(t, store)
@@ -338,7 +557,7 @@ func TestUnitCreateAuthor(t *testing.T) {
 			request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
 			require.NoError(t, err)
 
-			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Email, time.Minute)
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.ID, db.UserRole, time.Minute)
 
 			server.router.ServeHTTP(recorder, request)
 			tc.checkResponse(recorder)
@@ -348,6 +567,7 @@ func TestUnitCreateAuthor(t *testing.T) {
 
 func TestUnitPatchAuthorByID(t *testing.T) {
 	user, _ := randomUser(t)
+	admin := randomAdmin(t)
 	author, _ := randomAuthor(t)
 	nonMatchingID := primitive.NewObjectID().Hex()
 
@@ -355,22 +575,26 @@ func TestUnitPatchAuthorByID(t *testing.T) {
 		Name:         "New author name",
 		FirstName:    "New first name",
 		LastName:     "New last name",
-		WebsiteURL:   "new website url",
-		InstagramURL: "new instagram url",
-		YoutubeURL:   "new youtube url",
+		WebsiteURL:   "https://www.new-website.com",
+		InstagramURL: "https://www.instagram.com/new/",
+		YoutubeURL:   "https://www.youtube.com/channel/new",
 		ImageName:    "new image name",
 	}
 
 	testCases := []struct {
 		name          string
 		id            string
+		userID        string
+		role          db.Role
 		body          gin.H
 		buildStubs    func(store *mock_db.MockDBStore)
 		checkResponse func(recorder *httptest.ResponseRecorder)
 	}{
 		{
-			name: "Success with full update of the author",
-			id:   author.ID,
+			name:   "Success with full update of the author",
+			id:     author.ID,
+			userID: author.UserID,
+			role:   db.UserRole,
 			body: gin.H{
 				"name":         fullAuthorPatch.Name,
 				"firstName":    fullAuthorPatch.FirstName,
@@ -391,8 +615,10 @@ func TestUnitPatchAuthorByID(t *testing.T) {
 			},
 		},
 		{
-			name: "Success with partial update of the author",
-			id:   author.ID,
+			name:   "Success with partial update of the author",
+			id:     author.ID,
+			userID: author.UserID,
+			role:   db.UserRole,
 			body: gin.H{
 				"name":      fullAuthorPatch.Name,
 				"firstName": fullAuthorPatch.FirstName,
@@ -409,8 +635,44 @@ func TestUnitPatchAuthorByID(t *testing.T) {
 			},
 		},
 		{
-			name: "Fail due to missing id",
-			id:   "",
+			name:   "Success with update by an admin not being the owner",
+			id:     author.ID,
+			userID: admin.ID,
+			role:   db.AdminRole,
+			body: gin.H{
+				"name": fullAuthorPatch.Name,
+			},
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().GetAuthorByID(gomock.Any(), author.ID).Times(1).Return(author, nil)
+				store.EXPECT().UpdateAuthorByID(gomock.Any(), author.ID, db.AuthorUpdate{
+					Name: fullAuthorPatch.Name,
+				}).Times(1).Return(int64(1), nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:   "Fail due to the user not being the owner",
+			id:     author.ID,
+			userID: user.ID,
+			role:   db.UserRole,
+			body: gin.H{
+				"name": fullAuthorPatch.Name,
+			},
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().GetAuthorByID(gomock.Any(), author.ID).Times(1).Return(author, nil)
+				store.EXPECT().UpdateAuthorByID(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:   "Fail due to missing id",
+			id:     "",
+			userID: author.UserID,
+			role:   db.UserRole,
 			body: gin.H{
 				"name": fullAuthorPatch.Name,
 			},
@@ -423,9 +685,11 @@ func TestUnitPatchAuthorByID(t *testing.T) {
 			},
 		},
 		{
-			name: "Fail due to missing body",
-			id:   author.ID,
-			body: gin.H{},
+			name:   "Fail due to missing body",
+			id:     author.ID,
+			userID: author.UserID,
+			role:   db.UserRole,
+			body:   gin.H{},
 			buildStubs: func(store *mock_db.MockDBStore) {
 				store.EXPECT().GetAuthorByID(gomock.Any(), author.ID).Times(0)
 				store.EXPECT().UpdateAuthorByID(gomock.Any(), author.ID, gomock.Any()).Times(0)
@@ -435,13 +699,15 @@ func TestUnitPatchAuthorByID(t *testing.T) {
 			},
 		},
 		{
-			name: "Fail due to the provided authorID not being valid",
-			id:   "not-valid-id",
+			name:   "Fail due to the provided authorID not being valid",
+			id:     "not-valid-id",
+			userID: author.UserID,
+			role:   db.UserRole,
 			body: gin.H{
 				"name": fullAuthorPatch.Name,
 			},
 			buildStubs: func(store *mock_db.MockDBStore) {
-				store.EXPECT().GetAuthorByID(gomock.Any(), "not-valid-id").Times(1).Return(db.Author{}, fmt.Errorf("failed to parse authorID"))
+				store.EXPECT().GetAuthorByID(gomock.Any(), "not-valid-id").Times(1).Return(db.Author{}, db.ErrInvalidID)
 				store.EXPECT().UpdateAuthorByID(gomock.Any(), "not-valid-id", gomock.Any()).Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
@@ -449,13 +715,15 @@ func TestUnitPatchAuthorByID(t *testing.T) {
 			},
 		},
 		{
-			name: "Fail due to no matching author for author ID",
-			id:   nonMatchingID,
+			name:   "Fail due to no matching author for author ID",
+			id:     nonMatchingID,
+			userID: author.UserID,
+			role:   db.UserRole,
 			body: gin.H{
 				"name": fullAuthorPatch.Name,
 			},
 			buildStubs: func(store *mock_db.MockDBStore) {
-				store.EXPECT().GetAuthorByID(gomock.Any(), nonMatchingID).Times(1).Return(db.Author{}, fmt.Errorf("failed to find author"))
+				store.EXPECT().GetAuthorByID(gomock.Any(), nonMatchingID).Times(1).Return(db.Author{}, db.ErrNotFound)
 				store.EXPECT().UpdateAuthorByID(gomock.Any(), nonMatchingID, gomock.Any()).Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
@@ -470,6 +738,7 @@ func TestUnitPatchAuthorByID(t *testing.T) {
 			defer ctrl.Finish()
 
 			store := mock_db.NewMockDBStore(ctrl)
+			expectActiveSession(store)
 			tc.buildStubs(store)
 
 			server := newTestServer(t, store)
@@ -483,7 +752,7 @@ func TestUnitPatchAuthorByID(t *testing.T) {
 			request, err := http.NewRequest(http.MethodPatch, url, bytes.NewReader(data))
 			require.NoError(t, err)
 
-			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Email, time.Minute)
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, tc.userID, tc.role, time.Minute)
 
 			server.router.ServeHTTP(recorder, request)
 			tc.checkResponse(recorder)
@@ -493,18 +762,23 @@ func TestUnitPatchAuthorByID(t *testing.T) {
 
 func TestUnitDeleteAuthorByID(t *testing.T) {
 	user, _ := randomUser(t)
+	admin := randomAdmin(t)
 	author, _ := randomAuthor(t)
 	nonMatchingID := primitive.NewObjectID().Hex()
 
 	testCases := []struct {
 		name          string
 		id            string
+		userID        string
+		role          db.Role
 		buildStubs    func(store *mock_db.MockDBStore)
 		checkResponse func(recorder *httptest.ResponseRecorder)
 	}{
 		{
-			name: "Success deleting the author",
-			id:   author.ID,
+			name:   "Success deleting the author",
+			id:     author.ID,
+			userID: author.UserID,
+			role:   db.UserRole,
 			buildStubs: func(store *mock_db.MockDBStore) {
 				store.EXPECT().GetAuthorByID(gomock.Any(), author.ID).Times(1).Return(author, nil)
 				store.EXPECT().DeleteAuthorByID(gomock.Any(), author.ID).Times(1).Return(int64(1), nil)
@@ -514,8 +788,36 @@ func TestUnitDeleteAuthorByID(t *testing.T) {
 			},
 		},
 		{
-			name: "Fail due to missing id",
-			id:   "",
+			name:   "Success deleting the author as an admin not being the owner",
+			id:     author.ID,
+			userID: admin.ID,
+			role:   db.AdminRole,
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().GetAuthorByID(gomock.Any(), author.ID).Times(1).Return(author, nil)
+				store.EXPECT().DeleteAuthorByID(gomock.Any(), author.ID).Times(1).Return(int64(1), nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:   "Fail due to the user not being the owner",
+			id:     author.ID,
+			userID: user.ID,
+			role:   db.UserRole,
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().GetAuthorByID(gomock.Any(), author.ID).Times(1).Return(author, nil)
+				store.EXPECT().DeleteAuthorByID(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:   "Fail due to missing id",
+			id:     "",
+			userID: author.UserID,
+			role:   db.UserRole,
 			buildStubs: func(store *mock_db.MockDBStore) {
 				store.EXPECT().GetAuthorByID(gomock.Any(), "").Times(0)
 				store.EXPECT().DeleteAuthorByID(gomock.Any(), "").Times(0)
@@ -525,10 +827,12 @@ func TestUnitDeleteAuthorByID(t *testing.T) {
 			},
 		},
 		{
-			name: "Fail due to the provided authorID not being valid",
-			id:   "not-valid-id",
+			name:   "Fail due to the provided authorID not being valid",
+			id:     "not-valid-id",
+			userID: author.UserID,
+			role:   db.UserRole,
 			buildStubs: func(store *mock_db.MockDBStore) {
-				store.EXPECT().GetAuthorByID(gomock.Any(), "not-valid-id").Times(1).Return(db.Author{}, fmt.Errorf("failed to parse authorID"))
+				store.EXPECT().GetAuthorByID(gomock.Any(), "not-valid-id").Times(1).Return(db.Author{}, db.ErrInvalidID)
 				store.EXPECT().DeleteAuthorByID(gomock.Any(), "not-valid-id").Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
@@ -536,10 +840,12 @@ func TestUnitDeleteAuthorByID(t *testing.T) {
 			},
 		},
 		{
-			name: "Fail due to no matching author for author ID",
-			id:   nonMatchingID,
+			name:   "Fail due to no matching author for author ID",
+			id:     nonMatchingID,
+			userID: author.UserID,
+			role:   db.UserRole,
 			buildStubs: func(store *mock_db.MockDBStore) {
-				store.EXPECT().GetAuthorByID(gomock.Any(), nonMatchingID).Times(1).Return(db.Author{}, fmt.Errorf("failed to find author"))
+				store.EXPECT().GetAuthorByID(gomock.Any(), nonMatchingID).Times(1).Return(db.Author{}, db.ErrNotFound)
 				store.EXPECT().DeleteAuthorByID(gomock.Any(), "not-valid-id").Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
@@ -554,6 +860,7 @@ func TestUnitDeleteAuthorByID(t *testing.T) {
 			defer ctrl.Finish()
 
 			store := mock_db.NewMockDBStore(ctrl)
+			expectActiveSession(store)
 			tc.buildStubs(store)
 
 			server := newTestServer(t, store)
@@ -564,7 +871,7 @@ func TestUnitDeleteAuthorByID(t *testing.T) {
 			request, err := http.NewRequest(http.MethodDelete, url, nil)
 			require.NoError(t, err)
 
-			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Email, time.Minute)
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, tc.userID, tc.role, time.Minute)
 
 			server.router.ServeHTTP(recorder, request)
 			tc.checkResponse(recorder)
@@ -23,9 +23,9 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/auth/login": {
+        "/admin/ingredients/import": {
             "post": {
-                "description": "A registered user is logged in with their email and matching password.",
+                "description": "The nutrients of common ingredients, which are bundled with the API, are imported into the ingredient catalogue. Missing catalogue ingredients are created and the nutrients of existing ones are updated.",
                 "consumes": [
                     "application/json"
                 ],
@@ -33,64 +33,49 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "admin"
                 ],
-                "summary": "Logs a user in",
-                "operationId": "auth-login-user",
+                "summary": "Import the bundled nutrient dataset",
+                "operationId": "admin-import-ingredient-nutrients",
                 "parameters": [
                     {
-                        "description": "Credentials for the login",
-                        "name": "userCredentials",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/authUserBody"
-                        }
+                        "type": "string",
+                        "description": "Authorization header for bearer token",
+                        "name": "authorization",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Login response with required tokens",
-                        "schema": {
-                            "$ref": "#/definitions/loginResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
+                        "description": "Number of inserted and updated catalogue ingredients",
                         "schema": {
-                            "$ref": "#/definitions/ErrorBadRequest"
+                            "$ref": "#/definitions/CatalogueImportResult"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/ErrorUnauthorized"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/ErrorNotFound"
+                            "$ref": "#/definitions/ProblemDetails"
                         }
                     },
-                    "406": {
-                        "description": "Not Acceptable",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
-                            "$ref": "#/definitions/ErrorNotAcceptable"
+                            "$ref": "#/definitions/ProblemDetails"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/ErrorInternalServerError"
+                            "$ref": "#/definitions/ProblemDetails"
                         }
                     }
                 }
             }
         },
-        "/auth/register": {
-            "post": {
-                "description": "A new user is registered by setting an unique email address and a password. The admins have to approve and active the user manually.",
+        "/admin/sessions/{id}": {
+            "delete": {
+                "description": "One session, which matches the ID, is revoked. Only admins are allowed to revoke sessions.",
                 "consumes": [
                     "application/json"
                 ],
@@ -98,43 +83,59 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "admin"
                 ],
-                "summary": "Registers a user",
-                "operationId": "auth-register-user",
+                "summary": "Revoke one session by ID",
+                "operationId": "admin-revoke-session-by-id",
                 "parameters": [
                     {
-                        "description": "Credentials for the registration",
-                        "name": "userCredentials",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/authUserBody"
-                        }
+                        "type": "string",
+                        "description": "Authorization header for bearer token",
+                        "name": "authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID of the session to revoke",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created"
+                    "200": {
+                        "description": "OK"
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/ErrorBadRequest"
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
                         }
                     },
-                    "406": {
-                        "description": "Not Acceptable",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/ErrorNotAcceptable"
+                            "$ref": "#/definitions/ProblemDetails"
                         }
                     }
                 }
             }
         },
-        "/authors": {
+        "/admin/users/pending": {
             "get": {
-                "description": "All authors are listed in a paginated manner",
+                "description": "All users, which have registered but have not been activated yet, are listed in a paginated manner. They are sorted by their registration.",
                 "consumes": [
                     "application/json"
                 ],
@@ -142,10 +143,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "authors"
+                    "admin"
                 ],
-                "summary": "List all authors",
-                "operationId": "authors-list-authors",
+                "summary": "List all pending registrations",
+                "operationId": "admin-list-pending-users",
                 "parameters": [
                     {
                         "type": "string",
@@ -155,10 +156,9 @@ const docTemplate = `{
                     },
                     {
                         "type": "integer",
-                        "description": "Offset for the pagination",
+                        "description": "Offset for the pagination, required without cursor",
                         "name": "page_id",
-                        "in": "query",
-                        "required": true
+                        "in": "query"
                     },
                     {
                         "type": "integer",
@@ -166,40 +166,51 @@ const docTemplate = `{
                         "name": "page_size",
                         "in": "query",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the next page, which was returned with the previous page",
+                        "name": "cursor",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "List of authors matching the given pagination parameters",
+                        "description": "Page of pending users matching the given pagination parameters",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/AuthorResponse"
-                            }
+                            "$ref": "#/definitions/UserListResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/ErrorBadRequest"
+                            "$ref": "#/definitions/ProblemDetails"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/ErrorUnauthorized"
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/ErrorInternalServerError"
+                            "$ref": "#/definitions/ProblemDetails"
                         }
                     }
                 }
-            },
+            }
+        },
+        "/admin/users/{id}/approve": {
             "post": {
-                "description": "Creates a new author",
+                "description": "The pending user, which matches the ID, is activated and can log in afterwards",
                 "consumes": [
                     "application/json"
                 ],
@@ -207,10 +218,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "authors"
+                    "admin"
                 ],
-                "summary": "Create new author",
-                "operationId": "authors-create-author",
+                "summary": "Approve a pending registration",
+                "operationId": "admin-approve-user",
                 "parameters": [
                     {
                         "type": "string",
@@ -219,46 +230,53 @@ const docTemplate = `{
                         "in": "header"
                     },
                     {
-                        "description": "Data for the author to create",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/AuthorToCreate"
-                        }
+                        "type": "string",
+                        "description": "ID of the user to approve",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "ID of the created author",
-                        "schema": {
-                            "type": "string"
-                        }
+                    "200": {
+                        "description": "OK"
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/ErrorBadRequest"
+                            "$ref": "#/definitions/ProblemDetails"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/ErrorUnauthorized"
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/ErrorInternalServerError"
+                            "$ref": "#/definitions/ProblemDetails"
                         }
                     }
                 }
             }
         },
-        "/authors/{id}": {
-            "get": {
-                "description": "One author, which matches the ID, is returned",
+        "/admin/users/{id}/reject": {
+            "post": {
+                "description": "The pending user, which matches the ID, is deleted. Users, which are already active, cannot be rejected.",
                 "consumes": [
                     "application/json"
                 ],
@@ -266,10 +284,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "authors"
+                    "admin"
                 ],
-                "summary": "Get one author by ID",
-                "operationId": "authors-get-author-by-id",
+                "summary": "Reject a pending registration",
+                "operationId": "admin-reject-user",
                 "parameters": [
                     {
                         "type": "string",
@@ -278,8 +296,8 @@ const docTemplate = `{
                         "in": "header"
                     },
                     {
-                        "type": "integer",
-                        "description": "ID of the desired author",
+                        "type": "string",
+                        "description": "ID of the user to reject",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -287,39 +305,44 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Author that matches the ID",
-                        "schema": {
-                            "$ref": "#/definitions/AuthorResponse"
-                        }
+                        "description": "OK"
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/ErrorBadRequest"
+                            "$ref": "#/definitions/ProblemDetails"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/ErrorUnauthorized"
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
                         }
                     },
                     "404": {
                         "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/ErrorNotFound"
+                            "$ref": "#/definitions/ProblemDetails"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/ErrorInternalServerError"
+                            "$ref": "#/definitions/ProblemDetails"
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "One author, which matches the ID, is deleted",
+            }
+        },
+        "/admin/users/{id}/sessions": {
+            "get": {
+                "description": "All sessions of the user, which matches the ID, are listed. Only admins are allowed to list sessions.",
                 "consumes": [
                     "application/json"
                 ],
@@ -327,10 +350,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "authors"
+                    "admin"
                 ],
-                "summary": "Delete one author by ID",
-                "operationId": "authors-delete-author-by-id",
+                "summary": "List all sessions of a user",
+                "operationId": "admin-list-user-sessions",
                 "parameters": [
                     {
                         "type": "string",
@@ -339,8 +362,8 @@ const docTemplate = `{
                         "in": "header"
                     },
                     {
-                        "type": "integer",
-                        "description": "ID of the desired author to patch",
+                        "type": "string",
+                        "description": "ID of the user",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -348,30 +371,42 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK"
+                        "description": "List of sessions of the user",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/SessionResponse"
+                            }
+                        }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/ErrorBadRequest"
+                            "$ref": "#/definitions/ProblemDetails"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/ErrorUnauthorized"
+                            "$ref": "#/definitions/ProblemDetails"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/ErrorNotFound"
+                            "$ref": "#/definitions/ProblemDetails"
                         }
                     }
                 }
             },
-            "patch": {
-                "description": "One author, which matches the ID, is modified with the provided patch",
+            "delete": {
+                "description": "All sessions of the user, which matches the ID, are revoked. Only admins are allowed to revoke sessions.",
                 "consumes": [
                     "application/json"
                 ],
@@ -379,10 +414,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "authors"
+                    "admin"
                 ],
-                "summary": "Patch one author by ID",
-                "operationId": "authors-patch-author-by-id",
+                "summary": "Revoke all sessions of a user",
+                "operationId": "admin-revoke-user-sessions",
                 "parameters": [
                     {
                         "type": "string",
@@ -391,20 +426,11 @@ const docTemplate = `{
                         "in": "header"
                     },
                     {
-                        "type": "integer",
-                        "description": "ID of the desired author to patch",
+                        "type": "string",
+                        "description": "ID of the user",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Patch for modifying the author",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/AuthorUpdate"
-                        }
                     }
                 ],
                 "responses": {
@@ -414,27 +440,33 @@ const docTemplate = `{
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/ErrorBadRequest"
+                            "$ref": "#/definitions/ProblemDetails"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/ErrorUnauthorized"
+                            "$ref": "#/definitions/ProblemDetails"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/ErrorNotFound"
+                            "$ref": "#/definitions/ProblemDetails"
                         }
                     }
                 }
             }
         },
-        "/heartbeat": {
-            "get": {
-                "description": "Check if the API is reachable with this route",
+        "/auth/login": {
+            "post": {
+                "description": "A registered user is logged in with their email and matching password.",
                 "consumes": [
                     "application/json"
                 ],
@@ -442,33 +474,75 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "heartbeat"
+                    "auth"
+                ],
+                "summary": "Logs a user in",
+                "operationId": "auth-login-user",
+                "parameters": [
+                    {
+                        "description": "Credentials for the login",
+                        "name": "userCredentials",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/authUserBody"
+                        }
+                    }
                 ],
-                "summary": "Check heartbeat",
                 "responses": {
                     "200": {
-                        "description": "Success",
+                        "description": "Login response with required tokens",
                         "schema": {
-                            "$ref": "#/definitions/heartbeatResponse"
+                            "$ref": "#/definitions/loginResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden, the user has not been activated yet",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
                         }
                     }
                 }
             }
         },
-        "/images": {
+        "/auth/logout": {
             "post": {
-                "description": "Saves an image to the filesystem. Send a request with\n` + "`" + `const formData = new FormData();` + "`" + `\n` + "`" + `formData.append('image', image);` + "`" + `\nAdd the header ` + "`" + `'ContentType': 'multipart/form-data';` + "`" + `",
+                "description": "The given session of the logged in user is revoked, so its access and refresh tokens can no longer be used.",
                 "consumes": [
-                    "multipart/form-data"
+                    "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "images"
+                    "auth"
                 ],
-                "summary": "Saves an image",
-                "operationId": "images-save",
+                "summary": "Logs a user out",
+                "operationId": "auth-logout-user",
                 "parameters": [
                     {
                         "type": "string",
@@ -477,11 +551,13 @@ const docTemplate = `{
                         "in": "header"
                     },
                     {
-                        "type": "integer",
-                        "description": "Number of elements in one page",
-                        "name": "page_size",
-                        "in": "query",
-                        "required": true
+                        "description": "Session to revoke",
+                        "name": "logoutData",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/logoutBody"
+                        }
                     }
                 ],
                 "responses": {
@@ -491,27 +567,39 @@ const docTemplate = `{
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/ErrorBadRequest"
+                            "$ref": "#/definitions/ProblemDetails"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/ErrorUnauthorized"
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/ErrorInternalServerError"
+                            "$ref": "#/definitions/ProblemDetails"
                         }
                     }
                 }
             }
         },
-        "/images/{imageName}": {
-            "get": {
-                "description": "Gets an image with the given image name from the file system",
+        "/auth/logout-all": {
+            "post": {
+                "description": "All sessions of the logged in user are revoked.",
                 "consumes": [
                     "application/json"
                 ],
@@ -519,59 +607,143 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "images"
+                    "auth"
                 ],
-                "summary": "Gets an image",
-                "operationId": "images-get",
+                "summary": "Logs a user out everywhere",
+                "operationId": "auth-logout-user-everywhere",
                 "parameters": [
                     {
                         "type": "string",
                         "description": "Authorization header for bearer token",
                         "name": "authorization",
                         "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
                     },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/refresh": {
+            "post": {
+                "description": "A new access token is issued for a valid refresh token of an active session.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Refreshes the access token",
+                "operationId": "auth-refresh-access-token",
+                "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Number of elements in one page",
-                        "name": "page_size",
-                        "in": "query",
-                        "required": true
+                        "description": "Session and refresh token returned by the login",
+                        "name": "refreshData",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/refreshTokenBody"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Image for the given image name",
+                        "description": "New access token",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "type": "integer"
-                            }
+                            "$ref": "#/definitions/refreshTokenResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/ErrorBadRequest"
+                            "$ref": "#/definitions/ProblemDetails"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/ErrorUnauthorized"
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/ErrorInternalServerError"
+                            "$ref": "#/definitions/ProblemDetails"
                         }
                     }
                 }
             }
         },
-        "/recipes": {
+        "/auth/register": {
+            "post": {
+                "description": "A new user is registered by setting an unique email address and a password. The admins have to approve and active the user manually.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Registers a user",
+                "operationId": "auth-register-user",
+                "parameters": [
+                    {
+                        "description": "Credentials for the registration",
+                        "name": "userCredentials",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/authUserBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict, a user with this email already exists",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    }
+                }
+            }
+        },
+        "/authors": {
             "get": {
-                "description": "All recipes are listed in a paginated manner",
+                "description": "All authors are listed in a paginated manner. They are sorted by name, if no sorting is provided.",
                 "consumes": [
                     "application/json"
                 ],
@@ -579,10 +751,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "recipes"
+                    "authors"
                 ],
-                "summary": "List all recipes",
-                "operationId": "recipes-list-recipes",
+                "summary": "List all authors",
+                "operationId": "authors-list-authors",
                 "parameters": [
                     {
                         "type": "string",
@@ -592,10 +764,9 @@ const docTemplate = `{
                     },
                     {
                         "type": "integer",
-                        "description": "Offset for the pagination",
+                        "description": "Offset for the pagination, required without cursor",
                         "name": "page_id",
-                        "in": "query",
-                        "required": true
+                        "in": "query"
                     },
                     {
                         "type": "integer",
@@ -603,40 +774,75 @@ const docTemplate = `{
                         "name": "page_size",
                         "in": "query",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the next page, which was returned with the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "enum": [
+                                "name",
+                                "firstName",
+                                "lastName",
+                                "recipeCount",
+                                "createdAt",
+                                "modifiedAt"
+                            ],
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Keys to sort by",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "enum": [
+                                "asc",
+                                "desc"
+                            ],
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Sort order per key",
+                        "name": "order",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "List of recipes matching the given pagination parameters",
+                        "description": "Page of authors matching the given pagination parameters",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/RecipeResponse"
-                            }
+                            "$ref": "#/definitions/AuthorListResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/ErrorBadRequest"
+                            "$ref": "#/definitions/ProblemDetails"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/ErrorUnauthorized"
+                            "$ref": "#/definitions/ProblemDetails"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/ErrorInternalServerError"
+                            "$ref": "#/definitions/ProblemDetails"
                         }
                     }
                 }
             },
             "post": {
-                "description": "Creates a new recipe",
+                "description": "Creates a new author, which belongs to the logged in user",
                 "consumes": [
                     "application/json"
                 ],
@@ -644,10 +850,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "recipes"
+                    "authors"
                 ],
-                "summary": "Create new recipe",
-                "operationId": "recipes-create-recipe",
+                "summary": "Create new author",
+                "operationId": "authors-create-author",
                 "parameters": [
                     {
                         "type": "string",
@@ -656,18 +862,18 @@ const docTemplate = `{
                         "in": "header"
                     },
                     {
-                        "description": "Data for the recipe to create",
+                        "description": "Data for the author to create",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/RecipeToCreate"
+                            "$ref": "#/definitions/AuthorToCreate"
                         }
                     }
                 ],
                 "responses": {
                     "201": {
-                        "description": "ID of the created recipe",
+                        "description": "ID of the created author",
                         "schema": {
                             "type": "string"
                         }
@@ -675,27 +881,39 @@ const docTemplate = `{
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/ErrorBadRequest"
+                            "$ref": "#/definitions/ProblemDetails"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/ErrorUnauthorized"
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict, an author with this name already exists",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/ErrorInternalServerError"
+                            "$ref": "#/definitions/ProblemDetails"
                         }
                     }
                 }
             }
         },
-        "/recipes/{id}": {
+        "/authors/{id}": {
             "get": {
-                "description": "One recipe, which matches the ID, is returned",
+                "description": "One author, which matches the ID, is returned",
                 "consumes": [
                     "application/json"
                 ],
@@ -703,10 +921,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "recipes"
+                    "authors"
                 ],
-                "summary": "Get one recipe by ID",
-                "operationId": "recipes-get-recipe-by-id",
+                "summary": "Get one author by ID",
+                "operationId": "authors-get-author-by-id",
                 "parameters": [
                     {
                         "type": "string",
@@ -716,7 +934,7 @@ const docTemplate = `{
                     },
                     {
                         "type": "integer",
-                        "description": "ID of the desired recipe",
+                        "description": "ID of the desired author",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -724,39 +942,39 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Recipe that matches the ID",
+                        "description": "Author that matches the ID",
                         "schema": {
-                            "$ref": "#/definitions/RecipeResponse"
+                            "$ref": "#/definitions/AuthorResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/ErrorBadRequest"
+                            "$ref": "#/definitions/ProblemDetails"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/ErrorUnauthorized"
+                            "$ref": "#/definitions/ProblemDetails"
                         }
                     },
                     "404": {
                         "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/ErrorNotFound"
+                            "$ref": "#/definitions/ProblemDetails"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/ErrorInternalServerError"
+                            "$ref": "#/definitions/ProblemDetails"
                         }
                     }
                 }
             },
             "delete": {
-                "description": "One recipe, which matches the ID, is deleted",
+                "description": "One author, which matches the ID, is deleted. Only the user, who created the author, or an admin is allowed to delete it.",
                 "consumes": [
                     "application/json"
                 ],
@@ -764,10 +982,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "recipes"
+                    "authors"
                 ],
-                "summary": "Delete one recipe by ID",
-                "operationId": "recipes-delete-recipe-by-id",
+                "summary": "Delete one author by ID",
+                "operationId": "authors-delete-author-by-id",
                 "parameters": [
                     {
                         "type": "string",
@@ -777,7 +995,7 @@ const docTemplate = `{
                     },
                     {
                         "type": "integer",
-                        "description": "ID of the desired recipe to patch",
+                        "description": "ID of the desired author to patch",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -790,25 +1008,43 @@ const docTemplate = `{
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/ErrorBadRequest"
+                            "$ref": "#/definitions/ProblemDetails"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/ErrorUnauthorized"
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
                         }
                     },
                     "404": {
                         "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/ErrorNotFound"
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict, the author is still referenced by at least one recipe",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
                         }
                     }
                 }
             },
             "patch": {
-                "description": "One recipe, which matches the ID, is modified with the provided patch",
+                "description": "One author, which matches the ID, is modified with the provided patch. Only the user, who created the author, or an admin is allowed to patch it.",
                 "consumes": [
                     "application/json"
                 ],
@@ -816,10 +1052,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "recipes"
+                    "authors"
                 ],
-                "summary": "Patch one recipe by ID",
-                "operationId": "recipes-patch-recipe-by-id",
+                "summary": "Patch one author by ID",
+                "operationId": "authors-patch-author-by-id",
                 "parameters": [
                     {
                         "type": "string",
@@ -829,18 +1065,18 @@ const docTemplate = `{
                     },
                     {
                         "type": "integer",
-                        "description": "ID of the desired recipe to patch",
+                        "description": "ID of the desired author to patch",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Patch for modifying the recipe",
+                        "description": "Patch for modifying the author",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/RecipeUpdate"
+                            "$ref": "#/definitions/AuthorUpdate"
                         }
                     }
                 ],
@@ -851,265 +1087,3843 @@ const docTemplate = `{
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/ErrorBadRequest"
+                            "$ref": "#/definitions/ProblemDetails"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/ErrorUnauthorized"
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
                         }
                     },
                     "404": {
                         "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/ErrorNotFound"
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict, an author with this name already exists",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "AuthorResponse": {
-            "type": "object",
-            "required": [
-                "name"
-            ],
-            "properties": {
-                "createdAt": {
-                    "type": "integer",
-                    "example": 1714462120
-                },
-                "firstName": {
-                    "type": "string",
-                    "example": "Moe"
-                },
-                "id": {
-                    "type": "string",
-                    "example": "660c4b99bc1bc4aabe126cd1"
-                },
-                "imageName": {
-                    "type": "string",
-                    "example": "moezarella.png"
-                },
-                "instagramUrl": {
-                    "type": "string",
-                    "example": "https://wwww.instagram.com/moezarella/"
-                },
-                "lastName": {
-                    "type": "string",
-                    "example": "Zarella"
-                },
-                "modifiedAt": {
-                    "type": "integer",
-                    "example": 1714462120
-                },
-                "name": {
-                    "type": "string",
-                    "example": "Moe Zarella"
-                },
-                "recipeCount": {
-                    "type": "integer",
-                    "example": 5
-                },
-                "userCreated": {
-                    "$ref": "#/definitions/UserResponse"
-                },
-                "userId": {
-                    "type": "string",
-                    "example": "660c4b99bc1bc4aabe3e6cd1"
-                },
-                "websiteUrl": {
-                    "type": "string",
-                    "example": "https://www.moezarella.com"
-                },
-                "youtubeUrl": {
-                    "type": "string",
-                    "example": "https://www.youtube.com/channel/UCy8asdgasdf7RcC6OZffZA"
-                }
-            }
         },
-        "AuthorToCreate": {
-            "type": "object",
-            "required": [
-                "name",
-                "userId"
-            ],
+        "/authors/{id}/recipes": {
+            "get": {
+                "description": "All recipes of the author, which matches the ID, are listed in a paginated manner. They are sorted by name, if no sorting is provided.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "authors"
+                ],
+                "summary": "List all recipes of one author",
+                "operationId": "authors-list-author-recipes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization header for bearer token",
+                        "name": "authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID of the desired author",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset for the pagination, required without cursor",
+                        "name": "page_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of elements in one page",
+                        "name": "page_size",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the next page, which was returned with the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "enum": [
+                                "name",
+                                "timeM",
+                                "category",
+                                "averageRating",
+                                "ratingCount",
+                                "createdAt",
+                                "modifiedAt"
+                            ],
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Keys to sort by",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "enum": [
+                                "asc",
+                                "desc"
+                            ],
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Sort order per key",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "metric",
+                            "imperial"
+                        ],
+                        "type": "string",
+                        "description": "Unit system to convert the ingredient amounts to",
+                        "name": "units",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Page of recipes of the author",
+                        "schema": {
+                            "$ref": "#/definitions/RecipeListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    }
+                }
+            }
+        },
+        "/heartbeat": {
+            "get": {
+                "description": "Check if the API is reachable with this route",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "heartbeat"
+                ],
+                "summary": "Check heartbeat",
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "$ref": "#/definitions/heartbeatResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/images": {
+            "post": {
+                "description": "Saves an image to the filesystem. Send a request with\n` + "`" + `const formData = new FormData();` + "`" + `\n` + "`" + `formData.append('image', image);` + "`" + `\nAdd the header ` + "`" + `'ContentType': 'multipart/form-data';` + "`" + `",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "images"
+                ],
+                "summary": "Saves an image",
+                "operationId": "images-save",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization header for bearer token",
+                        "name": "authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of elements in one page",
+                        "name": "page_size",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    }
+                }
+            }
+        },
+        "/images/{imageName}": {
+            "get": {
+                "description": "Gets an image with the given image name from the file system",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "images"
+                ],
+                "summary": "Gets an image",
+                "operationId": "images-get",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization header for bearer token",
+                        "name": "authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of elements in one page",
+                        "name": "page_size",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Image for the given image name",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "integer"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    }
+                }
+            }
+        },
+        "/ingredients": {
+            "get": {
+                "description": "All ingredients of the ingredient catalogue are listed in a paginated manner. They are sorted by name, if no sorting is provided.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ingredients"
+                ],
+                "summary": "List all catalogue ingredients",
+                "operationId": "ingredients-list-ingredients",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization header for bearer token",
+                        "name": "authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset for the pagination, required without cursor",
+                        "name": "page_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of elements in one page",
+                        "name": "page_size",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the next page, which was returned with the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "enum": [
+                                "name",
+                                "defaultUnit",
+                                "createdAt",
+                                "modifiedAt"
+                            ],
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Keys to sort by",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "enum": [
+                                "asc",
+                                "desc"
+                            ],
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Sort order per key",
+                        "name": "order",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Page of catalogue ingredients matching the given pagination parameters",
+                        "schema": {
+                            "$ref": "#/definitions/IngredientListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Creates a new ingredient in the ingredient catalogue. The name and the aliases are stored trimmed and lowercase.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ingredients"
+                ],
+                "summary": "Create new catalogue ingredient",
+                "operationId": "ingredients-create-ingredient",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization header for bearer token",
+                        "name": "authorization",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Data for the catalogue ingredient to create",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/CatalogueIngredientToCreate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "ID of the created catalogue ingredient",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict, a catalogue ingredient with this name already exists",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    }
+                }
+            }
+        },
+        "/ingredients/autocomplete": {
+            "get": {
+                "description": "Catalogue ingredients, whose name or alias starts with the query, are returned sorted by name. Clients use them to link the ingredients of a recipe to the catalogue.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ingredients"
+                ],
+                "summary": "Autocomplete ingredient names",
+                "operationId": "ingredients-autocomplete-ingredients",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization header for bearer token",
+                        "name": "authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Beginning of the ingredient name",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of suggestions, 10 by default",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Catalogue ingredients starting with the query",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/CatalogueIngredient"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    }
+                }
+            }
+        },
+        "/ingredients/{id}": {
+            "get": {
+                "description": "One catalogue ingredient, which matches the ID, is returned",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ingredients"
+                ],
+                "summary": "Get one catalogue ingredient by ID",
+                "operationId": "ingredients-get-ingredient-by-id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization header for bearer token",
+                        "name": "authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID of the desired catalogue ingredient",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Catalogue ingredient that matches the ID",
+                        "schema": {
+                            "$ref": "#/definitions/CatalogueIngredient"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "One catalogue ingredient, which matches the ID, is deleted. Only users, who are allowed to manage content, are allowed to delete it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ingredients"
+                ],
+                "summary": "Delete one catalogue ingredient by ID",
+                "operationId": "ingredients-delete-ingredient-by-id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization header for bearer token",
+                        "name": "authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID of the desired catalogue ingredient to delete",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict, the ingredient is still linked to at least one recipe or substitution",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "One catalogue ingredient, which matches the ID, is modified with the provided patch. The catalogue is shared by all users, so only users, who are allowed to manage content, are allowed to patch it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ingredients"
+                ],
+                "summary": "Patch one catalogue ingredient by ID",
+                "operationId": "ingredients-patch-ingredient-by-id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization header for bearer token",
+                        "name": "authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID of the desired catalogue ingredient to patch",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Patch for modifying the catalogue ingredient",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/CatalogueIngredientUpdate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict, a catalogue ingredient with this name already exists",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    }
+                }
+            }
+        },
+        "/recipes": {
+            "get": {
+                "description": "All recipes, which match the optional filters, are listed in a paginated manner. They are sorted by name, if no sorting is provided.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recipes"
+                ],
+                "summary": "List all recipes",
+                "operationId": "recipes-list-recipes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization header for bearer token",
+                        "name": "authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset for the pagination, required without cursor",
+                        "name": "page_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of elements in one page",
+                        "name": "page_size",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the next page, which was returned with the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Part of the recipe name (case-insensitive)",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "breakfast",
+                            "main",
+                            "desert",
+                            "smoothie",
+                            "baby",
+                            "drink"
+                        ],
+                        "type": "string",
+                        "description": "Category of the recipes",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID of the author of the recipes",
+                        "name": "author_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID of the user, who created the recipes",
+                        "name": "user_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum preparation time in minutes",
+                        "name": "max_time_m",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Ingredients, which must all be part of the recipes",
+                        "name": "ingredients",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Ingredients, which must not be part of the recipes",
+                        "name": "excluded_ingredients",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Tags, which must all be part of the recipes (case-insensitive)",
+                        "name": "tags",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "enum": [
+                                "gluten-free",
+                                "nut-free",
+                                "soy-free",
+                                "raw",
+                                "high-protein"
+                            ],
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Dietary labels, which must all be part of the recipes",
+                        "name": "dietary_labels",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "enum": [
+                                "gluten",
+                                "nuts",
+                                "soy",
+                                "sesame"
+                            ],
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Allergens, which must not be contained in the ingredients of the recipes. Recipes, which were created before the allergen detection, are not matched",
+                        "name": "excluded_allergens",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "IDs of catalogue ingredients, which must all be part of the recipes",
+                        "name": "ingredient_ids",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "enum": [
+                                "name",
+                                "timeM",
+                                "category",
+                                "averageRating",
+                                "ratingCount",
+                                "createdAt",
+                                "modifiedAt"
+                            ],
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Keys to sort by",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "enum": [
+                                "asc",
+                                "desc"
+                            ],
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Sort order per key",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "metric",
+                            "imperial"
+                        ],
+                        "type": "string",
+                        "description": "Unit system to convert the ingredient amounts to",
+                        "name": "units",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Page of recipes matching the given pagination parameters",
+                        "schema": {
+                            "$ref": "#/definitions/RecipeListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Creates a new recipe, which belongs to the logged in user. The allergens of the recipe are derived from its ingredients",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recipes"
+                ],
+                "summary": "Create new recipe",
+                "operationId": "recipes-create-recipe",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization header for bearer token",
+                        "name": "authorization",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Data for the recipe to create",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/RecipeToCreate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "ID of the created recipe",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found, a catalogue ingredient of the ingredients does not exist",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict, a recipe with this name already exists",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity, a dietary label contradicts the allergens of the ingredients",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    }
+                }
+            }
+        },
+        "/recipes/search": {
+            "get": {
+                "description": "Recipes are searched by the words in their name, ingredients and preparation steps and are listed in a paginated manner, ordered by their relevance. Phrases can be searched in double quotes and words can be excluded with a leading minus.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recipes"
+                ],
+                "summary": "Search recipes",
+                "operationId": "recipes-search-recipes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization header for bearer token",
+                        "name": "authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search query with optional phrases in double quotes and words excluded by a leading minus",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset for the pagination, required without cursor",
+                        "name": "page_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of elements in one page",
+                        "name": "page_size",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the next page, which was returned with the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "metric",
+                            "imperial"
+                        ],
+                        "type": "string",
+                        "description": "Unit system to convert the ingredient amounts to",
+                        "name": "units",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Page of recipes matching the search, ordered by relevance",
+                        "schema": {
+                            "$ref": "#/definitions/RecipeSearchListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    }
+                }
+            }
+        },
+        "/recipes/{id}": {
+            "get": {
+                "description": "One recipe, which matches the ID, is returned with its nutrition. The ingredient amounts and the nutrition are scaled, if servings are requested",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recipes"
+                ],
+                "summary": "Get one recipe by ID",
+                "operationId": "recipes-get-recipe-by-id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization header for bearer token",
+                        "name": "authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID of the desired recipe",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Number of servings to scale the ingredient amounts to",
+                        "name": "servings",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "metric",
+                            "imperial"
+                        ],
+                        "type": "string",
+                        "description": "Unit system to convert the ingredient amounts to",
+                        "name": "units",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Recipe that matches the ID",
+                        "schema": {
+                            "$ref": "#/definitions/RecipeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "One recipe, which matches the ID, is deleted. Only the user, who created the recipe, or an admin is allowed to delete it. The reviews of the recipe are deleted with it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recipes"
+                ],
+                "summary": "Delete one recipe by ID",
+                "operationId": "recipes-delete-recipe-by-id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization header for bearer token",
+                        "name": "authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID of the desired recipe to patch",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "One recipe, which matches the ID, is modified with the provided patch. Only the user, who created the recipe, or an admin is allowed to patch it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recipes"
+                ],
+                "summary": "Patch one recipe by ID",
+                "operationId": "recipes-patch-recipe-by-id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization header for bearer token",
+                        "name": "authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID of the desired recipe to patch",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Patch for modifying the recipe",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/RecipeUpdate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict, a recipe with this name already exists",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity, a dietary label contradicts the allergens of the ingredients",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    }
+                }
+            }
+        },
+        "/recipes/{id}/reviews": {
+            "get": {
+                "description": "All reviews of the recipe, which matches the ID, are listed in a paginated manner. They are sorted by their creation, if no sorting is provided.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recipes"
+                ],
+                "summary": "List the reviews of a recipe",
+                "operationId": "recipes-list-recipe-reviews",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization header for bearer token",
+                        "name": "authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID of the desired recipe",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset for the pagination, required without cursor",
+                        "name": "page_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of elements in one page",
+                        "name": "page_size",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the next page, which was returned with the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "enum": [
+                                "rating",
+                                "createdAt",
+                                "modifiedAt"
+                            ],
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Keys to sort by",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "enum": [
+                                "asc",
+                                "desc"
+                            ],
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Sort order per key",
+                        "name": "order",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Page of reviews of the recipe",
+                        "schema": {
+                            "$ref": "#/definitions/ReviewListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Creates a new review of the recipe, which matches the ID, for the current user. Each user can review a recipe once.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recipes"
+                ],
+                "summary": "Create new review of a recipe",
+                "operationId": "recipes-create-recipe-review",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization header for bearer token",
+                        "name": "authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID of the recipe to review",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Data for the review to create",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/ReviewToCreate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "ID of the created review",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict, the user already reviewed the recipe",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    }
+                }
+            }
+        },
+        "/recipes/{id}/substitutions": {
+            "get": {
+                "description": "The substitutions of the catalogue ingredients, which are linked to the ingredients of the recipe, are returned with the amount of the substitute. Substitutions, whose ratio applies to another unit than the one of the ingredient, are skipped. The amounts are scaled, if servings are requested",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recipes"
+                ],
+                "summary": "List the substitutions for the ingredients of a recipe",
+                "operationId": "recipes-list-recipe-substitutions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization header for bearer token",
+                        "name": "authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID of the desired recipe",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Number of servings to scale the ingredient amounts to",
+                        "name": "servings",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Substitutions for the ingredients of the recipe",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/RecipeSubstitution"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    }
+                }
+            }
+        },
+        "/reviews/{id}": {
+            "get": {
+                "description": "One review, which matches the ID, is returned",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reviews"
+                ],
+                "summary": "Get one review by ID",
+                "operationId": "reviews-get-review-by-id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization header for bearer token",
+                        "name": "authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID of the desired review",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Review that matches the ID",
+                        "schema": {
+                            "$ref": "#/definitions/ReviewResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "One review, which matches the ID, is deleted. Only the user, who created the review, or an admin is allowed to delete it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reviews"
+                ],
+                "summary": "Delete one review by ID",
+                "operationId": "reviews-delete-review-by-id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization header for bearer token",
+                        "name": "authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID of the desired review to delete",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "One review, which matches the ID, is modified with the provided patch. An empty text clears the text of the review. Only the user, who created the review, is allowed to patch it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reviews"
+                ],
+                "summary": "Patch one review by ID",
+                "operationId": "reviews-patch-review-by-id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization header for bearer token",
+                        "name": "authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID of the desired review to patch",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Patch for modifying the review",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/ReviewUpdate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    }
+                }
+            }
+        },
+        "/substitutions": {
+            "get": {
+                "description": "All ingredient substitutions are listed in a paginated manner. They are sorted by the name of the replaced ingredient, if no sorting is provided.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "substitutions"
+                ],
+                "summary": "List all substitutions",
+                "operationId": "substitutions-list-substitutions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization header for bearer token",
+                        "name": "authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset for the pagination, required without cursor",
+                        "name": "page_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of elements in one page",
+                        "name": "page_size",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the next page, which was returned with the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID of the catalogue ingredient, which is replaced",
+                        "name": "ingredient_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "enum": [
+                                "ingredientName",
+                                "substituteName",
+                                "ratio",
+                                "createdAt",
+                                "modifiedAt"
+                            ],
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Keys to sort by",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "enum": [
+                                "asc",
+                                "desc"
+                            ],
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Sort order per key",
+                        "name": "order",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Page of substitutions matching the given pagination parameters",
+                        "schema": {
+                            "$ref": "#/definitions/SubstitutionListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Creates a new substitution of one catalogue ingredient with another one. Both catalogue ingredients have to exist. A substitute in another unit requires the source unit, which the ratio applies to.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "substitutions"
+                ],
+                "summary": "Create new substitution",
+                "operationId": "substitutions-create-substitution",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization header for bearer token",
+                        "name": "authorization",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Data for the substitution to create",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/SubstitutionToCreate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "ID of the created substitution",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict, the substitution already exists",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity, a catalogue ingredient does not exist",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    }
+                }
+            }
+        },
+        "/substitutions/{id}": {
+            "get": {
+                "description": "One substitution, which matches the ID, is returned",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "substitutions"
+                ],
+                "summary": "Get one substitution by ID",
+                "operationId": "substitutions-get-substitution-by-id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization header for bearer token",
+                        "name": "authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID of the desired substitution",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Substitution that matches the ID",
+                        "schema": {
+                            "$ref": "#/definitions/Substitution"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "One substitution, which matches the ID, is deleted. Only users, who are allowed to manage content, are allowed to delete it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "substitutions"
+                ],
+                "summary": "Delete one substitution by ID",
+                "operationId": "substitutions-delete-substitution-by-id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization header for bearer token",
+                        "name": "authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID of the desired substitution to delete",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "One substitution, which matches the ID, is modified with the provided patch. Substitutions are shared by all users, so only users, who are allowed to manage content, are allowed to patch them.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "substitutions"
+                ],
+                "summary": "Patch one substitution by ID",
+                "operationId": "substitutions-patch-substitution-by-id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization header for bearer token",
+                        "name": "authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID of the desired substitution to patch",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Patch for modifying the substitution",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/SubstitutionUpdate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    }
+                }
+            }
+        },
+        "/tags": {
+            "get": {
+                "description": "All tags of the recipes are listed with the number of recipes, which are tagged with them. The most used tags come first.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "List all tags",
+                "operationId": "tags-list-tags",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization header for bearer token",
+                        "name": "authorization",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Tags with their usage counts",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/TagCount"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    }
+                }
+            }
+        },
+        "/users": {
+            "get": {
+                "description": "All users are listed in a paginated manner. They are sorted by email, if no sorting is provided. Only admins are allowed to list users.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "List all users",
+                "operationId": "users-list-users",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization header for bearer token",
+                        "name": "authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset for the pagination, required without cursor",
+                        "name": "page_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of elements in one page",
+                        "name": "page_size",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the next page, which was returned with the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "enum": [
+                                "email",
+                                "role",
+                                "createdAt",
+                                "modifiedAt"
+                            ],
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Keys to sort by",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "enum": [
+                                "asc",
+                                "desc"
+                            ],
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Sort order per key",
+                        "name": "order",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Page of users matching the given pagination parameters",
+                        "schema": {
+                            "$ref": "#/definitions/UserListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me": {
+            "get": {
+                "description": "The user, which belongs to the access token, is returned",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Get the logged in user",
+                "operationId": "users-get-current-user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization header for bearer token",
+                        "name": "authorization",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Logged in user",
+                        "schema": {
+                            "$ref": "#/definitions/UserResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}": {
+            "get": {
+                "description": "One user, which matches the ID, is returned. Users can only get themselves unless they are admins.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Get one user by ID",
+                "operationId": "users-get-user-by-id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization header for bearer token",
+                        "name": "authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID of the desired user",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User that matches the ID",
+                        "schema": {
+                            "$ref": "#/definitions/UserResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "One user, which matches the ID, is deleted together with all of its sessions. Users can only delete themselves unless they are admins.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Delete one user by ID",
+                "operationId": "users-delete-user-by-id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization header for bearer token",
+                        "name": "authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID of the desired user to delete",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict, the user is still referenced by recipes, authors or reviews",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "The email address or the password of the user, which matches the ID, is modified. Users can only patch themselves unless they are admins.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Patch one user by ID",
+                "operationId": "users-patch-user-by-id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization header for bearer token",
+                        "name": "authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID of the desired user to patch",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Patch for modifying the user",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/userUpdateBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict, a user with this email already exists",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/recipes": {
+            "get": {
+                "description": "All recipes, which were created by the user matching the ID, are listed in a paginated manner. They are sorted by name, if no sorting is provided.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "List all recipes of one user",
+                "operationId": "users-list-user-recipes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization header for bearer token",
+                        "name": "authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID of the desired user",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset for the pagination, required without cursor",
+                        "name": "page_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of elements in one page",
+                        "name": "page_size",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the next page, which was returned with the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "enum": [
+                                "name",
+                                "timeM",
+                                "category",
+                                "averageRating",
+                                "ratingCount",
+                                "createdAt",
+                                "modifiedAt"
+                            ],
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Keys to sort by",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "enum": [
+                                "asc",
+                                "desc"
+                            ],
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Sort order per key",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "metric",
+                            "imperial"
+                        ],
+                        "type": "string",
+                        "description": "Unit system to convert the ingredient amounts to",
+                        "name": "units",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Page of recipes of the user",
+                        "schema": {
+                            "$ref": "#/definitions/RecipeListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ProblemDetails"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "AuthorListResponse": {
+            "type": "object",
+            "properties": {
+                "hasNext": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/AuthorResponse"
+                    }
+                },
+                "links": {
+                    "$ref": "#/definitions/PageLinks"
+                },
+                "nextCursor": {
+                    "type": "string",
+                    "example": "SQAAAARrABMAAAACMAAFAAAAbmFtZQAABG8ADAAAABAwAAEAAAAABHYAFQAAAAIwAAkAAABQYW5jYWtlcwAAB2lkAGYMS5m8G8SqvhJs0QA"
+                },
+                "page": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "pageSize": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "totalCount": {
+                    "type": "integer",
+                    "example": 42
+                }
+            }
+        },
+        "AuthorResponse": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "createdAt": {
+                    "type": "integer",
+                    "example": 1714462120
+                },
+                "firstName": {
+                    "type": "string",
+                    "example": "Moe"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "660c4b99bc1bc4aabe126cd1"
+                },
+                "imageName": {
+                    "type": "string",
+                    "example": "moezarella.png"
+                },
+                "instagramUrl": {
+                    "type": "string",
+                    "example": "https://wwww.instagram.com/moezarella/"
+                },
+                "lastName": {
+                    "type": "string",
+                    "example": "Zarella"
+                },
+                "modifiedAt": {
+                    "type": "integer",
+                    "example": 1714462120
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Moe Zarella"
+                },
+                "recipeCount": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "userCreated": {
+                    "$ref": "#/definitions/UserResponse"
+                },
+                "userId": {
+                    "type": "string",
+                    "example": "660c4b99bc1bc4aabe3e6cd1"
+                },
+                "websiteUrl": {
+                    "type": "string",
+                    "example": "https://www.moezarella.com"
+                },
+                "youtubeUrl": {
+                    "type": "string",
+                    "example": "https://www.youtube.com/channel/UCy8asdgasdf7RcC6OZffZA"
+                }
+            }
+        },
+        "AuthorToCreate": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "firstName": {
+                    "type": "string",
+                    "example": "Moe"
+                },
+                "imageName": {
+                    "type": "string",
+                    "example": "moezarella.png"
+                },
+                "instagramUrl": {
+                    "type": "string",
+                    "example": "https://wwww.instagram.com/moezarella/"
+                },
+                "lastName": {
+                    "type": "string",
+                    "example": "Zarella"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Moe Zarella"
+                },
+                "websiteUrl": {
+                    "type": "string",
+                    "example": "https://www.moezarella.com"
+                },
+                "youtubeUrl": {
+                    "type": "string",
+                    "example": "https://www.youtube.com/channel/UCy8asdgasdf7RcC6OZffZA"
+                }
+            }
+        },
+        "AuthorUpdate": {
+            "type": "object",
+            "properties": {
+                "firstName": {
+                    "type": "string",
+                    "example": "Moe"
+                },
+                "imageName": {
+                    "type": "string",
+                    "example": "moezarella.png"
+                },
+                "instagramUrl": {
+                    "type": "string",
+                    "example": "https://wwww.instagram.com/moezarella/"
+                },
+                "lastName": {
+                    "type": "string",
+                    "example": "Zarella"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Moe Zarella"
+                },
+                "websiteUrl": {
+                    "type": "string",
+                    "example": "https://www.moezarella.com"
+                },
+                "youtubeUrl": {
+                    "type": "string",
+                    "example": "https://www.youtube.com/channel/UCy8asdgasdf7RcC6OZffZA"
+                }
+            }
+        },
+        "CatalogueImportResult": {
+            "type": "object",
+            "properties": {
+                "insertedCount": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "updatedCount": {
+                    "type": "integer",
+                    "example": 30
+                }
+            }
+        },
+        "CatalogueIngredient": {
+            "type": "object",
+            "properties": {
+                "aliases": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "wheat flour",
+                        "all-purpose flour"
+                    ]
+                },
+                "createdAt": {
+                    "type": "integer",
+                    "example": 1714462120
+                },
+                "defaultUnit": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/db.AmountUnit"
+                        }
+                    ],
+                    "example": "g"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "660c4b99bc1bc4aabe126cd1"
+                },
+                "modifiedAt": {
+                    "type": "integer",
+                    "example": 1714462120
+                },
+                "name": {
+                    "type": "string",
+                    "example": "flour"
+                },
+                "nutrients": {
+                    "$ref": "#/definitions/Nutrients"
+                },
+                "pieceWeightG": {
+                    "type": "number",
+                    "example": 118
+                }
+            }
+        },
+        "CatalogueIngredientToCreate": {
+            "type": "object",
+            "required": [
+                "aliases",
+                "name"
+            ],
+            "properties": {
+                "aliases": {
+                    "type": "array",
+                    "maxItems": 20,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "wheat flour",
+                        "all-purpose flour"
+                    ]
+                },
+                "defaultUnit": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/db.AmountUnit"
+                        }
+                    ],
+                    "example": "g"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "flour"
+                },
+                "nutrients": {
+                    "$ref": "#/definitions/Nutrients"
+                },
+                "pieceWeightG": {
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 118
+                }
+            }
+        },
+        "CatalogueIngredientUpdate": {
+            "type": "object",
+            "required": [
+                "aliases"
+            ],
+            "properties": {
+                "aliases": {
+                    "type": "array",
+                    "maxItems": 20,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "wheat flour",
+                        "all-purpose flour"
+                    ]
+                },
+                "defaultUnit": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/db.AmountUnit"
+                        }
+                    ],
+                    "example": "g"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "flour"
+                },
+                "nutrients": {
+                    "$ref": "#/definitions/Nutrients"
+                },
+                "pieceWeightG": {
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 118
+                }
+            }
+        },
+        "FieldError": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string",
+                    "example": "ingredients[0].name"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "is required"
+                }
+            }
+        },
+        "IngredientListResponse": {
+            "type": "object",
+            "properties": {
+                "hasNext": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/CatalogueIngredient"
+                    }
+                },
+                "links": {
+                    "$ref": "#/definitions/PageLinks"
+                },
+                "nextCursor": {
+                    "type": "string",
+                    "example": "SQAAAARrABMAAAACMAAFAAAAbmFtZQAABG8ADAAAABAwAAEAAAAABHYAFQAAAAIwAAkAAABQYW5jYWtlcwAAB2lkAGYMS5m8G8SqvhJs0QA"
+                },
+                "page": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "pageSize": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "totalCount": {
+                    "type": "integer",
+                    "example": 42
+                }
+            }
+        },
+        "Nutrients": {
+            "type": "object",
+            "properties": {
+                "carbs": {
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 76.3
+                },
+                "fat": {
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 1
+                },
+                "fibre": {
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 2.7
+                },
+                "kcal": {
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 364
+                },
+                "protein": {
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 10.3
+                }
+            }
+        },
+        "PageLinks": {
+            "type": "object",
+            "properties": {
+                "next": {
+                    "type": "string",
+                    "example": "/api/v1/recipes?page_id=3\u0026page_size=10"
+                },
+                "prev": {
+                    "type": "string",
+                    "example": "/api/v1/recipes?page_id=1\u0026page_size=10"
+                }
+            }
+        },
+        "ProblemDetails": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/api.ErrorCode"
+                        }
+                    ],
+                    "example": "bad_request"
+                },
+                "detail": {
+                    "type": "string",
+                    "example": "Failed to parse data"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/FieldError"
+                    }
+                },
+                "instance": {
+                    "type": "string",
+                    "example": "/api/v1/recipes"
+                },
+                "requestId": {
+                    "type": "string",
+                    "example": "3f1b6a52-5a3e-4d4b-a1c5-1f5e4a2b7c9d"
+                },
+                "status": {
+                    "type": "integer",
+                    "example": 400
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Bad Request"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "about:blank"
+                }
+            }
+        },
+        "RecipeListResponse": {
+            "type": "object",
+            "properties": {
+                "hasNext": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/RecipeResponse"
+                    }
+                },
+                "links": {
+                    "$ref": "#/definitions/PageLinks"
+                },
+                "nextCursor": {
+                    "type": "string",
+                    "example": "SQAAAARrABMAAAACMAAFAAAAbmFtZQAABG8ADAAAABAwAAEAAAAABHYAFQAAAAIwAAkAAABQYW5jYWtlcwAAB2lkAGYMS5m8G8SqvhJs0QA"
+                },
+                "page": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "pageSize": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "totalCount": {
+                    "type": "integer",
+                    "example": 42
+                }
+            }
+        },
+        "RecipeNutrition": {
+            "type": "object",
+            "properties": {
+                "missingIngredients": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "vegetable broth"
+                    ]
+                },
+                "perServing": {
+                    "$ref": "#/definitions/Nutrients"
+                },
+                "total": {
+                    "$ref": "#/definitions/Nutrients"
+                }
+            }
+        },
+        "RecipeResponse": {
+            "type": "object",
+            "required": [
+                "authorId"
+            ],
+            "properties": {
+                "allergens": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/db.Allergen"
+                    },
+                    "example": [
+                        "gluten",
+                        "nuts"
+                    ]
+                },
+                "author": {
+                    "$ref": "#/definitions/AuthorResponse"
+                },
+                "authorId": {
+                    "type": "string",
+                    "example": "660c4b99bc1bc4aabe126cd1"
+                },
+                "averageRating": {
+                    "type": "number",
+                    "example": 4.5
+                },
+                "category": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/db.Category"
+                        }
+                    ],
+                    "example": "breakfast"
+                },
+                "createdAt": {
+                    "type": "integer",
+                    "example": 1714462120
+                },
+                "dietaryLabels": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/db.DietaryLabel"
+                    },
+                    "example": [
+                        "nut-free",
+                        "soy-free"
+                    ]
+                },
+                "id": {
+                    "type": "string",
+                    "example": "660c4b99bc1bc4aabe126cd1"
+                },
+                "imageName": {
+                    "type": "string",
+                    "example": "Pancakes.png"
+                },
+                "ingredients": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/db.Ingredient"
+                    }
+                },
+                "modifiedAt": {
+                    "type": "integer",
+                    "example": 1714462120
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Pancakes"
+                },
+                "nutrition": {
+                    "$ref": "#/definitions/RecipeNutrition"
+                },
+                "prepSteps": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/db.PrepStep"
+                    }
+                },
+                "ratingCount": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "recipeUrl": {
+                    "type": "string",
+                    "example": "https://www.allthepancakes.com/pancakes"
+                },
+                "servings": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "quick",
+                        "sweet"
+                    ]
+                },
+                "timeM": {
+                    "type": "integer",
+                    "example": 30
+                },
+                "userCreated": {
+                    "$ref": "#/definitions/UserResponse"
+                },
+                "userId": {
+                    "type": "string",
+                    "example": "660c4b99bc1bc4aabe126cd1"
+                }
+            }
+        },
+        "RecipeSearchListResponse": {
+            "type": "object",
+            "properties": {
+                "hasNext": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/RecipeSearchResponse"
+                    }
+                },
+                "links": {
+                    "$ref": "#/definitions/PageLinks"
+                },
+                "nextCursor": {
+                    "type": "string",
+                    "example": "SQAAAARrABMAAAACMAAFAAAAbmFtZQAABG8ADAAAABAwAAEAAAAABHYAFQAAAAIwAAkAAABQYW5jYWtlcwAAB2lkAGYMS5m8G8SqvhJs0QA"
+                },
+                "page": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "pageSize": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "totalCount": {
+                    "type": "integer",
+                    "example": 42
+                }
+            }
+        },
+        "RecipeSearchResponse": {
+            "type": "object",
+            "required": [
+                "authorId"
+            ],
             "properties": {
-                "firstName": {
-                    "type": "string",
-                    "example": "Moe"
+                "allergens": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/db.Allergen"
+                    },
+                    "example": [
+                        "gluten",
+                        "nuts"
+                    ]
                 },
-                "imageName": {
+                "author": {
+                    "$ref": "#/definitions/AuthorResponse"
+                },
+                "authorId": {
                     "type": "string",
-                    "example": "moezarella.png"
+                    "example": "660c4b99bc1bc4aabe126cd1"
                 },
-                "instagramUrl": {
+                "averageRating": {
+                    "type": "number",
+                    "example": 4.5
+                },
+                "category": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/db.Category"
+                        }
+                    ],
+                    "example": "breakfast"
+                },
+                "createdAt": {
+                    "type": "integer",
+                    "example": 1714462120
+                },
+                "dietaryLabels": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/db.DietaryLabel"
+                    },
+                    "example": [
+                        "nut-free",
+                        "soy-free"
+                    ]
+                },
+                "id": {
                     "type": "string",
-                    "example": "https://wwww.instagram.com/moezarella/"
+                    "example": "660c4b99bc1bc4aabe126cd1"
                 },
-                "lastName": {
+                "imageName": {
                     "type": "string",
-                    "example": "Zarella"
+                    "example": "Pancakes.png"
+                },
+                "ingredients": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/db.Ingredient"
+                    }
+                },
+                "modifiedAt": {
+                    "type": "integer",
+                    "example": 1714462120
                 },
                 "name": {
                     "type": "string",
-                    "example": "Moe Zarella"
+                    "example": "Pancakes"
+                },
+                "nutrition": {
+                    "$ref": "#/definitions/RecipeNutrition"
+                },
+                "prepSteps": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/db.PrepStep"
+                    }
+                },
+                "ratingCount": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "recipeUrl": {
+                    "type": "string",
+                    "example": "https://www.allthepancakes.com/pancakes"
+                },
+                "score": {
+                    "type": "number",
+                    "example": 11.5
+                },
+                "servings": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "quick",
+                        "sweet"
+                    ]
+                },
+                "timeM": {
+                    "type": "integer",
+                    "example": 30
+                },
+                "userCreated": {
+                    "$ref": "#/definitions/UserResponse"
                 },
                 "userId": {
                     "type": "string",
-                    "example": "660c4b99bc1bc4aabe3e6cd1"
+                    "example": "660c4b99bc1bc4aabe126cd1"
+                }
+            }
+        },
+        "RecipeSubstitution": {
+            "type": "object",
+            "properties": {
+                "ingredient": {
+                    "$ref": "#/definitions/db.Ingredient"
                 },
-                "websiteUrl": {
+                "notes": {
                     "type": "string",
-                    "example": "https://www.moezarella.com"
+                    "example": "Whip it until stiff peaks form"
                 },
-                "youtubeUrl": {
+                "ratio": {
+                    "type": "number",
+                    "example": 2
+                },
+                "substitute": {
+                    "$ref": "#/definitions/db.Ingredient"
+                },
+                "substitutionId": {
                     "type": "string",
-                    "example": "https://www.youtube.com/channel/UCy8asdgasdf7RcC6OZffZA"
+                    "example": "660c4b99bc1bc4aabe126cd1"
                 }
             }
         },
-        "AuthorUpdate": {
+        "RecipeToCreate": {
             "type": "object",
+            "required": [
+                "authorId",
+                "category",
+                "name",
+                "tags"
+            ],
             "properties": {
-                "firstName": {
+                "authorId": {
                     "type": "string",
-                    "example": "Moe"
+                    "example": "660c4b99bc1bc4aabe126cd1"
+                },
+                "category": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/db.Category"
+                        }
+                    ],
+                    "example": "breakfast"
+                },
+                "dietaryLabels": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/db.DietaryLabel"
+                    },
+                    "example": [
+                        "nut-free",
+                        "soy-free"
+                    ]
                 },
                 "imageName": {
                     "type": "string",
-                    "example": "moezarella.png"
+                    "example": "Pancakes.png"
                 },
-                "instagramUrl": {
+                "ingredients": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/db.Ingredient"
+                    }
+                },
+                "name": {
                     "type": "string",
-                    "example": "https://wwww.instagram.com/moezarella/"
+                    "example": "Pancakes"
                 },
-                "lastName": {
+                "prepSteps": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/db.PrepStep"
+                    }
+                },
+                "recipeUrl": {
                     "type": "string",
-                    "example": "Zarella"
+                    "example": "https://www.allthepancakes.com/pancakes"
                 },
-                "name": {
+                "servings": {
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 1,
+                    "example": 4
+                },
+                "tags": {
+                    "type": "array",
+                    "maxItems": 20,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "quick",
+                        "sweet"
+                    ]
+                },
+                "timeM": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 30
+                }
+            }
+        },
+        "RecipeUpdate": {
+            "type": "object",
+            "required": [
+                "tags"
+            ],
+            "properties": {
+                "authorId": {
                     "type": "string",
-                    "example": "Moe Zarella"
+                    "example": "660c4b99bc1bc4aabe126cd1"
                 },
-                "websiteUrl": {
+                "category": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/db.Category"
+                        }
+                    ],
+                    "example": "breakfast"
+                },
+                "dietaryLabels": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/db.DietaryLabel"
+                    },
+                    "example": [
+                        "nut-free",
+                        "soy-free"
+                    ]
+                },
+                "imageName": {
                     "type": "string",
-                    "example": "https://www.moezarella.com"
+                    "example": "Pancakes.png"
                 },
-                "youtubeUrl": {
+                "ingredients": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/db.Ingredient"
+                    }
+                },
+                "name": {
                     "type": "string",
-                    "example": "https://www.youtube.com/channel/UCy8asdgasdf7RcC6OZffZA"
+                    "example": "Pancakes"
+                },
+                "prepSteps": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/db.PrepStep"
+                    }
+                },
+                "recipeUrl": {
+                    "type": "string",
+                    "example": "https://www.allthepancakes.com/pancakes"
+                },
+                "servings": {
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 1,
+                    "example": 4
+                },
+                "tags": {
+                    "type": "array",
+                    "maxItems": 20,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "quick",
+                        "sweet"
+                    ]
+                },
+                "timeM": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 30
                 }
             }
         },
-        "ErrorBadRequest": {
+        "ReviewListResponse": {
             "type": "object",
             "properties": {
-                "message": {
+                "hasNext": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ReviewResponse"
+                    }
+                },
+                "links": {
+                    "$ref": "#/definitions/PageLinks"
+                },
+                "nextCursor": {
                     "type": "string",
-                    "example": "Failed to parse data"
+                    "example": "SQAAAARrABMAAAACMAAFAAAAbmFtZQAABG8ADAAAABAwAAEAAAAABHYAFQAAAAIwAAkAAABQYW5jYWtlcwAAB2lkAGYMS5m8G8SqvhJs0QA"
                 },
-                "statusCode": {
+                "page": {
                     "type": "integer",
-                    "example": 400
+                    "example": 2
                 },
-                "statusText": {
-                    "type": "string",
-                    "example": "Bad Request"
+                "pageSize": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "totalCount": {
+                    "type": "integer",
+                    "example": 42
                 }
             }
         },
-        "ErrorInternalServerError": {
+        "ReviewResponse": {
             "type": "object",
             "properties": {
-                "message": {
+                "createdAt": {
+                    "type": "integer",
+                    "example": 1714462120
+                },
+                "id": {
                     "type": "string",
-                    "example": "An internal server error occurred"
+                    "example": "660c4b99bc1bc4aabe126cd1"
+                },
+                "modifiedAt": {
+                    "type": "integer",
+                    "example": 1714462120
                 },
-                "statusCode": {
+                "rating": {
                     "type": "integer",
-                    "example": 500
+                    "example": 5
                 },
-                "statusText": {
+                "recipeId": {
                     "type": "string",
-                    "example": "Internal Server Error"
+                    "example": "660c4b99bc1bc4aabe126cd1"
+                },
+                "text": {
+                    "type": "string",
+                    "example": "Fluffy and not too sweet"
+                },
+                "userCreated": {
+                    "$ref": "#/definitions/UserResponse"
+                },
+                "userId": {
+                    "type": "string",
+                    "example": "660c4b99bc1bc4aabe3e6cd1"
                 }
             }
         },
-        "ErrorNotAcceptable": {
+        "ReviewToCreate": {
             "type": "object",
+            "required": [
+                "rating"
+            ],
             "properties": {
-                "message": {
-                    "type": "string",
-                    "example": "Provided input is not acceptable"
-                },
-                "statusCode": {
+                "rating": {
                     "type": "integer",
-                    "example": 406
+                    "maximum": 5,
+                    "minimum": 1,
+                    "example": 5
                 },
-                "statusText": {
+                "text": {
                     "type": "string",
-                    "example": "Not Acceptable"
+                    "maxLength": 2000,
+                    "example": "Fluffy and not too sweet"
                 }
             }
         },
-        "ErrorNotFound": {
+        "ReviewUpdate": {
             "type": "object",
             "properties": {
-                "message": {
-                    "type": "string",
-                    "example": "Could not find requested data"
-                },
-                "statusCode": {
+                "rating": {
                     "type": "integer",
-                    "example": 404
+                    "maximum": 5,
+                    "minimum": 1,
+                    "example": 4
                 },
-                "statusText": {
+                "text": {
                     "type": "string",
-                    "example": "Not Found"
+                    "maxLength": 2000,
+                    "example": "Even better with blueberries"
                 }
             }
         },
-        "ErrorUnauthorized": {
+        "SessionResponse": {
             "type": "object",
             "properties": {
-                "message": {
+                "clientIp": {
                     "type": "string",
-                    "example": "Unauthorized for retrieving this information"
+                    "example": "127.0.0.1"
                 },
-                "statusCode": {
+                "expiresAt": {
                     "type": "integer",
-                    "example": 401
+                    "example": 1714462120
+                },
+                "id": {
+                    "type": "string",
+                    "example": "660c4b99bc1bc4aabe3e6cd1"
+                },
+                "isBlocked": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "userAgent": {
+                    "type": "string",
+                    "example": "Mozilla/5.0"
                 },
-                "statusText": {
+                "userId": {
                     "type": "string",
-                    "example": "Unauthorized"
+                    "example": "660c4b99bc1bc4aabe3e6cd1"
                 }
             }
         },
-        "RecipeResponse": {
+        "Substitution": {
             "type": "object",
-            "required": [
-                "authorId"
-            ],
             "properties": {
-                "author": {
-                    "$ref": "#/definitions/AuthorResponse"
-                },
-                "authorId": {
-                    "type": "string",
-                    "example": "660c4b99bc1bc4aabe126cd1"
-                },
-                "category": {
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/db.Category"
-                        }
-                    ],
-                    "example": "breakfast"
-                },
                 "createdAt": {
                     "type": "integer",
                     "example": 1714462120
@@ -1118,143 +4932,204 @@ const docTemplate = `{
                     "type": "string",
                     "example": "660c4b99bc1bc4aabe126cd1"
                 },
-                "imageName": {
+                "ingredientId": {
                     "type": "string",
-                    "example": "Pancakes.png"
+                    "example": "660c4b99bc1bc4aabe126cd2"
                 },
-                "ingredients": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/db.Ingredient"
-                    }
+                "ingredientName": {
+                    "type": "string",
+                    "example": "egg white"
                 },
                 "modifiedAt": {
                     "type": "integer",
                     "example": 1714462120
                 },
-                "name": {
+                "notes": {
                     "type": "string",
-                    "example": "Pancakes"
+                    "example": "Whip it until stiff peaks form"
                 },
-                "prepSteps": {
+                "ratio": {
+                    "type": "number",
+                    "example": 2
+                },
+                "sourceUnit": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/db.AmountUnit"
+                        }
+                    ],
+                    "example": "pc"
+                },
+                "substituteId": {
+                    "type": "string",
+                    "example": "660c4b99bc1bc4aabe126cd3"
+                },
+                "substituteName": {
+                    "type": "string",
+                    "example": "aquafaba"
+                },
+                "unit": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/db.AmountUnit"
+                        }
+                    ],
+                    "example": "tbs"
+                }
+            }
+        },
+        "SubstitutionListResponse": {
+            "type": "object",
+            "properties": {
+                "hasNext": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "items": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/db.PrepStep"
+                        "$ref": "#/definitions/Substitution"
                     }
                 },
-                "recipeUrl": {
+                "links": {
+                    "$ref": "#/definitions/PageLinks"
+                },
+                "nextCursor": {
                     "type": "string",
-                    "example": "https://www.allthepancakes.com/pancakes"
+                    "example": "SQAAAARrABMAAAACMAAFAAAAbmFtZQAABG8ADAAAABAwAAEAAAAABHYAFQAAAAIwAAkAAABQYW5jYWtlcwAAB2lkAGYMS5m8G8SqvhJs0QA"
                 },
-                "timeM": {
+                "page": {
                     "type": "integer",
-                    "example": 30
+                    "example": 2
                 },
-                "userCreated": {
-                    "$ref": "#/definitions/UserResponse"
+                "pageSize": {
+                    "type": "integer",
+                    "example": 10
                 },
-                "userId": {
-                    "type": "string",
-                    "example": "660c4b99bc1bc4aabe126cd1"
+                "totalCount": {
+                    "type": "integer",
+                    "example": 42
                 }
             }
         },
-        "RecipeToCreate": {
+        "SubstitutionToCreate": {
             "type": "object",
             "required": [
-                "authorId",
-                "name",
-                "userId"
+                "ingredientId",
+                "ratio",
+                "substituteId"
             ],
             "properties": {
-                "authorId": {
+                "ingredientId": {
                     "type": "string",
-                    "example": "660c4b99bc1bc4aabe126cd1"
+                    "example": "660c4b99bc1bc4aabe126cd2"
                 },
-                "category": {
+                "notes": {
+                    "type": "string",
+                    "maxLength": 500,
+                    "example": "Whip it until stiff peaks form"
+                },
+                "ratio": {
+                    "type": "number",
+                    "example": 2
+                },
+                "sourceUnit": {
                     "allOf": [
                         {
-                            "$ref": "#/definitions/db.Category"
+                            "$ref": "#/definitions/db.AmountUnit"
                         }
                     ],
-                    "example": "breakfast"
-                },
-                "imageName": {
-                    "type": "string",
-                    "example": "Pancakes.png"
-                },
-                "ingredients": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/db.Ingredient"
-                    }
-                },
-                "name": {
-                    "type": "string",
-                    "example": "Pancakes"
-                },
-                "prepSteps": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/db.PrepStep"
-                    }
+                    "example": "pc"
                 },
-                "recipeUrl": {
+                "substituteId": {
                     "type": "string",
-                    "example": "https://www.allthepancakes.com/pancakes"
-                },
-                "timeM": {
-                    "type": "integer",
-                    "example": 30
+                    "example": "660c4b99bc1bc4aabe126cd3"
                 },
-                "userId": {
-                    "type": "string",
-                    "example": "660c4b99bc1bc4aabe126cd1"
+                "unit": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/db.AmountUnit"
+                        }
+                    ],
+                    "example": "tbs"
                 }
             }
         },
-        "RecipeUpdate": {
+        "SubstitutionUpdate": {
             "type": "object",
             "properties": {
-                "authorId": {
+                "notes": {
                     "type": "string",
-                    "example": "660c4b99bc1bc4aabe126cd1"
+                    "maxLength": 500,
+                    "example": "Whip it until stiff peaks form"
                 },
-                "category": {
+                "ratio": {
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 2
+                },
+                "sourceUnit": {
                     "allOf": [
                         {
-                            "$ref": "#/definitions/db.Category"
+                            "$ref": "#/definitions/db.AmountUnit"
                         }
                     ],
-                    "example": "breakfast"
+                    "example": "pc"
                 },
-                "imageName": {
+                "unit": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/db.AmountUnit"
+                        }
+                    ],
+                    "example": "tbs"
+                }
+            }
+        },
+        "TagCount": {
+            "type": "object",
+            "properties": {
+                "name": {
                     "type": "string",
-                    "example": "Pancakes.png"
+                    "example": "quick"
                 },
-                "ingredients": {
+                "recipeCount": {
+                    "type": "integer",
+                    "example": 12
+                }
+            }
+        },
+        "UserListResponse": {
+            "type": "object",
+            "properties": {
+                "hasNext": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "items": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/db.Ingredient"
+                        "$ref": "#/definitions/UserResponse"
                     }
                 },
-                "name": {
+                "links": {
+                    "$ref": "#/definitions/PageLinks"
+                },
+                "nextCursor": {
                     "type": "string",
-                    "example": "Pancakes"
+                    "example": "SQAAAARrABMAAAACMAAFAAAAbmFtZQAABG8ADAAAABAwAAEAAAAABHYAFQAAAAIwAAkAAABQYW5jYWtlcwAAB2lkAGYMS5m8G8SqvhJs0QA"
                 },
-                "prepSteps": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/db.PrepStep"
-                    }
+                "page": {
+                    "type": "integer",
+                    "example": 2
                 },
-                "recipeUrl": {
-                    "type": "string",
-                    "example": "https://www.allthepancakes.com/pancakes"
+                "pageSize": {
+                    "type": "integer",
+                    "example": 10
                 },
-                "timeM": {
+                "totalCount": {
                     "type": "integer",
-                    "example": 30
+                    "example": 42
                 }
             }
         },
@@ -1294,6 +5169,43 @@ const docTemplate = `{
                 }
             }
         },
+        "api.ErrorCode": {
+            "type": "string",
+            "enum": [
+                "bad_request",
+                "validation_failed",
+                "invalid_id",
+                "invalid_sort",
+                "invalid_cursor",
+                "unauthorized",
+                "forbidden",
+                "not_found",
+                "not_acceptable",
+                "conflict",
+                "duplicate_key",
+                "still_referenced",
+                "unprocessable_entity",
+                "too_many_requests",
+                "internal_server_error"
+            ],
+            "x-enum-varnames": [
+                "CodeBadRequest",
+                "CodeValidationFailed",
+                "CodeInvalidID",
+                "CodeInvalidSort",
+                "CodeInvalidCursor",
+                "CodeUnauthorized",
+                "CodeForbidden",
+                "CodeNotFound",
+                "CodeNotAcceptable",
+                "CodeConflict",
+                "CodeDuplicateKey",
+                "CodeStillReferenced",
+                "CodeUnprocessableEntity",
+                "CodeTooManyRequests",
+                "CodeInternalServerError"
+            ]
+        },
         "authUserBody": {
             "type": "object",
             "required": [
@@ -1313,6 +5225,21 @@ const docTemplate = `{
                 }
             }
         },
+        "db.Allergen": {
+            "type": "string",
+            "enum": [
+                "gluten",
+                "nuts",
+                "soy",
+                "sesame"
+            ],
+            "x-enum-varnames": [
+                "Gluten",
+                "Nuts",
+                "Soy",
+                "Sesame"
+            ]
+        },
         "db.AmountUnit": {
             "type": "string",
             "enum": [
@@ -1320,18 +5247,38 @@ const docTemplate = `{
                 "l",
                 "mg",
                 "g",
+                "kg",
                 "tbs",
                 "tsp",
-                "pc"
+                "cup",
+                "floz",
+                "oz",
+                "lb",
+                "pinch",
+                "pc",
+                "can",
+                "clove",
+                "bunch",
+                "slice"
             ],
             "x-enum-varnames": [
                 "Milliliters",
                 "Liters",
                 "Milligrams",
                 "Grams",
+                "Kilograms",
                 "Tablespoon",
                 "Teaspoon",
-                "Piece"
+                "Cup",
+                "FluidOunce",
+                "Ounce",
+                "Pound",
+                "Pinch",
+                "Piece",
+                "Can",
+                "Clove",
+                "Bunch",
+                "Slice"
             ]
         },
         "db.Category": {
@@ -1353,12 +5300,37 @@ const docTemplate = `{
                 "Drink"
             ]
         },
+        "db.DietaryLabel": {
+            "type": "string",
+            "enum": [
+                "gluten-free",
+                "nut-free",
+                "soy-free",
+                "raw",
+                "high-protein"
+            ],
+            "x-enum-varnames": [
+                "GlutenFree",
+                "NutFree",
+                "SoyFree",
+                "Raw",
+                "HighProtein"
+            ]
+        },
         "db.Ingredient": {
             "type": "object",
+            "required": [
+                "name"
+            ],
             "properties": {
                 "amount": {
-                    "type": "integer",
-                    "example": 100
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 0.5
+                },
+                "ingredientId": {
+                    "type": "string",
+                    "example": "660c4b99bc1bc4aabe126cd1"
                 },
                 "name": {
                     "type": "string",
@@ -1370,12 +5342,15 @@ const docTemplate = `{
                             "$ref": "#/definitions/db.AmountUnit"
                         }
                     ],
-                    "example": "g"
+                    "example": "cup"
                 }
             }
         },
         "db.PrepStep": {
             "type": "object",
+            "required": [
+                "description"
+            ],
             "properties": {
                 "description": {
                     "type": "string",
@@ -1383,6 +5358,7 @@ const docTemplate = `{
                 },
                 "rank": {
                     "type": "integer",
+                    "minimum": 1,
                     "example": 1
                 }
             }
@@ -1435,6 +5411,62 @@ const docTemplate = `{
                     "example": "user@example.com"
                 }
             }
+        },
+        "logoutBody": {
+            "type": "object",
+            "required": [
+                "sessionId"
+            ],
+            "properties": {
+                "sessionId": {
+                    "type": "string",
+                    "example": "660c4b99bc1bc4aabe3e6cd1"
+                }
+            }
+        },
+        "refreshTokenBody": {
+            "type": "object",
+            "required": [
+                "refreshToken",
+                "sessionId"
+            ],
+            "properties": {
+                "refreshToken": {
+                    "type": "string",
+                    "example": "v2.local.example-refresh-token"
+                },
+                "sessionId": {
+                    "type": "string",
+                    "example": "660c4b99bc1bc4aabe3e6cd1"
+                }
+            }
+        },
+        "refreshTokenResponse": {
+            "type": "object",
+            "properties": {
+                "accessToken": {
+                    "type": "string",
+                    "example": "v2.local.example-session-token"
+                },
+                "accessTokenExpiresAt": {
+                    "type": "integer",
+                    "example": 1714462120
+                }
+            }
+        },
+        "userUpdateBody": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "user@example.com"
+                },
+                "password": {
+                    "type": "string",
+                    "minLength": 6,
+                    "example": "s3cr3tP@ssw0rd"
+                }
+            }
         }
     },
     "securityDefinitions": {
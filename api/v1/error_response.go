@@ -1,97 +1,219 @@
 package api
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
+	"strings"
 
+	"github.com/PfMartin/wegonice-api/db"
 	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
 )
 
-type ErrorBadRequest struct {
-	StatusText string `json:"statusText" example:"Bad Request"`
-	StatusCode int    `json:"statusCode" example:"400"`
-	Message    string `json:"message" example:"Failed to parse data"`
-} // @name ErrorBadRequest
-
-func NewErrorBadRequest(err error) *ErrorBadRequest {
-	return &ErrorBadRequest{
-		StatusText: http.StatusText(http.StatusBadRequest),
-		StatusCode: http.StatusBadRequest,
-		Message:    err.Error(),
+const problemContentType = "application/problem+json"
+
+type ErrorCode string
+
+const (
+	CodeBadRequest          ErrorCode = "bad_request"
+	CodeValidationFailed    ErrorCode = "validation_failed"
+	CodeInvalidID           ErrorCode = "invalid_id"
+	CodeInvalidSort         ErrorCode = "invalid_sort"
+	CodeInvalidCursor       ErrorCode = "invalid_cursor"
+	CodeUnauthorized        ErrorCode = "unauthorized"
+	CodeForbidden           ErrorCode = "forbidden"
+	CodeNotFound            ErrorCode = "not_found"
+	CodeNotAcceptable       ErrorCode = "not_acceptable"
+	CodeConflict            ErrorCode = "conflict"
+	CodeDuplicateKey        ErrorCode = "duplicate_key"
+	CodeStillReferenced     ErrorCode = "still_referenced"
+	CodeUnprocessableEntity ErrorCode = "unprocessable_entity"
+	CodeTooManyRequests     ErrorCode = "too_many_requests"
+	CodeInternalServerError ErrorCode = "internal_server_error"
+)
+
+type FieldError struct {
+	Field   string `json:"field" example:"ingredients[0].name"`
+	Message string `json:"message" example:"is required"`
+} // @name FieldError
+
+// ProblemDetails is the error response of the API. It follows RFC 7807 and adds a stable,
+// machine-readable code, the ID of the failed request and the validation errors per field.
+type ProblemDetails struct {
+	Type      string       `json:"type" example:"about:blank"`
+	Title     string       `json:"title" example:"Bad Request"`
+	Status    int          `json:"status" example:"400"`
+	Detail    string       `json:"detail" example:"Failed to parse data"`
+	Instance  string       `json:"instance,omitempty" example:"/api/v1/recipes"`
+	Code      ErrorCode    `json:"code" example:"bad_request"`
+	RequestID string       `json:"requestId,omitempty" example:"3f1b6a52-5a3e-4d4b-a1c5-1f5e4a2b7c9d"`
+	Errors    []FieldError `json:"errors,omitempty"`
+} // @name ProblemDetails
+
+func newProblemDetails(status int, code ErrorCode, err error) *ProblemDetails {
+	return &ProblemDetails{
+		Type:   "about:blank",
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: err.Error(),
+		Code:   code,
 	}
 }
 
-func (err *ErrorBadRequest) Send(ctx *gin.Context) {
-	ctx.AbortWithStatusJSON(err.StatusCode, err)
+// WithCode replaces the default code of the problem with a more specific one.
+func (problem *ProblemDetails) WithCode(code ErrorCode) *ProblemDetails {
+	problem.Code = code
+	return problem
+}
+
+func (problem *ProblemDetails) Send(ctx *gin.Context) {
+	problem.Instance = ctx.Request.URL.Path
+	problem.RequestID = ctx.GetString(requestIDKey)
+
+	ctx.Header("Content-Type", problemContentType)
+	ctx.AbortWithStatusJSON(problem.Status, problem)
 }
 
-type ErrorInternalServerError struct {
-	StatusText string `json:"statusText" example:"Internal Server Error"`
-	StatusCode int    `json:"statusCode" example:"500"`
-	Message    string `json:"message" example:"An internal server error occurred"`
-} // @name ErrorInternalServerError
+// NewErrorBadRequest creates a 400 problem. Validation errors of the request binding are
+// added per field.
+func NewErrorBadRequest(err error) *ProblemDetails {
+	problem := newProblemDetails(http.StatusBadRequest, CodeBadRequest, err)
 
-func NewErrorInternalServerError(err error) *ErrorInternalServerError {
-	return &ErrorInternalServerError{
-		StatusText: http.StatusText(http.StatusInternalServerError),
-		StatusCode: http.StatusInternalServerError,
-		Message:    err.Error(),
+	if fieldErrors := newFieldErrors(err); len(fieldErrors) > 0 {
+		problem.Code = CodeValidationFailed
+		problem.Detail = "request validation failed"
+		problem.Errors = fieldErrors
 	}
+
+	return problem
 }
 
-func (err *ErrorInternalServerError) Send(ctx *gin.Context) {
-	ctx.AbortWithStatusJSON(err.StatusCode, err)
+func NewErrorUnauthorized(err error) *ProblemDetails {
+	return newProblemDetails(http.StatusUnauthorized, CodeUnauthorized, err)
 }
 
-type ErrorNotAcceptable struct {
-	StatusText string `json:"statusText" example:"Not Acceptable"`
-	StatusCode int    `json:"statusCode" example:"406"`
-	Message    string `json:"message" example:"Provided input is not acceptable"`
-} // @name ErrorNotAcceptable
+func NewErrorForbidden(err error) *ProblemDetails {
+	return newProblemDetails(http.StatusForbidden, CodeForbidden, err)
+}
 
-func NewErrorNotAcceptable(err error) *ErrorNotAcceptable {
-	return &ErrorNotAcceptable{
-		StatusText: http.StatusText(http.StatusNotAcceptable),
-		StatusCode: http.StatusNotAcceptable,
-		Message:    err.Error(),
-	}
+func NewErrorNotFound(err error) *ProblemDetails {
+	return newProblemDetails(http.StatusNotFound, CodeNotFound, err)
+}
+
+func NewErrorNotAcceptable(err error) *ProblemDetails {
+	return newProblemDetails(http.StatusNotAcceptable, CodeNotAcceptable, err)
+}
+
+func NewErrorConflict(err error) *ProblemDetails {
+	return newProblemDetails(http.StatusConflict, CodeConflict, err)
 }
 
-func (err *ErrorNotAcceptable) Send(ctx *gin.Context) {
-	ctx.AbortWithStatusJSON(err.StatusCode, err)
+func NewErrorUnprocessableEntity(err error) *ProblemDetails {
+	return newProblemDetails(http.StatusUnprocessableEntity, CodeUnprocessableEntity, err)
 }
 
-type ErrorNotFound struct {
-	StatusText string `json:"statusText" example:"Not Found"`
-	StatusCode int    `json:"statusCode" example:"404"`
-	Message    string `json:"message" example:"Could not find requested data"`
-} // @name ErrorNotFound
+func NewErrorTooManyRequests(err error) *ProblemDetails {
+	return newProblemDetails(http.StatusTooManyRequests, CodeTooManyRequests, err)
+}
+
+func NewErrorInternalServerError(err error) *ProblemDetails {
+	return newProblemDetails(http.StatusInternalServerError, CodeInternalServerError, err)
+}
 
-func NewErrorNotFound(err error) *ErrorNotFound {
-	return &ErrorNotFound{
-		StatusText: http.StatusText(http.StatusNotFound),
-		StatusCode: http.StatusNotFound,
-		Message:    err.Error(),
+// newErrorFromDB maps errors returned by the store to the matching error response.
+// Errors, which are not known to the db package, are treated as internal server errors.
+func newErrorFromDB(err error) *ProblemDetails {
+	switch {
+	case errors.Is(err, db.ErrNotFound):
+		return NewErrorNotFound(err)
+	case errors.Is(err, db.ErrInvalidID):
+		return NewErrorBadRequest(err).WithCode(CodeInvalidID)
+	case errors.Is(err, db.ErrInvalidSort):
+		return NewErrorBadRequest(err).WithCode(CodeInvalidSort)
+	case errors.Is(err, db.ErrInvalidCursor):
+		return NewErrorBadRequest(err).WithCode(CodeInvalidCursor)
+	case errors.Is(err, db.ErrDuplicateKey):
+		return NewErrorConflict(err).WithCode(CodeDuplicateKey)
+	case errors.Is(err, db.ErrReferenced):
+		return NewErrorConflict(err).WithCode(CodeStillReferenced)
+	default:
+		return NewErrorInternalServerError(err)
 	}
 }
 
-func (err *ErrorNotFound) Send(ctx *gin.Context) {
-	ctx.AbortWithStatusJSON(err.StatusCode, err)
+func newFieldErrors(err error) []FieldError {
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		return nil
+	}
+
+	fieldErrors := make([]FieldError, 0, len(validationErrors))
+	for _, fieldError := range validationErrors {
+		fieldErrors = append(fieldErrors, FieldError{
+			Field:   getFieldPath(fieldError),
+			Message: getValidationMessage(fieldError),
+		})
+	}
+
+	return fieldErrors
 }
 
-type ErrorUnauthorized struct {
-	StatusText string `json:"statusText" example:"Unauthorized"`
-	StatusCode int    `json:"statusCode" example:"401"`
-	Message    string `json:"message" example:"Unauthorized for retrieving this information"`
-} // @name ErrorUnauthorized
+// getFieldPath returns the path of the field in the request body without the name of the
+// bound struct, e.g. "ingredients[0].name".
+func getFieldPath(fieldError validator.FieldError) string {
+	namespace := fieldError.Namespace()
+	if _, path, found := strings.Cut(namespace, "."); found {
+		return path
+	}
+
+	return namespace
+}
 
-func NewErrorUnauthorized(err error) *ErrorUnauthorized {
-	return &ErrorUnauthorized{
-		StatusText: http.StatusText(http.StatusUnauthorized),
-		StatusCode: http.StatusUnauthorized,
-		Message:    err.Error(),
+func getValidationMessage(fieldError validator.FieldError) string {
+	switch fieldError.Tag() {
+	case "required":
+		return "is required"
+	case "required_without":
+		return fmt.Sprintf("is required if %s is not provided", fieldError.Param())
+	case "required_with":
+		return fmt.Sprintf("is required if %s is provided", fieldError.Param())
+	case "excluded_with":
+		return fmt.Sprintf("must not be provided together with %s", fieldError.Param())
+	case "min":
+		return fmt.Sprintf("must be at least %s", fieldError.Param())
+	case "max":
+		return fmt.Sprintf("must be at most %s", fieldError.Param())
+	case "gte":
+		return fmt.Sprintf("must be greater than or equal to %s", fieldError.Param())
+	case "lte":
+		return fmt.Sprintf("must be less than or equal to %s", fieldError.Param())
+	case "email":
+		return "must be a valid email address"
+	case "oneof":
+		return fmt.Sprintf("must be one of: %s", fieldError.Param())
+	case "amount_unit":
+		return fmt.Sprintf("must be one of: %s", joinValues(db.AmountUnits))
+	case "recipe_category":
+		return fmt.Sprintf("must be one of: %s", joinValues(db.Categories))
+	case "dietary_label":
+		return fmt.Sprintf("must be one of: %s", joinValues(db.DietaryLabels))
+	case "allergen":
+		return fmt.Sprintf("must be one of: %s", joinValues(db.Allergens))
+	case "web_url":
+		return "must be a valid http or https URL"
+	case "prep_steps":
+		return "must have unique ranks from 1 to the number of steps"
+	default:
+		return fmt.Sprintf("failed on the '%s' validation", fieldError.Tag())
 	}
 }
 
-func (err *ErrorUnauthorized) Send(ctx *gin.Context) {
-	ctx.AbortWithStatusJSON(err.StatusCode, err)
+func joinValues[T ~string](values []T) string {
+	names := make([]string, 0, len(values))
+	for _, value := range values {
+		names = append(names, string(value))
+	}
+
+	return strings.Join(names, " ")
 }
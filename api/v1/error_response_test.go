@@ -1,24 +1,33 @@
 package api
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
+	"github.com/PfMartin/wegonice-api/db"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/require"
 )
 
-type ErrorResponse struct {
-	StatusText string `json:"statusText"`
-	StatusCode int    `json:"statusCode"`
-	Message    string `json:"message"`
+func decodeProblemDetails(t *testing.T, recorder *httptest.ResponseRecorder) ProblemDetails {
+	t.Helper()
+
+	require.Equal(t, problemContentType, recorder.Header().Get("Content-Type"))
+
+	var problem ProblemDetails
+	err := json.NewDecoder(recorder.Body).Decode(&problem)
+	require.NoError(t, err)
+
+	return problem
 }
 
 func TestUnitErrorResponses(t *testing.T) {
 	router := gin.Default()
+	router.Use(requestIDMiddleware())
 
 	router.GET("/bad_request", func(ctx *gin.Context) {
 		NewErrorBadRequest(fmt.Errorf("bad request")).Send(ctx)
@@ -35,36 +44,78 @@ func TestUnitErrorResponses(t *testing.T) {
 	router.GET("/unauthorized", func(ctx *gin.Context) {
 		NewErrorUnauthorized(fmt.Errorf("unauthorized")).Send(ctx)
 	})
+	router.GET("/forbidden", func(ctx *gin.Context) {
+		NewErrorForbidden(fmt.Errorf("forbidden")).Send(ctx)
+	})
+	router.GET("/conflict", func(ctx *gin.Context) {
+		NewErrorConflict(fmt.Errorf("conflict")).Send(ctx)
+	})
+	router.GET("/unprocessable_entity", func(ctx *gin.Context) {
+		NewErrorUnprocessableEntity(fmt.Errorf("unprocessable entity")).Send(ctx)
+	})
+	router.GET("/too_many_requests", func(ctx *gin.Context) {
+		NewErrorTooManyRequests(fmt.Errorf("too many requests")).Send(ctx)
+	})
 
 	testCases := []struct {
 		name               string
 		expectedStatusCode int
-		expectedMessage    string
+		expectedCode       ErrorCode
+		expectedDetail     string
 	}{
 		{
 			name:               "bad_request",
 			expectedStatusCode: http.StatusBadRequest,
-			expectedMessage:    "bad request",
+			expectedCode:       CodeBadRequest,
+			expectedDetail:     "bad request",
 		},
 		{
 			name:               "internal_server_error",
 			expectedStatusCode: http.StatusInternalServerError,
-			expectedMessage:    "internal server error",
+			expectedCode:       CodeInternalServerError,
+			expectedDetail:     "internal server error",
 		},
 		{
 			name:               "not_acceptable",
 			expectedStatusCode: http.StatusNotAcceptable,
-			expectedMessage:    "not acceptable",
+			expectedCode:       CodeNotAcceptable,
+			expectedDetail:     "not acceptable",
 		},
 		{
 			name:               "not_found",
 			expectedStatusCode: http.StatusNotFound,
-			expectedMessage:    "not found",
+			expectedCode:       CodeNotFound,
+			expectedDetail:     "not found",
 		},
 		{
 			name:               "unauthorized",
 			expectedStatusCode: http.StatusUnauthorized,
-			expectedMessage:    "unauthorized",
+			expectedCode:       CodeUnauthorized,
+			expectedDetail:     "unauthorized",
+		},
+		{
+			name:               "forbidden",
+			expectedStatusCode: http.StatusForbidden,
+			expectedCode:       CodeForbidden,
+			expectedDetail:     "forbidden",
+		},
+		{
+			name:               "conflict",
+			expectedStatusCode: http.StatusConflict,
+			expectedCode:       CodeConflict,
+			expectedDetail:     "conflict",
+		},
+		{
+			name:               "unprocessable_entity",
+			expectedStatusCode: http.StatusUnprocessableEntity,
+			expectedCode:       CodeUnprocessableEntity,
+			expectedDetail:     "unprocessable entity",
+		},
+		{
+			name:               "too_many_requests",
+			expectedStatusCode: http.StatusTooManyRequests,
+			expectedCode:       CodeTooManyRequests,
+			expectedDetail:     "too many requests",
 		},
 	}
 
@@ -79,12 +130,140 @@ func TestUnitErrorResponses(t *testing.T) {
 			router.ServeHTTP(recorder, request)
 			require.Equal(t, tc.expectedStatusCode, recorder.Code)
 
-			var errorBody ErrorResponse
-			err = json.NewDecoder(recorder.Body).Decode(&errorBody)
+			problem := decodeProblemDetails(t, recorder)
+
+			require.Equal(t, "about:blank", problem.Type)
+			require.Equal(t, http.StatusText(tc.expectedStatusCode), problem.Title)
+			require.Equal(t, tc.expectedStatusCode, problem.Status)
+			require.Equal(t, tc.expectedDetail, problem.Detail)
+			require.Equal(t, tc.expectedCode, problem.Code)
+			require.Equal(t, url, problem.Instance)
+			require.NotEmpty(t, problem.RequestID)
+			require.Equal(t, recorder.Header().Get(requestIDHeaderKey), problem.RequestID)
+			require.Empty(t, problem.Errors)
+		})
+	}
+}
+
+func TestUnitValidationErrorResponse(t *testing.T) {
+	registerValidators()
+
+	type ingredient struct {
+		Name   string `json:"name" binding:"required"`
+		Amount int    `json:"amount" binding:"min=1"`
+	}
+
+	type body struct {
+		Email       string       `json:"email" binding:"required,email"`
+		Ingredients []ingredient `json:"ingredients" binding:"dive"`
+	}
+
+	router := gin.Default()
+	router.Use(requestIDMiddleware())
+	router.POST("/validate", func(ctx *gin.Context) {
+		var data body
+		if err := ctx.ShouldBindJSON(&data); err != nil {
+			NewErrorBadRequest(err).Send(ctx)
+			return
+		}
+
+		ctx.Status(http.StatusOK)
+	})
+
+	data, err := json.Marshal(gin.H{
+		"email":       "not-an-email",
+		"ingredients": []gin.H{{"amount": 0}},
+	})
+	require.NoError(t, err)
+
+	recorder := httptest.NewRecorder()
+	request, err := http.NewRequest(http.MethodPost, "/validate", bytes.NewReader(data))
+	require.NoError(t, err)
+	request.Header.Set(requestIDHeaderKey, "test-request-id")
+
+	router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+
+	problem := decodeProblemDetails(t, recorder)
+
+	require.Equal(t, CodeValidationFailed, problem.Code)
+	require.Equal(t, "test-request-id", problem.RequestID)
+	require.Equal(t, []FieldError{
+		{Field: "email", Message: "must be a valid email address"},
+		{Field: "ingredients[0].name", Message: "is required"},
+		{Field: "ingredients[0].amount", Message: "must be at least 1"},
+	}, problem.Errors)
+}
+
+func TestUnitNewErrorFromDB(t *testing.T) {
+	testCases := []struct {
+		name               string
+		err                error
+		expectedStatusCode int
+		expectedCode       ErrorCode
+	}{
+		{
+			name:               "Not found",
+			err:                fmt.Errorf("%w: failed to find recipe", db.ErrNotFound),
+			expectedStatusCode: http.StatusNotFound,
+			expectedCode:       CodeNotFound,
+		},
+		{
+			name:               "Invalid ID",
+			err:                fmt.Errorf("%w: failed to parse recipeID", db.ErrInvalidID),
+			expectedStatusCode: http.StatusBadRequest,
+			expectedCode:       CodeInvalidID,
+		},
+		{
+			name:               "Invalid sorting",
+			err:                fmt.Errorf("%w: sorting by password is not supported", db.ErrInvalidSort),
+			expectedStatusCode: http.StatusBadRequest,
+			expectedCode:       CodeInvalidSort,
+		},
+		{
+			name:               "Invalid cursor",
+			err:                fmt.Errorf("%w: cursor was created with a different sorting", db.ErrInvalidCursor),
+			expectedStatusCode: http.StatusBadRequest,
+			expectedCode:       CodeInvalidCursor,
+		},
+		{
+			name:               "Duplicate key",
+			err:                fmt.Errorf("%w: duplicate name", db.ErrDuplicateKey),
+			expectedStatusCode: http.StatusConflict,
+			expectedCode:       CodeDuplicateKey,
+		},
+		{
+			name:               "Referenced document",
+			err:                fmt.Errorf("%w: referenced by recipe", db.ErrReferenced),
+			expectedStatusCode: http.StatusConflict,
+			expectedCode:       CodeStillReferenced,
+		},
+		{
+			name:               "Unknown error",
+			err:                fmt.Errorf("connection refused"),
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedCode:       CodeInternalServerError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			router := gin.Default()
+			router.GET("/error", func(ctx *gin.Context) {
+				newErrorFromDB(tc.err).Send(ctx)
+			})
+
+			recorder := httptest.NewRecorder()
+			request, err := http.NewRequest(http.MethodGet, "/error", nil)
 			require.NoError(t, err)
 
-			require.Equal(t, tc.expectedMessage, errorBody.Message)
-			require.Equal(t, http.StatusText(tc.expectedStatusCode), errorBody.StatusText)
+			router.ServeHTTP(recorder, request)
+			require.Equal(t, tc.expectedStatusCode, recorder.Code)
+
+			problem := decodeProblemDetails(t, recorder)
+
+			require.Equal(t, tc.err.Error(), problem.Detail)
+			require.Equal(t, tc.expectedCode, problem.Code)
 		})
 	}
 }
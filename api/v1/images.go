@@ -28,9 +28,10 @@ var allowedFileTypes = []string{".png", ".jpg"}
 // @Param				authorization	header			string							false	"Authorization header for bearer token"
 // @Param				page_size			query 			int									true	"Number of elements in one page"
 // @Success			200
-// @Failure			400						{object}		ErrorBadRequest						"Bad Request"
-// @Failure			401						{object}		ErrorUnauthorized					"Unauthorized"
-// @Failure 		500						{object}		ErrorInternalServerError	"Internal Server Error"
+// @Failure			400						{object}		ProblemDetails						"Bad Request"
+// @Failure			401						{object}		ProblemDetails						"Unauthorized"
+// @Failure			403						{object}		ProblemDetails						"Forbidden"
+// @Failure 		500						{object}		ProblemDetails						"Internal Server Error"
 // @Router			/images				[post]
 func (server *Server) SaveImage(ctx *gin.Context) {
 	file, err := ctx.FormFile(createFormFileName)
@@ -67,9 +68,9 @@ func (server *Server) SaveImage(ctx *gin.Context) {
 // @Param				authorization				header			string							false	"Authorization header for bearer token"
 // @Param				page_size						query 			int									true	"Number of elements in one page"
 // @Success			200									{array}			byte											"Image for the given image name"
-// @Failure			400									{object}		ErrorBadRequest						"Bad Request"
-// @Failure			401									{object}		ErrorUnauthorized					"Unauthorized"
-// @Failure 		500									{object}		ErrorInternalServerError	"Internal Server Error"
+// @Failure			400									{object}		ProblemDetails						"Bad Request"
+// @Failure			401									{object}		ProblemDetails						"Unauthorized"
+// @Failure 		500									{object}		ProblemDetails						"Internal Server Error"
 // @Router			/images/{imageName}	[get]
 func (server *Server) GetImage(ctx *gin.Context) {
 	type imageNameURI struct {
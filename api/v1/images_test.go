@@ -11,6 +11,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/PfMartin/wegonice-api/db"
 	mock_db "github.com/PfMartin/wegonice-api/db/mock"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
@@ -58,6 +59,7 @@ func TestUnitSaveImage(t *testing.T) {
 			defer ctrl.Finish()
 
 			store := mock_db.NewMockDBStore(ctrl)
+			expectActiveSession(store)
 
 			server := newTestServer(t, store)
 
@@ -69,7 +71,7 @@ func TestUnitSaveImage(t *testing.T) {
 			request.Header.Set("Content-Type", w.FormDataContentType())
 
 			user, _ := randomUser(t)
-			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Email, time.Minute)
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Email, db.UserRole, time.Minute)
 
 			server.router.ServeHTTP(recorder, request)
 			require.Equal(t, tc.responseCode, recorder.Code)
@@ -106,6 +108,7 @@ func TestUnitServeImage(t *testing.T) {
 			defer ctrl.Finish()
 
 			store := mock_db.NewMockDBStore(ctrl)
+			expectActiveSession(store)
 
 			server := newTestServer(t, store)
 
@@ -116,7 +119,7 @@ func TestUnitServeImage(t *testing.T) {
 			require.NoError(t, err)
 
 			user, _ := randomUser(t)
-			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Email, time.Minute)
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Email, db.UserRole, time.Minute)
 
 			server.router.ServeHTTP(recorder, request)
 			require.Equal(t, tc.responseCode, recorder.Code)
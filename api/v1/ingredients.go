@@ -0,0 +1,282 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/PfMartin/wegonice-api/db"
+	"github.com/PfMartin/wegonice-api/nutrition"
+	"github.com/gin-gonic/gin"
+)
+
+// listIngredients
+//
+// @Summary			List all catalogue ingredients
+// @Description	All ingredients of the ingredient catalogue are listed in a paginated manner. They are sorted by name, if no sorting is provided.
+// @ID					ingredients-list-ingredients
+// @Tags				ingredients
+// @Accept			json
+// @Produce			json
+// @Param				authorization	header			string							false	"Authorization header for bearer token"
+// @Param				page_id				query 			int									false	"Offset for the pagination, required without cursor"
+// @Param				page_size			query 			int									true	"Number of elements in one page"
+// @Param				cursor				query 			string							false	"Cursor of the next page, which was returned with the previous page"
+// @Param				sort					query 			[]string						false	"Keys to sort by"	collectionFormat(multi)	Enums(name, defaultUnit, createdAt, modifiedAt)
+// @Param				order					query 			[]string						false	"Sort order per key"	collectionFormat(multi)	Enums(asc, desc)
+// @Success			200						{object}		IngredientListResponse		"Page of catalogue ingredients matching the given pagination parameters"
+// @Failure			400						{object}		ProblemDetails						"Bad Request"
+// @Failure			401						{object}		ProblemDetails						"Unauthorized"
+// @Failure 		500						{object}		ProblemDetails						"Internal Server Error"
+// @Router			/ingredients	[get]
+func (server *Server) listIngredients(ctx *gin.Context) {
+	var pagination db.Pagination
+	if err := ctx.ShouldBindQuery(&pagination); err != nil {
+		NewErrorBadRequest(err).Send(ctx)
+		return
+	}
+
+	var sorting db.Sorting
+	if err := ctx.ShouldBindQuery(&sorting); err != nil {
+		NewErrorBadRequest(err).Send(ctx)
+		return
+	}
+
+	ingredients, pageInfo, err := server.store.GetAllCatalogueIngredients(ctx, pagination, sorting)
+	if err != nil {
+		newErrorFromDB(err).Send(ctx)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newListResponse(ctx, ingredients, pagination, pageInfo))
+}
+
+// autocompleteIngredients
+//
+// @Summary			Autocomplete ingredient names
+// @Description	Catalogue ingredients, whose name or alias starts with the query, are returned sorted by name. Clients use them to link the ingredients of a recipe to the catalogue.
+// @ID					ingredients-autocomplete-ingredients
+// @Tags				ingredients
+// @Accept			json
+// @Produce			json
+// @Param				authorization							header			string							false	"Authorization header for bearer token"
+// @Param				q													query 			string							true	"Beginning of the ingredient name"
+// @Param				limit											query 			int									false	"Maximum number of suggestions, 10 by default"
+// @Success			200												{array}			CatalogueIngredient				"Catalogue ingredients starting with the query"
+// @Failure			400												{object}		ProblemDetails						"Bad Request"
+// @Failure			401												{object}		ProblemDetails						"Unauthorized"
+// @Failure 		500												{object}		ProblemDetails						"Internal Server Error"
+// @Router			/ingredients/autocomplete	[get]
+func (server *Server) autocompleteIngredients(ctx *gin.Context) {
+	var autocomplete db.IngredientAutocomplete
+	if err := ctx.ShouldBindQuery(&autocomplete); err != nil {
+		NewErrorBadRequest(err).Send(ctx)
+		return
+	}
+
+	ingredients, err := server.store.AutocompleteCatalogueIngredients(ctx, autocomplete)
+	if err != nil {
+		newErrorFromDB(err).Send(ctx)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, ingredients)
+}
+
+// createIngredient
+//
+// @Summary			Create new catalogue ingredient
+// @Description	Creates a new ingredient in the ingredient catalogue. The name and the aliases are stored trimmed and lowercase.
+// @ID					ingredients-create-ingredient
+// @Tags				ingredients
+// @Accept			json
+// @Produce			json
+// @Param				authorization		header			string												false	"Authorization header for bearer token"
+// @Param				data						body 				CatalogueIngredientToCreate		true	"Data for the catalogue ingredient to create"
+// @Success			201							string			string										"ID of the created catalogue ingredient"
+// @Failure			400							{object}		ProblemDetails						"Bad Request"
+// @Failure			401							{object}		ProblemDetails						"Unauthorized"
+// @Failure			403							{object}		ProblemDetails						"Forbidden"
+// @Failure			409							{object}		ProblemDetails						"Conflict, a catalogue ingredient with this name already exists"
+// @Failure 		500							{object}		ProblemDetails						"Internal Server Error"
+// @Router			/ingredients		[post]
+func (server *Server) createIngredient(ctx *gin.Context) {
+	var ingredientBody db.CatalogueIngredientToCreate
+	if err := ctx.ShouldBindJSON(&ingredientBody); err != nil {
+		NewErrorBadRequest(err).Send(ctx)
+		return
+	}
+
+	ingredientID, err := server.store.CreateCatalogueIngredient(ctx, ingredientBody)
+	if err != nil {
+		newErrorFromDB(err).Send(ctx)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, ingredientID)
+}
+
+// getIngredientByID
+//
+// @Summary			Get one catalogue ingredient by ID
+// @Description	One catalogue ingredient, which matches the ID, is returned
+// @ID					ingredients-get-ingredient-by-id
+// @Tags				ingredients
+// @Accept			json
+// @Produce			json
+// @Param				authorization				header			string							false	"Authorization header for bearer token"
+// @Param				id									path 				string							true	"ID of the desired catalogue ingredient"
+// @Success			200									{object}		CatalogueIngredient				"Catalogue ingredient that matches the ID"
+// @Failure			400									{object}		ProblemDetails						"Bad Request"
+// @Failure			401									{object}		ProblemDetails						"Unauthorized"
+// @Failure			404									{object}		ProblemDetails						"Not Found"
+// @Failure 		500									{object}		ProblemDetails						"Internal Server Error"
+// @Router			/ingredients/{id}		[get]
+func (server *Server) getIngredientByID(ctx *gin.Context) {
+	var uriParam getByIDRequest
+	if err := ctx.ShouldBindUri(&uriParam); err != nil {
+		NewErrorBadRequest(err).Send(ctx)
+		return
+	}
+
+	ingredient, err := server.store.GetCatalogueIngredientByID(ctx, uriParam.ID)
+	if err != nil {
+		newErrorFromDB(err).Send(ctx)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, ingredient)
+}
+
+// patchIngredientByID
+//
+// @Summary			Patch one catalogue ingredient by ID
+// @Description	One catalogue ingredient, which matches the ID, is modified with the provided patch. The catalogue is shared by all users, so only users, who are allowed to manage content, are allowed to patch it.
+// @ID					ingredients-patch-ingredient-by-id
+// @Tags				ingredients
+// @Accept			json
+// @Produce			json
+// @Param				authorization				header			string												false	"Authorization header for bearer token"
+// @Param				id									path 				string												true	"ID of the desired catalogue ingredient to patch"
+// @Param				data								body 				CatalogueIngredientUpdate			true	"Patch for modifying the catalogue ingredient"
+// @Success			200
+// @Failure			400									{object}		ProblemDetails						"Bad Request"
+// @Failure			401									{object}		ProblemDetails						"Unauthorized"
+// @Failure			403									{object}		ProblemDetails						"Forbidden"
+// @Failure			404									{object}		ProblemDetails						"Not Found"
+// @Failure			409									{object}		ProblemDetails						"Conflict, a catalogue ingredient with this name already exists"
+// @Failure 		500									{object}		ProblemDetails						"Internal Server Error"
+// @Router			/ingredients/{id}		[patch]
+func (server *Server) patchIngredientByID(ctx *gin.Context) {
+	var uriParam getByIDRequest
+	if err := ctx.ShouldBindUri(&uriParam); err != nil {
+		NewErrorBadRequest(err).Send(ctx)
+		return
+	}
+
+	var ingredientPatch db.CatalogueIngredientUpdate
+	if err := ctx.ShouldBindJSON(&ingredientPatch); err != nil {
+		NewErrorBadRequest(err).Send(ctx)
+		return
+	}
+
+	if ingredientPatch.Name == "" &&
+		len(ingredientPatch.Aliases) == 0 &&
+		ingredientPatch.DefaultUnit == "" &&
+		ingredientPatch.PieceWeightG == 0 &&
+		ingredientPatch.Nutrients == nil {
+		NewErrorBadRequest(fmt.Errorf("missing ingredient patch")).Send(ctx)
+		return
+	}
+
+	// The name is needed to remove it from the patched aliases.
+	if ingredientPatch.Name == "" && len(ingredientPatch.Aliases) != 0 {
+		existingIngredient, err := server.store.GetCatalogueIngredientByID(ctx, uriParam.ID)
+		if err != nil {
+			newErrorFromDB(err).Send(ctx)
+			return
+		}
+		ingredientPatch.Name = existingIngredient.Name
+	}
+
+	modifiedCount, err := server.store.UpdateCatalogueIngredientByID(ctx, uriParam.ID, ingredientPatch)
+	if err != nil {
+		newErrorFromDB(err).Send(ctx)
+		return
+	}
+
+	if modifiedCount < 1 {
+		NewErrorNotFound(fmt.Errorf("could not find ingredient with ID: %s", uriParam.ID)).Send(ctx)
+		return
+	}
+
+	ctx.Status(http.StatusOK)
+}
+
+// deleteIngredientByID
+//
+// @Summary			Delete one catalogue ingredient by ID
+// @Description	One catalogue ingredient, which matches the ID, is deleted. Only users, who are allowed to manage content, are allowed to delete it.
+// @ID					ingredients-delete-ingredient-by-id
+// @Tags				ingredients
+// @Accept			json
+// @Produce			json
+// @Param				authorization				header			string							false	"Authorization header for bearer token"
+// @Param				id									path 				string							true	"ID of the desired catalogue ingredient to delete"
+// @Success			200
+// @Failure			400									{object}		ProblemDetails						"Bad Request"
+// @Failure			401									{object}		ProblemDetails						"Unauthorized"
+// @Failure			403									{object}		ProblemDetails						"Forbidden"
+// @Failure			404									{object}		ProblemDetails						"Not Found"
+// @Failure			409									{object}		ProblemDetails						"Conflict, the ingredient is still linked to at least one recipe or substitution"
+// @Failure 		500									{object}		ProblemDetails						"Internal Server Error"
+// @Router			/ingredients/{id}		[delete]
+func (server *Server) deleteIngredientByID(ctx *gin.Context) {
+	var uriParam getByIDRequest
+	if err := ctx.ShouldBindUri(&uriParam); err != nil {
+		NewErrorBadRequest(err).Send(ctx)
+		return
+	}
+
+	deleteCount, err := server.store.DeleteCatalogueIngredientByID(ctx, uriParam.ID)
+	if err != nil {
+		newErrorFromDB(err).Send(ctx)
+		return
+	}
+
+	if deleteCount < 1 {
+		NewErrorNotFound(fmt.Errorf("could not find ingredient with ID: %s", uriParam.ID)).Send(ctx)
+		return
+	}
+
+	ctx.Status(http.StatusOK)
+}
+
+// importIngredientNutrients
+//
+// @Summary			Import the bundled nutrient dataset
+// @Description	The nutrients of common ingredients, which are bundled with the API, are imported into the ingredient catalogue. Missing catalogue ingredients are created and the nutrients of existing ones are updated.
+// @ID					admin-import-ingredient-nutrients
+// @Tags				admin
+// @Accept			json
+// @Produce			json
+// @Param				authorization								header			string							false	"Authorization header for bearer token"
+// @Success			200													{object}		CatalogueImportResult		"Number of inserted and updated catalogue ingredients"
+// @Failure			401													{object}		ProblemDetails						"Unauthorized"
+// @Failure			403													{object}		ProblemDetails						"Forbidden"
+// @Failure 		500													{object}		ProblemDetails						"Internal Server Error"
+// @Router			/admin/ingredients/import		[post]
+func (server *Server) importIngredientNutrients(ctx *gin.Context) {
+	ingredients, err := nutrition.LoadDataset()
+	if err != nil {
+		NewErrorInternalServerError(err).Send(ctx)
+		return
+	}
+
+	importResult, err := server.store.ImportCatalogueIngredients(ctx, ingredients)
+	if err != nil {
+		newErrorFromDB(err).Send(ctx)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, importResult)
+}
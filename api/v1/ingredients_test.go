@@ -0,0 +1,702 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/PfMartin/wegonice-api/db"
+	mock_db "github.com/PfMartin/wegonice-api/db/mock"
+	"github.com/PfMartin/wegonice-api/nutrition"
+	"github.com/PfMartin/wegonice-api/util"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func randomCatalogueIngredient(t *testing.T) (db.CatalogueIngredient, primitive.ObjectID) {
+	t.Helper()
+
+	ingredientID := primitive.NewObjectID()
+
+	return db.CatalogueIngredient{
+		ID:          ingredientID.Hex(),
+		Name:        util.RandomString(8),
+		Aliases:     []string{util.RandomString(8), util.RandomString(8)},
+		DefaultUnit: db.Grams,
+		CreatedAt:   time.Now().Unix(),
+		ModifiedAt:  time.Now().Unix(),
+	}, ingredientID
+}
+
+func TestUnitListIngredients(t *testing.T) {
+	user, _ := randomUser(t)
+	var ingredients []db.CatalogueIngredient
+	for i := 0; i < 10; i++ {
+		ingredient, _ := randomCatalogueIngredient(t)
+
+		ingredients = append(ingredients, ingredient)
+	}
+
+	testCases := []struct {
+		name          string
+		query         string
+		buildStubs    func(store *mock_db.MockDBStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "Success with pagination from 1 to 10",
+			query: "?page_id=1&page_size=10",
+			buildStubs: func(store *mock_db.MockDBStore) {
+				pagination := db.Pagination{
+					PageID:   1,
+					PageSize: 10,
+				}
+
+				store.EXPECT().GetAllCatalogueIngredients(gomock.Any(), pagination, db.Sorting{}).Times(1).Return(ingredients, db.PageInfo{TotalCount: 25, NextCursor: "next-cursor"}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var gotPage IngredientListResponse
+				err := json.NewDecoder(recorder.Body).Decode(&gotPage)
+				require.NoError(t, err)
+
+				require.Equal(t, ingredients, gotPage.Items)
+				require.Equal(t, int64(25), gotPage.TotalCount)
+				require.True(t, gotPage.HasNext)
+			},
+		},
+		{
+			name:  "Fail with missing page_size",
+			query: "?page_id=1",
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().GetAllCatalogueIngredients(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "Fail with unsupported sort key",
+			query: "?page_id=1&page_size=10&sort=aliases",
+			buildStubs: func(store *mock_db.MockDBStore) {
+				pagination := db.Pagination{
+					PageID:   1,
+					PageSize: 10,
+				}
+				sorting := db.Sorting{Sort: []string{"aliases"}}
+
+				store.EXPECT().GetAllCatalogueIngredients(gomock.Any(), pagination, sorting).Times(1).Return(nil, db.PageInfo{}, db.ErrInvalidSort)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mock_db.NewMockDBStore(ctrl)
+			expectActiveSession(store)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodGet, "/api/v1/ingredients"+tc.query, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.ID, db.UserRole, time.Minute)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestUnitAutocompleteIngredients(t *testing.T) {
+	user, _ := randomUser(t)
+	ingredient, _ := randomCatalogueIngredient(t)
+
+	testCases := []struct {
+		name          string
+		query         string
+		buildStubs    func(store *mock_db.MockDBStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "Success completing the ingredient name",
+			query: "?q=" + ingredient.Name[:3] + "&limit=5",
+			buildStubs: func(store *mock_db.MockDBStore) {
+				autocomplete := db.IngredientAutocomplete{Query: ingredient.Name[:3], Limit: 5}
+
+				store.EXPECT().AutocompleteCatalogueIngredients(gomock.Any(), autocomplete).Times(1).Return([]db.CatalogueIngredient{ingredient}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var gotIngredients []db.CatalogueIngredient
+				err := json.NewDecoder(recorder.Body).Decode(&gotIngredients)
+				require.NoError(t, err)
+
+				require.Equal(t, []db.CatalogueIngredient{ingredient}, gotIngredients)
+			},
+		},
+		{
+			name:  "Fail with missing query",
+			query: "",
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().AutocompleteCatalogueIngredients(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "Fail with too large limit",
+			query: "?q=fl&limit=51",
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().AutocompleteCatalogueIngredients(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mock_db.NewMockDBStore(ctrl)
+			expectActiveSession(store)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodGet, "/api/v1/ingredients/autocomplete"+tc.query, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.ID, db.UserRole, time.Minute)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestUnitCreateIngredient(t *testing.T) {
+	user, _ := randomUser(t)
+	ingredient, primitiveID := randomCatalogueIngredient(t)
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mock_db.MockDBStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "Success creating a new catalogue ingredient",
+			body: gin.H{
+				"name":        ingredient.Name,
+				"aliases":     ingredient.Aliases,
+				"defaultUnit": ingredient.DefaultUnit,
+			},
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().CreateCatalogueIngredient(gomock.Any(), db.CatalogueIngredientToCreate{
+					Name:        ingredient.Name,
+					Aliases:     ingredient.Aliases,
+					DefaultUnit: ingredient.DefaultUnit,
+				}).Times(1).Return(primitiveID, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "Fail due to missing name",
+			body: gin.H{
+				"aliases": ingredient.Aliases,
+			},
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().CreateCatalogueIngredient(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "Fail due to unsupported default unit",
+			body: gin.H{
+				"name":        ingredient.Name,
+				"defaultUnit": "handful",
+			},
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().CreateCatalogueIngredient(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "Fail due to empty alias",
+			body: gin.H{
+				"name":    ingredient.Name,
+				"aliases": []string{""},
+			},
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().CreateCatalogueIngredient(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "Fail due to already existing catalogue ingredient",
+			body: gin.H{
+				"name": ingredient.Name,
+			},
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().CreateCatalogueIngredient(gomock.Any(), db.CatalogueIngredientToCreate{
+					Name: ingredient.Name,
+				}).Times(1).Return(primitive.NilObjectID, db.ErrDuplicateKey)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mock_db.NewMockDBStore(ctrl)
+			expectActiveSession(store)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, "/api/v1/ingredients/", bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.ID, db.UserRole, time.Minute)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestUnitGetIngredientByID(t *testing.T) {
+	user, _ := randomUser(t)
+	ingredient, _ := randomCatalogueIngredient(t)
+	nonMatchingID := primitive.NewObjectID().Hex()
+
+	testCases := []struct {
+		name          string
+		id            string
+		buildStubs    func(store *mock_db.MockDBStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "Success getting the catalogue ingredient",
+			id:   ingredient.ID,
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().GetCatalogueIngredientByID(gomock.Any(), ingredient.ID).Times(1).Return(ingredient, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var gotIngredient db.CatalogueIngredient
+				err := json.NewDecoder(recorder.Body).Decode(&gotIngredient)
+				require.NoError(t, err)
+
+				require.Equal(t, ingredient, gotIngredient)
+			},
+		},
+		{
+			name: "Fail due to the provided ingredientID not being valid",
+			id:   "not-valid-id",
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().GetCatalogueIngredientByID(gomock.Any(), "not-valid-id").Times(1).Return(db.CatalogueIngredient{}, db.ErrInvalidID)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "Fail due to no matching catalogue ingredient",
+			id:   nonMatchingID,
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().GetCatalogueIngredientByID(gomock.Any(), nonMatchingID).Times(1).Return(db.CatalogueIngredient{}, db.ErrNotFound)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mock_db.NewMockDBStore(ctrl)
+			expectActiveSession(store)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodGet, fmt.Sprintf("/api/v1/ingredients/%s", tc.id), nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.ID, db.UserRole, time.Minute)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestUnitPatchIngredientByID(t *testing.T) {
+	user, _ := randomUser(t)
+	admin := randomAdmin(t)
+	ingredient, _ := randomCatalogueIngredient(t)
+	nonMatchingID := primitive.NewObjectID().Hex()
+
+	testCases := []struct {
+		name          string
+		id            string
+		userID        string
+		role          db.Role
+		body          gin.H
+		buildStubs    func(store *mock_db.MockDBStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "Success patching the name as an admin",
+			id:     ingredient.ID,
+			userID: admin.ID,
+			role:   db.AdminRole,
+			body:   gin.H{"name": "flour", "defaultUnit": db.Cup},
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().GetCatalogueIngredientByID(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().UpdateCatalogueIngredientByID(gomock.Any(), ingredient.ID, db.CatalogueIngredientUpdate{
+					Name:        "flour",
+					DefaultUnit: db.Cup,
+				}).Times(1).Return(int64(1), nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:   "Success patching the aliases with the existing name",
+			id:     ingredient.ID,
+			userID: admin.ID,
+			role:   db.AdminRole,
+			body:   gin.H{"aliases": []string{"wheat flour"}},
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().GetCatalogueIngredientByID(gomock.Any(), ingredient.ID).Times(1).Return(ingredient, nil)
+				store.EXPECT().UpdateCatalogueIngredientByID(gomock.Any(), ingredient.ID, db.CatalogueIngredientUpdate{
+					Name:    ingredient.Name,
+					Aliases: []string{"wheat flour"},
+				}).Times(1).Return(int64(1), nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:   "Success patching the nutrients",
+			id:     ingredient.ID,
+			userID: admin.ID,
+			role:   db.AdminRole,
+			body:   gin.H{"pieceWeightG": 118, "nutrients": gin.H{"kcal": 89, "protein": 1.1, "fat": 0.3, "carbs": 22.8, "fibre": 2.6}},
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().UpdateCatalogueIngredientByID(gomock.Any(), ingredient.ID, db.CatalogueIngredientUpdate{
+					PieceWeightG: 118,
+					Nutrients:    &db.Nutrients{KCal: 89, Protein: 1.1, Fat: 0.3, Carbs: 22.8, Fibre: 2.6},
+				}).Times(1).Return(int64(1), nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:   "Fail due to negative nutrients",
+			id:     ingredient.ID,
+			userID: admin.ID,
+			role:   db.AdminRole,
+			body:   gin.H{"nutrients": gin.H{"kcal": -89}},
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().UpdateCatalogueIngredientByID(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:   "Fail due to missing permission",
+			id:     ingredient.ID,
+			userID: user.ID,
+			role:   db.UserRole,
+			body:   gin.H{"name": "flour"},
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().UpdateCatalogueIngredientByID(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:   "Fail due to missing patch",
+			id:     ingredient.ID,
+			userID: admin.ID,
+			role:   db.AdminRole,
+			body:   gin.H{},
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().UpdateCatalogueIngredientByID(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:   "Fail due to no matching catalogue ingredient",
+			id:     nonMatchingID,
+			userID: admin.ID,
+			role:   db.AdminRole,
+			body:   gin.H{"name": "flour"},
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().UpdateCatalogueIngredientByID(gomock.Any(), nonMatchingID, gomock.Any()).Times(1).Return(int64(0), nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:   "Fail due to already existing name",
+			id:     ingredient.ID,
+			userID: admin.ID,
+			role:   db.AdminRole,
+			body:   gin.H{"name": "flour"},
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().UpdateCatalogueIngredientByID(gomock.Any(), ingredient.ID, gomock.Any()).Times(1).Return(int64(0), db.ErrDuplicateKey)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mock_db.NewMockDBStore(ctrl)
+			expectActiveSession(store)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPatch, fmt.Sprintf("/api/v1/ingredients/%s", tc.id), bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, tc.userID, tc.role, time.Minute)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestUnitDeleteIngredientByID(t *testing.T) {
+	user, _ := randomUser(t)
+	admin := randomAdmin(t)
+	ingredient, _ := randomCatalogueIngredient(t)
+	nonMatchingID := primitive.NewObjectID().Hex()
+
+	testCases := []struct {
+		name          string
+		id            string
+		userID        string
+		role          db.Role
+		buildStubs    func(store *mock_db.MockDBStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "Success deleting the catalogue ingredient as an admin",
+			id:     ingredient.ID,
+			userID: admin.ID,
+			role:   db.AdminRole,
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().DeleteCatalogueIngredientByID(gomock.Any(), ingredient.ID).Times(1).Return(int64(1), nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:   "Fail due to missing permission",
+			id:     ingredient.ID,
+			userID: user.ID,
+			role:   db.UserRole,
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().DeleteCatalogueIngredientByID(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:   "Fail due to the ingredient being linked in a recipe",
+			id:     ingredient.ID,
+			userID: admin.ID,
+			role:   db.AdminRole,
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().DeleteCatalogueIngredientByID(gomock.Any(), ingredient.ID).Times(1).Return(int64(0), db.ErrReferenced)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name:   "Fail due to no matching catalogue ingredient",
+			id:     nonMatchingID,
+			userID: admin.ID,
+			role:   db.AdminRole,
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().DeleteCatalogueIngredientByID(gomock.Any(), nonMatchingID).Times(1).Return(int64(0), nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mock_db.NewMockDBStore(ctrl)
+			expectActiveSession(store)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodDelete, fmt.Sprintf("/api/v1/ingredients/%s", tc.id), nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, tc.userID, tc.role, time.Minute)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestUnitImportIngredientNutrients(t *testing.T) {
+	user, _ := randomUser(t)
+	admin := randomAdmin(t)
+
+	dataset, err := nutrition.LoadDataset()
+	require.NoError(t, err)
+
+	importResult := db.CatalogueImportResult{InsertedCount: 12, UpdatedCount: int64(len(dataset) - 12)}
+
+	testCases := []struct {
+		name          string
+		userID        string
+		role          db.Role
+		buildStubs    func(store *mock_db.MockDBStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "Success importing the bundled dataset",
+			userID: admin.ID,
+			role:   db.AdminRole,
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().ImportCatalogueIngredients(gomock.Any(), dataset).Times(1).Return(importResult, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var gotImportResult db.CatalogueImportResult
+				err := json.NewDecoder(recorder.Body).Decode(&gotImportResult)
+				require.NoError(t, err)
+
+				require.Equal(t, importResult, gotImportResult)
+			},
+		},
+		{
+			name:   "Fail due to missing admin role",
+			userID: user.ID,
+			role:   db.UserRole,
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().ImportCatalogueIngredients(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:   "Fail with internal server error",
+			userID: admin.ID,
+			role:   db.AdminRole,
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().ImportCatalogueIngredients(gomock.Any(), gomock.Any()).Times(1).Return(db.CatalogueImportResult{}, fmt.Errorf("connection lost"))
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mock_db.NewMockDBStore(ctrl)
+			expectActiveSession(store)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodPost, "/api/v1/admin/ingredients/import", nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, tc.userID, tc.role, time.Minute)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package api
+
+import (
+	"strconv"
+
+	"github.com/PfMartin/wegonice-api/db"
+	"github.com/gin-gonic/gin"
+)
+
+type PageLinks struct {
+	Prev string `json:"prev,omitempty" example:"/api/v1/recipes?page_id=1&page_size=10"`
+	Next string `json:"next,omitempty" example:"/api/v1/recipes?page_id=3&page_size=10"`
+} // @name PageLinks
+
+// PageMetadata describes the position of a page in the whole list, so clients are able to
+// render a pagination and to follow the links to the neighbouring pages. Pages, which are
+// requested with a cursor, have no page number and no link to the previous page.
+type PageMetadata struct {
+	TotalCount int64     `json:"totalCount" example:"42"`
+	Page       int64     `json:"page,omitempty" example:"2"`
+	PageSize   int64     `json:"pageSize" example:"10"`
+	HasNext    bool      `json:"hasNext" example:"true"`
+	NextCursor string    `json:"nextCursor,omitempty" example:"SQAAAARrABMAAAACMAAFAAAAbmFtZQAABG8ADAAAABAwAAEAAAAABHYAFQAAAAIwAAkAAABQYW5jYWtlcwAAB2lkAGYMS5m8G8SqvhJs0QA"`
+	Links      PageLinks `json:"links"`
+} // @name PageMetadata
+
+type listResponse[T any] struct {
+	Items []T `json:"items"`
+	PageMetadata
+}
+
+func newListResponse[T any](ctx *gin.Context, items []T, pagination db.Pagination, pageInfo db.PageInfo) listResponse[T] {
+	if items == nil {
+		items = []T{}
+	}
+
+	metadata := PageMetadata{
+		TotalCount: pageInfo.TotalCount,
+		Page:       pagination.PageID,
+		PageSize:   pagination.PageSize,
+		HasNext:    pageInfo.NextCursor != "",
+		NextCursor: pageInfo.NextCursor,
+	}
+
+	if pagination.Cursor != "" {
+		if metadata.HasNext {
+			metadata.Links.Next = getCursorLink(ctx, pageInfo.NextCursor)
+		}
+	} else {
+		if metadata.HasNext {
+			metadata.Links.Next = getPageLink(ctx, pagination.PageID+1)
+		}
+
+		if pagination.PageID > 1 {
+			metadata.Links.Prev = getPageLink(ctx, pagination.PageID-1)
+		}
+	}
+
+	return listResponse[T]{
+		Items:        items,
+		PageMetadata: metadata,
+	}
+}
+
+// getPageLink returns the path and query of the current request with the given page. All
+// other query parameters like filters and sorting are kept.
+func getPageLink(ctx *gin.Context, pageID int64) string {
+	query := ctx.Request.URL.Query()
+	query.Set("page_id", strconv.FormatInt(pageID, 10))
+
+	return ctx.Request.URL.Path + "?" + query.Encode()
+}
+
+func getCursorLink(ctx *gin.Context, cursor string) string {
+	query := ctx.Request.URL.Query()
+	query.Del("page_id")
+	query.Set("cursor", cursor)
+
+	return ctx.Request.URL.Path + "?" + query.Encode()
+}
@@ -0,0 +1,143 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/PfMartin/wegonice-api/db"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnitNewListResponse(t *testing.T) {
+	testCases := []struct {
+		name             string
+		query            string
+		items            []string
+		pagination       db.Pagination
+		pageInfo         db.PageInfo
+		expectedItems    []string
+		expectedMetadata PageMetadata
+	}{
+		{
+			name:          "First page with next page",
+			query:         "?page_id=1&page_size=2",
+			items:         []string{"a", "b"},
+			pagination:    db.Pagination{PageID: 1, PageSize: 2},
+			pageInfo:      db.PageInfo{TotalCount: 5, NextCursor: "cursor-b"},
+			expectedItems: []string{"a", "b"},
+			expectedMetadata: PageMetadata{
+				TotalCount: 5,
+				Page:       1,
+				PageSize:   2,
+				HasNext:    true,
+				NextCursor: "cursor-b",
+				Links: PageLinks{
+					Next: "/list?page_id=2&page_size=2",
+				},
+			},
+		},
+		{
+			name:          "Middle page keeps other query parameters",
+			query:         "?category=main&page_id=2&page_size=2&sort=name",
+			items:         []string{"c", "d"},
+			pagination:    db.Pagination{PageID: 2, PageSize: 2},
+			pageInfo:      db.PageInfo{TotalCount: 5, NextCursor: "cursor-d"},
+			expectedItems: []string{"c", "d"},
+			expectedMetadata: PageMetadata{
+				TotalCount: 5,
+				Page:       2,
+				PageSize:   2,
+				HasNext:    true,
+				NextCursor: "cursor-d",
+				Links: PageLinks{
+					Prev: "/list?category=main&page_id=1&page_size=2&sort=name",
+					Next: "/list?category=main&page_id=3&page_size=2&sort=name",
+				},
+			},
+		},
+		{
+			name:          "Last page",
+			query:         "?page_id=3&page_size=2",
+			items:         []string{"e"},
+			pagination:    db.Pagination{PageID: 3, PageSize: 2},
+			pageInfo:      db.PageInfo{TotalCount: 5},
+			expectedItems: []string{"e"},
+			expectedMetadata: PageMetadata{
+				TotalCount: 5,
+				Page:       3,
+				PageSize:   2,
+				Links: PageLinks{
+					Prev: "/list?page_id=2&page_size=2",
+				},
+			},
+		},
+		{
+			name:          "Cursor page with next page",
+			query:         "?cursor=cursor-b&page_size=2&sort=name",
+			items:         []string{"c", "d"},
+			pagination:    db.Pagination{Cursor: "cursor-b", PageSize: 2},
+			pageInfo:      db.PageInfo{TotalCount: 5, NextCursor: "cursor-d"},
+			expectedItems: []string{"c", "d"},
+			expectedMetadata: PageMetadata{
+				TotalCount: 5,
+				PageSize:   2,
+				HasNext:    true,
+				NextCursor: "cursor-d",
+				Links: PageLinks{
+					Next: "/list?cursor=cursor-d&page_size=2&sort=name",
+				},
+			},
+		},
+		{
+			name:          "Last cursor page",
+			query:         "?cursor=cursor-d&page_size=2",
+			items:         []string{"e"},
+			pagination:    db.Pagination{Cursor: "cursor-d", PageSize: 2},
+			pageInfo:      db.PageInfo{TotalCount: 5},
+			expectedItems: []string{"e"},
+			expectedMetadata: PageMetadata{
+				TotalCount: 5,
+				PageSize:   2,
+			},
+		},
+		{
+			name:          "Empty list",
+			query:         "?page_id=1&page_size=2",
+			items:         nil,
+			pagination:    db.Pagination{PageID: 1, PageSize: 2},
+			pageInfo:      db.PageInfo{},
+			expectedItems: []string{},
+			expectedMetadata: PageMetadata{
+				Page:     1,
+				PageSize: 2,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			router := gin.Default()
+			router.GET("/list", func(ctx *gin.Context) {
+				ctx.JSON(http.StatusOK, newListResponse(ctx, tc.items, tc.pagination, tc.pageInfo))
+			})
+
+			recorder := httptest.NewRecorder()
+			request, err := http.NewRequest(http.MethodGet, "/list"+tc.query, nil)
+			require.NoError(t, err)
+
+			router.ServeHTTP(recorder, request)
+			require.Equal(t, http.StatusOK, recorder.Code)
+			require.Contains(t, recorder.Body.String(), `"items":[`)
+
+			var gotResponse listResponse[string]
+			err = json.NewDecoder(recorder.Body).Decode(&gotResponse)
+			require.NoError(t, err)
+
+			require.Equal(t, tc.expectedItems, gotResponse.Items)
+			require.Equal(t, tc.expectedMetadata, gotResponse.PageMetadata)
+		})
+	}
+}
@@ -2,10 +2,13 @@ package api
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 
+	"github.com/PfMartin/wegonice-api/db"
 	"github.com/PfMartin/wegonice-api/token"
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 )
 
 const (
@@ -14,7 +17,30 @@ const (
 	authorizationPayloadKey = "authorization_payload"
 )
 
-func authMiddleware(tokenMaker token.Maker) gin.HandlerFunc {
+const (
+	requestIDHeaderKey = "X-Request-ID"
+	requestIDKey       = "request_id"
+	maxRequestIDLength = 128
+)
+
+// requestIDMiddleware reuses the request ID provided by the client or creates a new one.
+// The ID is added to the response headers and to every error response.
+func requestIDMiddleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		requestID := ctx.GetHeader(requestIDHeaderKey)
+		if requestID == "" || len(requestID) > maxRequestIDLength {
+			requestID = uuid.NewString()
+		}
+
+		ctx.Set(requestIDKey, requestID)
+		ctx.Header(requestIDHeaderKey, requestID)
+		ctx.Next()
+	}
+}
+
+// authMiddleware verifies the access token and checks that its session has not been revoked,
+// so logging out or deleting a user takes effect before the access token expires.
+func authMiddleware(tokenMaker token.Maker, store db.DBStore) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		authorizationHeader := ctx.GetHeader(authorizationHeaderKey)
 		if len(authorizationHeader) == 0 {
@@ -35,8 +61,19 @@ func authMiddleware(tokenMaker token.Maker) gin.HandlerFunc {
 		}
 
 		accessToken := fields[1]
-		payload, err := tokenMaker.VerifyToken(accessToken)
+		payload, err := tokenMaker.VerifyToken(accessToken, token.AccessToken)
+		if err != nil {
+			NewErrorUnauthorized(err).Send(ctx)
+			return
+		}
+
+		session, err := store.GetSessionByID(ctx, payload.SessionID)
 		if err != nil {
+			NewErrorUnauthorized(fmt.Errorf("session of the access token is invalid: %w", err)).Send(ctx)
+			return
+		}
+
+		if err := checkSession(session); err != nil {
 			NewErrorUnauthorized(err).Send(ctx)
 			return
 		}
@@ -45,3 +82,84 @@ func authMiddleware(tokenMaker token.Maker) gin.HandlerFunc {
 		ctx.Next()
 	}
 }
+
+// checkSession checks that the tokens of the session can still be used.
+func checkSession(session db.Session) error {
+	if session.IsBlocked {
+		return fmt.Errorf("session %s is blocked", session.ID)
+	}
+
+	if session.User.ID == "" {
+		return fmt.Errorf("user of session %s does not exist anymore", session.ID)
+	}
+
+	return nil
+}
+
+type permission string
+
+const (
+	permissionWriteContent   permission = "content:write"
+	permissionDeleteContent  permission = "content:delete"
+	permissionManageContent  permission = "content:manage"
+	permissionManageSessions permission = "sessions:manage"
+	permissionManageUsers    permission = "users:manage"
+)
+
+var rolePermissions = map[db.Role][]permission{
+	db.UserRole: {
+		permissionWriteContent,
+		permissionDeleteContent,
+	},
+	db.AdminRole: {
+		permissionWriteContent,
+		permissionDeleteContent,
+		permissionManageContent,
+		permissionManageSessions,
+		permissionManageUsers,
+	},
+}
+
+func getPayloadRole(payload *token.Payload) db.Role {
+	if payload.Role == "" {
+		return db.UserRole
+	}
+
+	return db.Role(payload.Role)
+}
+
+func hasPermission(role db.Role, requiredPermission permission) bool {
+	return slices.Contains(rolePermissions[role], requiredPermission)
+}
+
+func isOwnerOrPermitted(payload *token.Payload, ownerID string, requiredPermission permission) bool {
+	return payload.UserID == ownerID || hasPermission(getPayloadRole(payload), requiredPermission)
+}
+
+func requireRole(roles ...db.Role) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		payload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+		role := getPayloadRole(payload)
+		if !slices.Contains(roles, role) {
+			NewErrorForbidden(fmt.Errorf("role %s is not allowed to access this resource", role)).Send(ctx)
+			return
+		}
+
+		ctx.Next()
+	}
+}
+
+func requirePermission(requiredPermission permission) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		payload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+		role := getPayloadRole(payload)
+		if !hasPermission(role, requiredPermission) {
+			NewErrorForbidden(fmt.Errorf("role %s is missing the permission %s", role, requiredPermission)).Send(ctx)
+			return
+		}
+
+		ctx.Next()
+	}
+}
@@ -1,11 +1,22 @@
 package api
 
-import "github.com/PfMartin/wegonice-api/db"
+import (
+	"github.com/PfMartin/wegonice-api/conversion"
+	"github.com/PfMartin/wegonice-api/db"
+)
 
 type getByIDRequest struct {
 	ID string `uri:"id" binding:"required"`
 }
 
+type scaleRecipeQuery struct {
+	Servings int `form:"servings" binding:"omitempty,min=1,max=100"`
+}
+
+type unitsQuery struct {
+	Units conversion.UnitSystem `form:"units" binding:"omitempty,oneof=metric imperial"`
+}
+
 type authUserBody struct {
 	Email    string `json:"email,omitempty" binding:"required" example:"user@example.com"` //TODO: Email validation
 	Password string `json:"password,omitempty" binding:"required,min=6" example:"s3cr3tP@ssw0rd"`
@@ -20,6 +31,34 @@ type loginResponse struct {
 	UserEmail             string `json:"userEmail" example:"user@example.com"`
 } // @name loginResponse
 
+type refreshTokenBody struct {
+	SessionID    string `json:"sessionId" binding:"required" example:"660c4b99bc1bc4aabe3e6cd1"`
+	RefreshToken string `json:"refreshToken" binding:"required" example:"v2.local.example-refresh-token"`
+} // @name refreshTokenBody
+
+type refreshTokenResponse struct {
+	AccessToken          string `json:"accessToken" example:"v2.local.example-session-token"`
+	AccessTokenExpiresAt int64  `json:"accessTokenExpiresAt" example:"1714462120"`
+} // @name refreshTokenResponse
+
+type logoutBody struct {
+	SessionID string `json:"sessionId" binding:"required" example:"660c4b99bc1bc4aabe3e6cd1"`
+} // @name logoutBody
+
+type SessionResponse struct {
+	ID        string `bson:"_id" json:"id" example:"660c4b99bc1bc4aabe3e6cd1"`
+	UserID    string `bson:"userId" json:"userId" example:"660c4b99bc1bc4aabe3e6cd1"`
+	UserAgent string `bson:"userAgent" json:"userAgent" example:"Mozilla/5.0"`
+	ClientIP  string `bson:"clientIp" json:"clientIp" example:"127.0.0.1"`
+	IsBlocked bool   `bson:"isBlocked" json:"isBlocked" example:"false"`
+	ExpiresAt int64  `bson:"expiresAt" json:"expiresAt" example:"1714462120"`
+} // @name SessionResponse
+
+type userUpdateBody struct {
+	Email    string `json:"email,omitempty" example:"user@example.com"`
+	Password string `json:"password,omitempty" binding:"omitempty,min=6" example:"s3cr3tP@ssw0rd"`
+} // @name userUpdateBody
+
 type UserResponse struct {
 	ID         string  `bson:"_id" json:"id" example:"660c4b99bc1bc4aabe3e6cd1"`
 	Email      string  `bson:"email" json:"email" binding:"required" example:"user@example.com"`
@@ -37,7 +76,6 @@ type AuthorBody struct {
 	InstagramURL string `bson:"instagramUrl" json:"instagramUrl,omitempty"`
 	YoutubeURL   string `bson:"youtubeUrl" json:"youtubeUrl,omitempty"`
 	ImageName    string `bson:"imageName" json:"imageName,omitempty"`
-	UserID       string `bson:"userId" json:"userId,omitempty" binding:"required"`
 } // @name AuthorBody
 
 type AuthorResponse struct {
@@ -49,7 +87,7 @@ type AuthorResponse struct {
 	InstagramURL string       `bson:"instagramUrl" json:"instagramUrl,omitempty" example:"https://wwww.instagram.com/moezarella/"`
 	YoutubeURL   string       `bson:"youtubeUrl" json:"youtubeUrl,omitempty" example:"https://www.youtube.com/channel/UCy8asdgasdf7RcC6OZffZA"`
 	ImageName    string       `bson:"imageName" json:"imageName,omitempty" example:"moezarella.png"`
-	RecipeCount  int          `bson:"recipeCount" json:"recipeCount,omitempty" example:"5"`
+	RecipeCount  int          `bson:"recipeCount" json:"recipeCount" example:"5"`
 	UserID       string       `bson:"userId" json:"userId,omitempty" example:"660c4b99bc1bc4aabe3e6cd1"`
 	UserCreated  UserResponse `bson:"userCreated" json:"userCreated"`
 	CreatedAt    int64        `bson:"createdAt" json:"createdAt" example:"1714462120"`
@@ -57,18 +95,76 @@ type AuthorResponse struct {
 } // @name AuthorResponse
 
 type RecipeResponse struct {
-	ID          string          `bson:"_id" json:"id" example:"660c4b99bc1bc4aabe126cd1"`
-	Name        string          `bson:"name" json:"name" example:"Pancakes"`
-	ImageName   string          `bson:"imageName" json:"imageName,omitempty" example:"Pancakes.png"`
-	RecipeURL   string          `bson:"recipeUrl" json:"recipeUrl,omitempty" example:"https://www.allthepancakes.com/pancakes"`
-	TimeM       int             `bson:"timeM" json:"timeM" example:"30"`
-	Category    db.Category     `bson:"category" json:"category" example:"breakfast"`
-	Ingredients []db.Ingredient `bson:"ingredients" json:"ingredients"`
-	PrepSteps   []db.PrepStep   `bson:"prepSteps" json:"prepSteps"`
-	AuthorID    string          `bson:"authorId" json:"authorId,omitempty" binding:"required" example:"660c4b99bc1bc4aabe126cd1"`
-	Author      AuthorResponse  `bson:"author" json:"author"`
-	UserID      string          `bson:"userId" json:"userId,omitempty" example:"660c4b99bc1bc4aabe126cd1"`
-	UserCreated UserResponse    `bson:"userCreated" json:"userCreated"`
-	CreatedAt   int64           `bson:"createdAt" json:"createdAt" example:"1714462120"`
-	ModifiedAt  int64           `bson:"modifiedAt" json:"modifiedAt" example:"1714462120"`
+	ID            string              `bson:"_id" json:"id" example:"660c4b99bc1bc4aabe126cd1"`
+	Name          string              `bson:"name" json:"name" example:"Pancakes"`
+	ImageName     string              `bson:"imageName" json:"imageName,omitempty" example:"Pancakes.png"`
+	RecipeURL     string              `bson:"recipeUrl" json:"recipeUrl,omitempty" example:"https://www.allthepancakes.com/pancakes"`
+	TimeM         int                 `bson:"timeM" json:"timeM" example:"30"`
+	Servings      int                 `bson:"servings" json:"servings,omitempty" example:"4"`
+	Category      db.Category         `bson:"category" json:"category" example:"breakfast"`
+	Tags          []string            `bson:"tags" json:"tags,omitempty" example:"quick,sweet"`
+	DietaryLabels []db.DietaryLabel   `bson:"dietaryLabels" json:"dietaryLabels,omitempty" example:"nut-free,soy-free"`
+	Allergens     []db.Allergen       `bson:"allergens" json:"allergens,omitempty" example:"gluten,nuts"`
+	Ingredients   []db.Ingredient     `bson:"ingredients" json:"ingredients"`
+	PrepSteps     []db.PrepStep       `bson:"prepSteps" json:"prepSteps"`
+	AuthorID      string              `bson:"authorId" json:"authorId,omitempty" binding:"required" example:"660c4b99bc1bc4aabe126cd1"`
+	Author        AuthorResponse      `bson:"author" json:"author"`
+	UserID        string              `bson:"userId" json:"userId,omitempty" example:"660c4b99bc1bc4aabe126cd1"`
+	UserCreated   UserResponse        `bson:"userCreated" json:"userCreated"`
+	AverageRating float64             `bson:"averageRating" json:"averageRating" example:"4.5"`
+	RatingCount   int64               `bson:"ratingCount" json:"ratingCount" example:"12"`
+	CreatedAt     int64               `bson:"createdAt" json:"createdAt" example:"1714462120"`
+	ModifiedAt    int64               `bson:"modifiedAt" json:"modifiedAt" example:"1714462120"`
+	Nutrition     *db.RecipeNutrition `json:"nutrition,omitempty"`
 } // @name RecipeResponse
+
+type ReviewResponse struct {
+	ID          string       `bson:"_id" json:"id" example:"660c4b99bc1bc4aabe126cd1"`
+	RecipeID    string       `bson:"recipeId" json:"recipeId" example:"660c4b99bc1bc4aabe126cd1"`
+	Rating      int          `bson:"rating" json:"rating" example:"5"`
+	Text        string       `bson:"text" json:"text,omitempty" example:"Fluffy and not too sweet"`
+	UserID      string       `bson:"userId" json:"userId,omitempty" example:"660c4b99bc1bc4aabe3e6cd1"`
+	UserCreated UserResponse `bson:"userCreated" json:"userCreated"`
+	CreatedAt   int64        `bson:"createdAt" json:"createdAt" example:"1714462120"`
+	ModifiedAt  int64        `bson:"modifiedAt" json:"modifiedAt" example:"1714462120"`
+} // @name ReviewResponse
+
+type RecipeSearchResponse struct {
+	RecipeResponse
+	Score float64 `json:"score" example:"11.5"`
+} // @name RecipeSearchResponse
+
+type RecipeListResponse struct {
+	Items []RecipeResponse `json:"items"`
+	PageMetadata
+} // @name RecipeListResponse
+
+type AuthorListResponse struct {
+	Items []AuthorResponse `json:"items"`
+	PageMetadata
+} // @name AuthorListResponse
+
+type UserListResponse struct {
+	Items []UserResponse `json:"items"`
+	PageMetadata
+} // @name UserListResponse
+
+type IngredientListResponse struct {
+	Items []db.CatalogueIngredient `json:"items"`
+	PageMetadata
+} // @name IngredientListResponse
+
+type SubstitutionListResponse struct {
+	Items []db.Substitution `json:"items"`
+	PageMetadata
+} // @name SubstitutionListResponse
+
+type ReviewListResponse struct {
+	Items []ReviewResponse `json:"items"`
+	PageMetadata
+} // @name ReviewListResponse
+
+type RecipeSearchListResponse struct {
+	Items []RecipeSearchResponse `json:"items"`
+	PageMetadata
+} // @name RecipeSearchListResponse
@@ -3,9 +3,12 @@ package api
 import (
 	"fmt"
 	"net/http"
-	"strings"
 
+	"github.com/PfMartin/wegonice-api/allergens"
+	"github.com/PfMartin/wegonice-api/conversion"
 	"github.com/PfMartin/wegonice-api/db"
+	"github.com/PfMartin/wegonice-api/nutrition"
+	"github.com/PfMartin/wegonice-api/token"
 	"github.com/gin-gonic/gin"
 	"github.com/rs/zerolog/log"
 )
@@ -13,18 +16,33 @@ import (
 // listRecipes
 //
 // @Summary			List all recipes
-// @Description	All recipes are listed in a paginated manner
+// @Description	All recipes, which match the optional filters, are listed in a paginated manner. They are sorted by name, if no sorting is provided.
 // @ID					recipes-list-recipes
 // @Tags				recipes
 // @Accept			json
 // @Produce			json
-// @Param				authorization	header			string							false	"Authorization header for bearer token"
-// @Param				page_id				query 			int									true	"Offset for the pagination"
-// @Param				page_size			query 			int									true	"Number of elements in one page"
-// @Success			200						{array}			RecipeResponse						"List of recipes matching the given pagination parameters"
-// @Failure			400						{object}		ErrorBadRequest						"Bad Request"
-// @Failure			401						{object}		ErrorUnauthorized					"Unauthorized"
-// @Failure 		500						{object}		ErrorInternalServerError	"Internal Server Error"
+// @Param				authorization					header			string							false	"Authorization header for bearer token"
+// @Param				page_id								query 			int									false	"Offset for the pagination, required without cursor"
+// @Param				page_size							query 			int									true	"Number of elements in one page"
+// @Param				cursor								query 			string							false	"Cursor of the next page, which was returned with the previous page"
+// @Param				name									query 			string							false	"Part of the recipe name (case-insensitive)"
+// @Param				category							query 			string							false	"Category of the recipes"	Enums(breakfast, main, desert, smoothie, baby, drink)
+// @Param				author_id							query 			string							false	"ID of the author of the recipes"
+// @Param				user_id								query 			string							false	"ID of the user, who created the recipes"
+// @Param				max_time_m						query 			int									false	"Maximum preparation time in minutes"
+// @Param				ingredients						query 			[]string						false	"Ingredients, which must all be part of the recipes"	collectionFormat(multi)
+// @Param				excluded_ingredients	query 			[]string						false	"Ingredients, which must not be part of the recipes"	collectionFormat(multi)
+// @Param				tags									query 			[]string						false	"Tags, which must all be part of the recipes (case-insensitive)"	collectionFormat(multi)
+// @Param				dietary_labels				query 			[]string						false	"Dietary labels, which must all be part of the recipes"	collectionFormat(multi)	Enums(gluten-free, nut-free, soy-free, raw, high-protein)
+// @Param				excluded_allergens		query 			[]string						false	"Allergens, which must not be contained in the ingredients of the recipes. Recipes, which were created before the allergen detection, are not matched"	collectionFormat(multi)	Enums(gluten, nuts, soy, sesame)
+// @Param				ingredient_ids				query 			[]string						false	"IDs of catalogue ingredients, which must all be part of the recipes"	collectionFormat(multi)
+// @Param				sort									query 			[]string						false	"Keys to sort by"	collectionFormat(multi)	Enums(name, timeM, category, averageRating, ratingCount, createdAt, modifiedAt)
+// @Param				order									query 			[]string						false	"Sort order per key"	collectionFormat(multi)	Enums(asc, desc)
+// @Param				units									query 			string							false	"Unit system to convert the ingredient amounts to"	Enums(metric, imperial)
+// @Success			200						{object}		RecipeListResponse				"Page of recipes matching the given pagination parameters"
+// @Failure			400						{object}		ProblemDetails						"Bad Request"
+// @Failure			401						{object}		ProblemDetails						"Unauthorized"
+// @Failure 		500						{object}		ProblemDetails						"Internal Server Error"
 // @Router			/recipes			[get]
 func (server *Server) listRecipes(ctx *gin.Context) {
 	var pagination db.Pagination
@@ -33,21 +51,88 @@ func (server *Server) listRecipes(ctx *gin.Context) {
 		return
 	}
 
-	// TODO: Add sorting
+	var filter db.RecipeFilter
+	if err := ctx.ShouldBindQuery(&filter); err != nil {
+		NewErrorBadRequest(err).Send(ctx)
+		return
+	}
+
+	var sorting db.Sorting
+	if err := ctx.ShouldBindQuery(&sorting); err != nil {
+		NewErrorBadRequest(err).Send(ctx)
+		return
+	}
+
+	var units unitsQuery
+	if err := ctx.ShouldBindQuery(&units); err != nil {
+		NewErrorBadRequest(err).Send(ctx)
+		return
+	}
+
+	recipes, pageInfo, err := server.store.GetAllRecipes(ctx, pagination, filter, sorting)
+	if err != nil {
+		newErrorFromDB(err).Send(ctx)
+		return
+	}
+
+	recipes = conversion.ConvertRecipes(recipes, units.Units)
+
+	ctx.JSON(http.StatusOK, newListResponse(ctx, recipes, pagination, pageInfo))
+}
+
+// searchRecipes
+//
+// @Summary			Search recipes
+// @Description	Recipes are searched by the words in their name, ingredients and preparation steps and are listed in a paginated manner, ordered by their relevance. Phrases can be searched in double quotes and words can be excluded with a leading minus.
+// @ID					recipes-search-recipes
+// @Tags				recipes
+// @Accept			json
+// @Produce			json
+// @Param				authorization		header			string							false	"Authorization header for bearer token"
+// @Param				q								query 			string							true	"Search query with optional phrases in double quotes and words excluded by a leading minus"
+// @Param				page_id					query 			int									false	"Offset for the pagination, required without cursor"
+// @Param				page_size				query 			int									true	"Number of elements in one page"
+// @Param				cursor					query 			string							false	"Cursor of the next page, which was returned with the previous page"
+// @Param				units						query 			string							false	"Unit system to convert the ingredient amounts to"	Enums(metric, imperial)
+// @Success			200							{object}		RecipeSearchListResponse	"Page of recipes matching the search, ordered by relevance"
+// @Failure			400							{object}		ProblemDetails						"Bad Request"
+// @Failure			401							{object}		ProblemDetails						"Unauthorized"
+// @Failure 		500							{object}		ProblemDetails						"Internal Server Error"
+// @Router			/recipes/search	[get]
+func (server *Server) searchRecipes(ctx *gin.Context) {
+	var pagination db.Pagination
+	if err := ctx.ShouldBindQuery(&pagination); err != nil {
+		NewErrorBadRequest(err).Send(ctx)
+		return
+	}
 
-	recipes, err := server.store.GetAllRecipes(ctx, pagination)
+	var search db.RecipeSearch
+	if err := ctx.ShouldBindQuery(&search); err != nil {
+		NewErrorBadRequest(err).Send(ctx)
+		return
+	}
+
+	var units unitsQuery
+	if err := ctx.ShouldBindQuery(&units); err != nil {
+		NewErrorBadRequest(err).Send(ctx)
+		return
+	}
+
+	recipes, pageInfo, err := server.store.SearchRecipes(ctx, pagination, search)
 	if err != nil {
-		NewErrorInternalServerError(err).Send(ctx)
+		newErrorFromDB(err).Send(ctx)
 		return
 	}
 
-	ctx.JSON(http.StatusOK, recipes)
+	recipes = conversion.ConvertSearchResults(recipes, units.Units)
+
+	ctx.JSON(http.StatusOK, newListResponse(ctx, recipes, pagination, pageInfo))
 }
 
 // createRecipe
 //
 // @Summary			Create new recipe
-// @Description	Creates a new recipe
+// @Description	Creates a new recipe, which belongs to the logged in user. The allergens of the recipe are derived from its ingredients
 // @ID					recipes-create-recipe
 // @Tags				recipes
 // @Accept			json
@@ -55,9 +140,13 @@ func (server *Server) listRecipes(ctx *gin.Context) {
 // @Param				authorization		header			string							false	"Authorization header for bearer token"
 // @Param				data						body 				RecipeToCreate			true	"Data for the recipe to create"
 // @Success			201							string			string										"ID of the created recipe"
-// @Failure			400							{object}		ErrorBadRequest						"Bad Request"
-// @Failure			401							{object}		ErrorUnauthorized					"Unauthorized"
-// @Failure 		500							{object}		ErrorInternalServerError	"Internal Server Error"
+// @Failure			400							{object}		ProblemDetails						"Bad Request"
+// @Failure			401							{object}		ProblemDetails						"Unauthorized"
+// @Failure			403							{object}		ProblemDetails						"Forbidden"
+// @Failure			404							{object}		ProblemDetails						"Not Found, a catalogue ingredient of the ingredients does not exist"
+// @Failure			409							{object}		ProblemDetails						"Conflict, a recipe with this name already exists"
+// @Failure			422							{object}		ProblemDetails						"Unprocessable Entity, a dietary label contradicts the allergens of the ingredients"
+// @Failure 		500							{object}		ProblemDetails						"Internal Server Error"
 // @Router			/recipes				[post]
 func (server *Server) createRecipe(ctx *gin.Context) {
 	var recipeBody db.RecipeToCreate
@@ -67,18 +156,22 @@ func (server *Server) createRecipe(ctx *gin.Context) {
 		return
 	}
 
+	payload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	recipeBody.UserID = payload.UserID
+
 	if recipeBody.ImageName != "" {
 		recipeBody.ImageName = server.imageManager.CreateUniqueName(recipeBody.ImageName)
 	}
 
+	recipeBody.Allergens = allergens.Detect(recipeBody.Ingredients)
+	if err := allergens.CheckDietaryLabels(recipeBody.DietaryLabels, recipeBody.Allergens); err != nil {
+		NewErrorUnprocessableEntity(err).Send(ctx)
+		return
+	}
+
 	recipeID, err := server.store.CreateRecipe(ctx, recipeBody)
 	if err != nil {
-		if strings.HasPrefix(err.Error(), "recipe with name") { // TODO: Find better way to check error types (enum?)
-			NewErrorBadRequest(err).Send(ctx)
-			return
-		}
-
-		NewErrorInternalServerError(err).Send(ctx)
+		newErrorFromDB(err).Send(ctx)
 		return
 	}
 
@@ -88,18 +181,21 @@ func (server *Server) createRecipe(ctx *gin.Context) {
 // getRecipeByID
 //
 // @Summary			Get one recipe by ID
-// @Description	One recipe, which matches the ID, is returned
+// @Description	One recipe, which matches the ID, is returned with its nutrition. The ingredient amounts and the nutrition are scaled, if servings are requested
 // @ID					recipes-get-recipe-by-id
 // @Tags				recipes
 // @Accept			json
 // @Produce			json
 // @Param				authorization		header			string							false	"Authorization header for bearer token"
 // @Param				id							path 				int									true	"ID of the desired recipe"
+// @Param				servings				query				int									false	"Number of servings to scale the ingredient amounts to"	minimum(1)	maximum(100)
+// @Param				units						query 			string							false	"Unit system to convert the ingredient amounts to"	Enums(metric, imperial)
 // @Success			200							{object}		RecipeResponse						"Recipe that matches the ID"
-// @Failure			400							{object}		ErrorBadRequest						"Bad Request"
-// @Failure			401							{object}		ErrorUnauthorized					"Unauthorized"
-// @Failure			404							{object}		ErrorNotFound							"Not Found"
-// @Failure 		500							{object}		ErrorInternalServerError	"Internal Server Error"
+// @Failure			400							{object}		ProblemDetails						"Bad Request"
+// @Failure			401							{object}		ProblemDetails						"Unauthorized"
+// @Failure			404							{object}		ProblemDetails						"Not Found"
+// @Failure			422							{object}		ProblemDetails						"Unprocessable Entity"
+// @Failure 		500							{object}		ProblemDetails						"Internal Server Error"
 // @Router			/recipes/{id}		[get]
 func (server *Server) getRecipeByID(ctx *gin.Context) {
 	var uriParam getByIDRequest
@@ -108,24 +204,120 @@ func (server *Server) getRecipeByID(ctx *gin.Context) {
 		return
 	}
 
+	var scaleQuery scaleRecipeQuery
+	if err := ctx.ShouldBindQuery(&scaleQuery); err != nil {
+		NewErrorBadRequest(err).Send(ctx)
+		return
+	}
+
+	var units unitsQuery
+	if err := ctx.ShouldBindQuery(&units); err != nil {
+		NewErrorBadRequest(err).Send(ctx)
+		return
+	}
+
 	recipe, err := server.store.GetRecipeByID(ctx, uriParam.ID)
 	if err != nil {
-		if strings.HasPrefix(err.Error(), "failed to find recipe") { // TODO: Find better method to distinguish between error types (enum?)
-			NewErrorNotFound(err).Send(ctx)
+		newErrorFromDB(err).Send(ctx)
+		return
+	}
+
+	if scaleQuery.Servings != 0 {
+		if recipe.Servings == 0 {
+			NewErrorUnprocessableEntity(fmt.Errorf("recipe with ID %s has no servings to scale from", uriParam.ID)).Send(ctx)
 			return
 		}
 
-		NewErrorInternalServerError(err).Send(ctx)
+		recipe = recipe.ScaleToServings(scaleQuery.Servings)
+	}
+
+	ingredientIDs := []string{}
+	for _, ingredient := range recipe.Ingredients {
+		if ingredient.IngredientID != "" {
+			ingredientIDs = append(ingredientIDs, ingredient.IngredientID)
+		}
+	}
+
+	catalogueIngredients, err := server.store.GetCatalogueIngredientsByIDs(ctx, ingredientIDs)
+	if err != nil {
+		newErrorFromDB(err).Send(ctx)
 		return
 	}
 
+	recipeNutrition := nutrition.Calculate(recipe, catalogueIngredients)
+	recipe.Nutrition = &recipeNutrition
+
+	recipe = conversion.ConvertRecipe(recipe, units.Units)
+
 	ctx.JSON(http.StatusOK, recipe)
 }
 
+// listRecipeSubstitutions
+//
+// @Summary			List the substitutions for the ingredients of a recipe
+// @Description	The substitutions of the catalogue ingredients, which are linked to the ingredients of the recipe, are returned with the amount of the substitute. Substitutions, whose ratio applies to another unit than the one of the ingredient, are skipped. The amounts are scaled, if servings are requested
+// @ID					recipes-list-recipe-substitutions
+// @Tags				recipes
+// @Accept			json
+// @Produce			json
+// @Param				authorization									header			string							false	"Authorization header for bearer token"
+// @Param				id														path 				string							true	"ID of the desired recipe"
+// @Param				servings											query				int									false	"Number of servings to scale the ingredient amounts to"	minimum(1)	maximum(100)
+// @Success			200														{array}			RecipeSubstitution				"Substitutions for the ingredients of the recipe"
+// @Failure			400														{object}		ProblemDetails						"Bad Request"
+// @Failure			401														{object}		ProblemDetails						"Unauthorized"
+// @Failure			404														{object}		ProblemDetails						"Not Found"
+// @Failure			422														{object}		ProblemDetails						"Unprocessable Entity"
+// @Failure 		500														{object}		ProblemDetails						"Internal Server Error"
+// @Router			/recipes/{id}/substitutions		[get]
+func (server *Server) listRecipeSubstitutions(ctx *gin.Context) {
+	var uriParam getByIDRequest
+	if err := ctx.ShouldBindUri(&uriParam); err != nil {
+		NewErrorBadRequest(err).Send(ctx)
+		return
+	}
+
+	var scaleQuery scaleRecipeQuery
+	if err := ctx.ShouldBindQuery(&scaleQuery); err != nil {
+		NewErrorBadRequest(err).Send(ctx)
+		return
+	}
+
+	recipe, err := server.store.GetRecipeByID(ctx, uriParam.ID)
+	if err != nil {
+		newErrorFromDB(err).Send(ctx)
+		return
+	}
+
+	if scaleQuery.Servings != 0 {
+		if recipe.Servings == 0 {
+			NewErrorUnprocessableEntity(fmt.Errorf("recipe with ID %s has no servings to scale from", uriParam.ID)).Send(ctx)
+			return
+		}
+
+		recipe = recipe.ScaleToServings(scaleQuery.Servings)
+	}
+
+	ingredientIDs := []string{}
+	for _, ingredient := range recipe.Ingredients {
+		if ingredient.IngredientID != "" {
+			ingredientIDs = append(ingredientIDs, ingredient.IngredientID)
+		}
+	}
+
+	substitutions, err := server.store.GetSubstitutionsByIngredientIDs(ctx, ingredientIDs)
+	if err != nil {
+		newErrorFromDB(err).Send(ctx)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, recipe.GetSubstitutions(substitutions))
+}
+
 // patchRecipeByID
 //
 // @Summary			Patch one recipe by ID
-// @Description	One recipe, which matches the ID, is modified with the provided patch
+// @Description	One recipe, which matches the ID, is modified with the provided patch. Only the user, who created the recipe, or an admin is allowed to patch it.
 // @ID					recipes-patch-recipe-by-id
 // @Tags				recipes
 // @Accept			json
@@ -134,9 +326,13 @@ func (server *Server) getRecipeByID(ctx *gin.Context) {
 // @Param				id							path 				int									true	"ID of the desired recipe to patch"
 // @Param				data						body 				RecipeUpdate				true	"Patch for modifying the recipe"
 // @Success			200
-// @Failure			400							{object}		ErrorBadRequest						"Bad Request"
-// @Failure			401							{object}		ErrorUnauthorized					"Unauthorized"
-// @Failure			404							{object}		ErrorNotFound							"Not Found"
+// @Failure			400							{object}		ProblemDetails						"Bad Request"
+// @Failure			401							{object}		ProblemDetails						"Unauthorized"
+// @Failure			403							{object}		ProblemDetails						"Forbidden"
+// @Failure			404							{object}		ProblemDetails						"Not Found"
+// @Failure			409							{object}		ProblemDetails						"Conflict, a recipe with this name already exists"
+// @Failure			422							{object}		ProblemDetails						"Unprocessable Entity, a dietary label contradicts the allergens of the ingredients"
+// @Failure 		500							{object}		ProblemDetails						"Internal Server Error"
 // @Router			/recipes/{id}		[patch]
 func (server *Server) patchRecipeByID(ctx *gin.Context) {
 	var uriParam getByIDRequest
@@ -155,7 +351,10 @@ func (server *Server) patchRecipeByID(ctx *gin.Context) {
 		recipePatch.ImageName == "" &&
 		recipePatch.RecipeURL == "" &&
 		recipePatch.TimeM == 0 &&
+		recipePatch.Servings == 0 &&
 		recipePatch.Category == "" &&
+		len(recipePatch.Tags) == 0 &&
+		len(recipePatch.DietaryLabels) == 0 &&
 		len(recipePatch.Ingredients) == 0 &&
 		len(recipePatch.PrepSteps) == 0 &&
 		recipePatch.AuthorID == "" {
@@ -165,12 +364,13 @@ func (server *Server) patchRecipeByID(ctx *gin.Context) {
 
 	existingRecipe, err := server.store.GetRecipeByID(ctx, uriParam.ID)
 	if err != nil {
-		if strings.HasPrefix(err.Error(), "failed to find recipe") {
-			NewErrorNotFound(err).Send(ctx)
-			return
-		}
+		newErrorFromDB(err).Send(ctx)
+		return
+	}
 
-		NewErrorBadRequest(err).Send(ctx)
+	payload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if !isOwnerOrPermitted(payload, existingRecipe.UserCreated.ID, permissionManageContent) {
+		NewErrorForbidden(fmt.Errorf("not allowed to modify recipe with ID: %s", uriParam.ID)).Send(ctx)
 		return
 	}
 
@@ -178,9 +378,26 @@ func (server *Server) patchRecipeByID(ctx *gin.Context) {
 		recipePatch.ImageName = server.imageManager.CreateUniqueName(recipePatch.ImageName)
 	}
 
+	// The dietary labels are checked against the allergens of the recipe after the patch.
+	dietaryLabels := existingRecipe.DietaryLabels
+	if len(recipePatch.DietaryLabels) > 0 {
+		dietaryLabels = recipePatch.DietaryLabels
+	}
+
+	detectedAllergens := existingRecipe.Allergens
+	if len(recipePatch.Ingredients) > 0 {
+		recipePatch.Allergens = allergens.Detect(recipePatch.Ingredients)
+		detectedAllergens = recipePatch.Allergens
+	}
+
+	if err := allergens.CheckDietaryLabels(dietaryLabels, detectedAllergens); err != nil {
+		NewErrorUnprocessableEntity(err).Send(ctx)
+		return
+	}
+
 	modifiedCount, err := server.store.UpdateRecipeByID(ctx, uriParam.ID, recipePatch)
 	if err != nil {
-		NewErrorBadRequest(err).Send(ctx)
+		newErrorFromDB(err).Send(ctx)
 		return
 	}
 
@@ -199,7 +416,7 @@ func (server *Server) patchRecipeByID(ctx *gin.Context) {
 // deleteRecipeByID
 //
 // @Summary			Delete one recipe by ID
-// @Description	One recipe, which matches the ID, is deleted
+// @Description	One recipe, which matches the ID, is deleted. Only the user, who created the recipe, or an admin is allowed to delete it. The reviews of the recipe are deleted with it.
 // @ID					recipes-delete-recipe-by-id
 // @Tags				recipes
 // @Accept			json
@@ -207,9 +424,11 @@ func (server *Server) patchRecipeByID(ctx *gin.Context) {
 // @Param				authorization		header			string							false	"Authorization header for bearer token"
 // @Param				id							path 				int									true	"ID of the desired recipe to patch"
 // @Success			200
-// @Failure			400							{object}		ErrorBadRequest						"Bad Request"
-// @Failure			401							{object}		ErrorUnauthorized					"Unauthorized"
-// @Failure			404							{object}		ErrorNotFound							"Not Found"
+// @Failure			400							{object}		ProblemDetails						"Bad Request"
+// @Failure			401							{object}		ProblemDetails						"Unauthorized"
+// @Failure			403							{object}		ProblemDetails						"Forbidden"
+// @Failure			404							{object}		ProblemDetails						"Not Found"
+// @Failure 		500							{object}		ProblemDetails						"Internal Server Error"
 // @Router			/recipes/{id}		[delete]
 func (server *Server) deleteRecipeByID(ctx *gin.Context) {
 	var uriParam getByIDRequest
@@ -220,18 +439,19 @@ func (server *Server) deleteRecipeByID(ctx *gin.Context) {
 
 	existingRecipe, err := server.store.GetRecipeByID(ctx, uriParam.ID)
 	if err != nil {
-		if strings.HasPrefix(err.Error(), "failed to find recipe") {
-			NewErrorNotFound(err).Send(ctx)
-			return
-		}
+		newErrorFromDB(err).Send(ctx)
+		return
+	}
 
-		NewErrorBadRequest(err).Send(ctx)
+	payload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if !isOwnerOrPermitted(payload, existingRecipe.UserCreated.ID, permissionManageContent) {
+		NewErrorForbidden(fmt.Errorf("not allowed to delete recipe with ID: %s", uriParam.ID)).Send(ctx)
 		return
 	}
 
 	_, err = server.store.DeleteRecipeByID(ctx, uriParam.ID)
 	if err != nil {
-		NewErrorBadRequest(err).Send(ctx)
+		newErrorFromDB(err).Send(ctx)
 		return
 	}
 
@@ -10,6 +10,8 @@ import (
 	"testing"
 	"time"
 
+	"github.com/PfMartin/wegonice-api/allergens"
+	"github.com/PfMartin/wegonice-api/conversion"
 	"github.com/PfMartin/wegonice-api/db"
 	mock_db "github.com/PfMartin/wegonice-api/db/mock"
 	"github.com/PfMartin/wegonice-api/util"
@@ -19,18 +21,16 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
-var amountUnits = []db.AmountUnit{db.Milliliters, db.Liters, db.Milligrams, db.Grams, db.Tablespoon, db.Teaspoon, db.Piece}
-
 func randomIngredients(t *testing.T, ingredientsCount int, ingredients *[]db.Ingredient) {
 	t.Helper()
 
 	for i := 0; i < ingredientsCount; i++ {
-		amountIdx := util.RandomInt(0, int64(len(amountUnits)-1))
+		amountIdx := util.RandomInt(0, int64(len(db.AmountUnits)-1))
 
 		*ingredients = append(*ingredients, db.Ingredient{
 			Name:   util.RandomString(6),
-			Amount: int(util.RandomInt(0, 100)),
-			Unit:   amountUnits[amountIdx],
+			Amount: db.Amount(util.RandomInt(0, 400)) / 4,
+			Unit:   db.AmountUnits[amountIdx],
 		})
 	}
 }
@@ -87,23 +87,32 @@ func randomRecipe(t *testing.T) (db.Recipe, primitive.ObjectID) {
 		ID:        recipeID.Hex(),
 		Name:      util.RandomString(6),
 		ImageName: util.RandomString(6),
-		RecipeURL: util.RandomString(10),
+		RecipeURL: util.RandomURL(),
 		TimeM:     int(util.RandomInt(5, 120)),
+		Servings:  int(util.RandomInt(1, 6)),
 		Category:  db.Breakfast,
-		AuthorID:  authorID.Hex(),
-		UserID:    userID,
+		Tags:      []string{util.RandomString(5), util.RandomString(5)},
+		DietaryLabels: []db.DietaryLabel{
+			db.DietaryLabels[util.RandomInt(0, int64(len(db.DietaryLabels)-1))],
+		},
+		AuthorID: authorID.Hex(),
+		UserID:   userID,
 		UserCreated: db.User{
 			ID:    userID,
 			Email: util.RandomEmail(),
 		},
-		Author:      author,
-		Ingredients: ingredients,
-		PrepSteps:   prepSteps,
+		Author:        author,
+		Ingredients:   ingredients,
+		PrepSteps:     prepSteps,
+		AverageRating: float64(util.RandomInt(10, 50)) / 10,
+		RatingCount:   util.RandomInt(1, 100),
 	}, recipeID
 }
 
 func TestUnitListRecipes(t *testing.T) {
 	user, _ := randomUser(t)
+	authorID := primitive.NewObjectID().Hex()
+	ingredientID := primitive.NewObjectID().Hex()
 	var recipes []db.Recipe
 	for i := 0; i < 10; i++ {
 		recipe, _ := randomRecipe(t)
@@ -125,16 +134,24 @@ func TestUnitListRecipes(t *testing.T) {
 					PageSize: 10,
 				}
 
-				store.EXPECT().GetAllRecipes(gomock.Any(), pagination).Times(1).Return(recipes, nil)
+				store.EXPECT().GetAllRecipes(gomock.Any(), pagination, db.RecipeFilter{}, db.Sorting{}).Times(1).Return(recipes, db.PageInfo{TotalCount: 25, NextCursor: "next-cursor"}, nil)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusOK, recorder.Code)
 
-				var gotRecipes []RecipeResponse
-				err := json.NewDecoder(recorder.Body).Decode(&gotRecipes)
+				var gotPage RecipeListResponse
+				err := json.NewDecoder(recorder.Body).Decode(&gotPage)
 				require.NoError(t, err)
 
+				gotRecipes := gotPage.Items
 				require.Equal(t, 10, len(gotRecipes))
+				require.Equal(t, int64(25), gotPage.TotalCount)
+				require.Equal(t, int64(1), gotPage.Page)
+				require.Equal(t, int64(10), gotPage.PageSize)
+				require.True(t, gotPage.HasNext)
+				require.Equal(t, "next-cursor", gotPage.NextCursor)
+				require.Equal(t, "/api/v1/recipes?page_id=2&page_size=10", gotPage.Links.Next)
+				require.Empty(t, gotPage.Links.Prev)
 
 				for i, expectedRecipe := range recipes {
 					requireRecipeComparison(t, expectedRecipe, gotRecipes[i])
@@ -150,22 +167,256 @@ func TestUnitListRecipes(t *testing.T) {
 					PageSize: 10,
 				}
 
-				store.EXPECT().GetAllRecipes(gomock.Any(), pagination).Times(1).Return(recipes[4:], nil)
+				store.EXPECT().GetAllRecipes(gomock.Any(), pagination, db.RecipeFilter{}, db.Sorting{}).Times(1).Return(recipes[4:], db.PageInfo{TotalCount: 46}, nil)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusOK, recorder.Code)
 
-				var gotRecipes []RecipeResponse
-				err := json.NewDecoder(recorder.Body).Decode(&gotRecipes)
+				var gotPage RecipeListResponse
+				err := json.NewDecoder(recorder.Body).Decode(&gotPage)
 				require.NoError(t, err)
 
+				gotRecipes := gotPage.Items
 				require.Equal(t, 6, len(gotRecipes))
+				require.Equal(t, int64(46), gotPage.TotalCount)
+				require.False(t, gotPage.HasNext)
+				require.Empty(t, gotPage.Links.Next)
+				require.Equal(t, "/api/v1/recipes?page_id=4&page_size=10", gotPage.Links.Prev)
 
 				for i, expectedRecipe := range recipes[4:] {
 					requireRecipeComparison(t, expectedRecipe, gotRecipes[i])
 				}
 			},
 		},
+		{
+			name:  "Success converting the ingredients to imperial units",
+			query: "?page_id=1&page_size=10&units=imperial",
+			buildStubs: func(store *mock_db.MockDBStore) {
+				pagination := db.Pagination{
+					PageID:   1,
+					PageSize: 10,
+				}
+
+				store.EXPECT().GetAllRecipes(gomock.Any(), pagination, db.RecipeFilter{}, db.Sorting{}).Times(1).Return(recipes, db.PageInfo{TotalCount: 10}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var gotPage RecipeListResponse
+				err := json.NewDecoder(recorder.Body).Decode(&gotPage)
+				require.NoError(t, err)
+
+				require.Equal(t, len(recipes), len(gotPage.Items))
+				for i, expectedRecipe := range conversion.ConvertRecipes(recipes, conversion.Imperial) {
+					requireRecipeComparison(t, expectedRecipe, gotPage.Items[i])
+				}
+			},
+		},
+		{
+			name:  "Success with filters",
+			query: "?page_id=1&page_size=10&name=pan&category=breakfast&author_id=" + authorID + "&max_time_m=30&ingredients=flour&ingredients=oat%20milk&excluded_ingredients=peanuts&tags=quick&tags=sweet&dietary_labels=nut-free&excluded_allergens=nuts&excluded_allergens=soy&ingredient_ids=" + ingredientID,
+			buildStubs: func(store *mock_db.MockDBStore) {
+				pagination := db.Pagination{
+					PageID:   1,
+					PageSize: 10,
+				}
+
+				filter := db.RecipeFilter{
+					Name:                "pan",
+					Category:            db.Breakfast,
+					AuthorID:            authorID,
+					MaxTimeM:            30,
+					Ingredients:         []string{"flour", "oat milk"},
+					ExcludedIngredients: []string{"peanuts"},
+					Tags:                []string{"quick", "sweet"},
+					DietaryLabels:       []db.DietaryLabel{db.NutFree},
+					ExcludedAllergens:   []db.Allergen{db.Nuts, db.Soy},
+					IngredientIDs:       []string{ingredientID},
+				}
+
+				store.EXPECT().GetAllRecipes(gomock.Any(), pagination, filter, db.Sorting{}).Times(1).Return(recipes[:2], db.PageInfo{TotalCount: 2}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var gotPage RecipeListResponse
+				err := json.NewDecoder(recorder.Body).Decode(&gotPage)
+				require.NoError(t, err)
+
+				require.Equal(t, 2, len(gotPage.Items))
+				require.Equal(t, int64(2), gotPage.TotalCount)
+				require.False(t, gotPage.HasNext)
+			},
+		},
+		{
+			name:  "Fail with invalid category",
+			query: "?page_id=1&page_size=10&category=lunch",
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().GetAllRecipes(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "Fail with unsupported dietary label",
+			query: "?page_id=1&page_size=10&dietary_labels=vegan",
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().GetAllRecipes(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "Fail with unsupported allergen",
+			query: "?page_id=1&page_size=10&excluded_allergens=shellfish",
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().GetAllRecipes(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "Fail with negative max_time_m",
+			query: "?page_id=1&page_size=10&max_time_m=-5",
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().GetAllRecipes(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "Fail with invalid author_id",
+			query: "?page_id=1&page_size=10&author_id=not-valid-id",
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().GetAllRecipes(gomock.Any(), gomock.Any(), db.RecipeFilter{AuthorID: "not-valid-id"}, db.Sorting{}).Times(1).Return(nil, db.PageInfo{}, db.ErrInvalidID)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "Success with sorting",
+			query: "?page_id=1&page_size=10&sort=timeM&order=desc&sort=createdAt",
+			buildStubs: func(store *mock_db.MockDBStore) {
+				pagination := db.Pagination{
+					PageID:   1,
+					PageSize: 10,
+				}
+
+				sorting := db.Sorting{
+					Sort:  []string{"timeM", "createdAt"},
+					Order: []string{"desc"},
+				}
+
+				store.EXPECT().GetAllRecipes(gomock.Any(), pagination, db.RecipeFilter{}, sorting).Times(1).Return(recipes, db.PageInfo{TotalCount: 25, NextCursor: "next-cursor"}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:  "Success sorting by rating",
+			query: "?page_id=1&page_size=10&sort=averageRating&order=desc",
+			buildStubs: func(store *mock_db.MockDBStore) {
+				pagination := db.Pagination{
+					PageID:   1,
+					PageSize: 10,
+				}
+
+				sorting := db.Sorting{
+					Sort:  []string{"averageRating"},
+					Order: []string{"desc"},
+				}
+
+				store.EXPECT().GetAllRecipes(gomock.Any(), pagination, db.RecipeFilter{}, sorting).Times(1).Return(recipes, db.PageInfo{TotalCount: 25}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var gotPage RecipeListResponse
+				err := json.NewDecoder(recorder.Body).Decode(&gotPage)
+				require.NoError(t, err)
+
+				for i, gotRecipe := range gotPage.Items {
+					require.Equal(t, recipes[i].AverageRating, gotRecipe.AverageRating)
+					require.Equal(t, recipes[i].RatingCount, gotRecipe.RatingCount)
+				}
+			},
+		},
+		{
+			name:  "Success with cursor",
+			query: "?cursor=next-cursor&page_size=10",
+			buildStubs: func(store *mock_db.MockDBStore) {
+				pagination := db.Pagination{
+					Cursor:   "next-cursor",
+					PageSize: 10,
+				}
+
+				store.EXPECT().GetAllRecipes(gomock.Any(), pagination, db.RecipeFilter{}, db.Sorting{}).Times(1).Return(recipes, db.PageInfo{TotalCount: 25, NextCursor: "other-cursor"}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var gotPage RecipeListResponse
+				err := json.NewDecoder(recorder.Body).Decode(&gotPage)
+				require.NoError(t, err)
+
+				require.Equal(t, 10, len(gotPage.Items))
+				require.True(t, gotPage.HasNext)
+				require.Equal(t, "other-cursor", gotPage.NextCursor)
+				require.Equal(t, "/api/v1/recipes?cursor=other-cursor&page_size=10", gotPage.Links.Next)
+				require.Empty(t, gotPage.Links.Prev)
+			},
+		},
+		{
+			name:  "Fail with cursor and page_id",
+			query: "?cursor=next-cursor&page_id=2&page_size=10",
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().GetAllRecipes(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "Fail with invalid cursor",
+			query: "?cursor=invalid&page_size=10",
+			buildStubs: func(store *mock_db.MockDBStore) {
+				pagination := db.Pagination{
+					Cursor:   "invalid",
+					PageSize: 10,
+				}
+
+				store.EXPECT().GetAllRecipes(gomock.Any(), pagination, db.RecipeFilter{}, db.Sorting{}).Times(1).Return(nil, db.PageInfo{}, db.ErrInvalidCursor)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+
+				problem := decodeProblemDetails(t, recorder)
+				require.Equal(t, CodeInvalidCursor, problem.Code)
+			},
+		},
+		{
+			name:  "Fail with invalid sort key",
+			query: "?page_id=1&page_size=10&sort=password",
+			buildStubs: func(store *mock_db.MockDBStore) {
+				sorting := db.Sorting{
+					Sort: []string{"password"},
+				}
+
+				store.EXPECT().GetAllRecipes(gomock.Any(), gomock.Any(), db.RecipeFilter{}, sorting).Times(1).Return(nil, db.PageInfo{}, db.ErrInvalidSort)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+
+				problem := decodeProblemDetails(t, recorder)
+				require.Equal(t, CodeInvalidSort, problem.Code)
+			},
+		},
 		{
 			name:  "Fail with missing page_size",
 			query: "?page_id=5",
@@ -174,7 +425,7 @@ func TestUnitListRecipes(t *testing.T) {
 					PageID: 5,
 				}
 
-				store.EXPECT().GetAllRecipes(gomock.Any(), pagination).Times(0)
+				store.EXPECT().GetAllRecipes(gomock.Any(), pagination, db.RecipeFilter{}, db.Sorting{}).Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusBadRequest, recorder.Code)
@@ -188,7 +439,7 @@ func TestUnitListRecipes(t *testing.T) {
 					PageSize: 10,
 				}
 
-				store.EXPECT().GetAllRecipes(gomock.Any(), pagination).Times(0)
+				store.EXPECT().GetAllRecipes(gomock.Any(), pagination, db.RecipeFilter{}, db.Sorting{}).Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusBadRequest, recorder.Code)
@@ -202,6 +453,7 @@ func TestUnitListRecipes(t *testing.T) {
 			defer ctrl.Finish()
 
 			store := mock_db.NewMockDBStore(ctrl)
+			expectActiveSession(store)
 			tc.buildStubs(store)
 
 			server := newTestServer(t, store)
@@ -211,7 +463,108 @@ func TestUnitListRecipes(t *testing.T) {
 			request, err := http.NewRequest(http.MethodGet, url, nil)
 			require.NoError(t, err)
 
-			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Email, time.Minute)
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Email, db.UserRole, time.Minute)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestUnitSearchRecipes(t *testing.T) {
+	user, _ := randomUser(t)
+	var results []db.RecipeSearchResult
+	for i := 0; i < 3; i++ {
+		recipe, _ := randomRecipe(t)
+		results = append(results, db.RecipeSearchResult{Recipe: recipe, Score: float64(3 - i)})
+	}
+
+	testCases := []struct {
+		name          string
+		query         string
+		buildStubs    func(store *mock_db.MockDBStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "Success with phrase and negation",
+			query: "?page_id=1&page_size=10&q=%22oat+milk%22+pancakes+-banana",
+			buildStubs: func(store *mock_db.MockDBStore) {
+				pagination := db.Pagination{
+					PageID:   1,
+					PageSize: 10,
+				}
+
+				search := db.RecipeSearch{
+					Query: `"oat milk" pancakes -banana`,
+				}
+
+				store.EXPECT().SearchRecipes(gomock.Any(), pagination, search).Times(1).Return(results, db.PageInfo{TotalCount: 3}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var gotPage RecipeSearchListResponse
+				err := json.NewDecoder(recorder.Body).Decode(&gotPage)
+				require.NoError(t, err)
+
+				require.Equal(t, 3, len(gotPage.Items))
+				require.Equal(t, int64(3), gotPage.TotalCount)
+
+				for i, expectedResult := range results {
+					requireRecipeComparison(t, expectedResult.Recipe, gotPage.Items[i].RecipeResponse)
+					require.Equal(t, expectedResult.Score, gotPage.Items[i].Score)
+				}
+			},
+		},
+		{
+			name:  "Fail with missing query",
+			query: "?page_id=1&page_size=10",
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().SearchRecipes(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "Fail with missing pagination",
+			query: "?q=pancakes",
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().SearchRecipes(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "Fail with internal server error",
+			query: "?page_id=1&page_size=10&q=pancakes",
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().SearchRecipes(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(nil, db.PageInfo{}, fmt.Errorf("text index required"))
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mock_db.NewMockDBStore(ctrl)
+			expectActiveSession(store)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/api/v1/recipes/search%s", tc.query)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.ID, db.UserRole, time.Minute)
 
 			server.router.ServeHTTP(recorder, request)
 			tc.checkResponse(recorder)
@@ -227,9 +580,34 @@ func TestUnitGetRecipeByID(t *testing.T) {
 		recipes = append(recipes, recipe)
 	}
 
+	imperialRecipe, _ := randomRecipe(t)
+	imperialRecipe.Ingredients = []db.Ingredient{
+		{Name: "flour", Amount: 2, Unit: db.Cup},
+		{Name: "butter", Amount: 4, Unit: db.Ounce},
+		{Name: "eggs", Amount: 2, Unit: db.Piece},
+	}
+
+	recipeWithoutServings, _ := randomRecipe(t)
+	recipeWithoutServings.Servings = 0
+
+	flourID := primitive.NewObjectID().Hex()
+	bananaID := primitive.NewObjectID().Hex()
+	linkedRecipe, _ := randomRecipe(t)
+	linkedRecipe.Servings = 2
+	linkedRecipe.Ingredients = []db.Ingredient{
+		{Name: "Flour", Amount: 200, Unit: db.Grams, IngredientID: flourID},
+		{Name: "Bananas", Amount: 2, IngredientID: bananaID},
+		{Name: "Vanilla", Amount: 1, Unit: db.Teaspoon},
+	}
+	catalogueIngredients := []db.CatalogueIngredient{
+		{ID: flourID, Name: "flour", Nutrients: &db.Nutrients{KCal: 364, Protein: 10.3, Fat: 1, Carbs: 76.3, Fibre: 2.7}},
+		{ID: bananaID, Name: "banana", PieceWeightG: 118, Nutrients: &db.Nutrients{KCal: 89, Protein: 1.1, Fat: 0.3, Carbs: 22.8, Fibre: 2.6}},
+	}
+
 	testCases := []struct {
 		name          string
 		id            string
+		query         string
 		buildStubs    func(store *mock_db.MockDBStore)
 		checkResponse func(recorder *httptest.ResponseRecorder)
 	}{
@@ -238,6 +616,7 @@ func TestUnitGetRecipeByID(t *testing.T) {
 			id:   recipes[1].ID,
 			buildStubs: func(store *mock_db.MockDBStore) {
 				store.EXPECT().GetRecipeByID(gomock.Any(), recipes[1].ID).Times(1).Return(recipes[1], nil)
+				store.EXPECT().GetCatalogueIngredientsByIDs(gomock.Any(), []string{}).Times(1).Return([]db.CatalogueIngredient{}, nil)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusOK, recorder.Code)
@@ -249,11 +628,116 @@ func TestUnitGetRecipeByID(t *testing.T) {
 				requireRecipeComparison(t, recipes[1], gotRecipe)
 			},
 		},
+		{
+			name:  "Success scaling the recipe to the requested servings",
+			id:    recipes[0].ID,
+			query: fmt.Sprintf("?servings=%d", recipes[0].Servings*2),
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().GetRecipeByID(gomock.Any(), recipes[0].ID).Times(1).Return(recipes[0], nil)
+				store.EXPECT().GetCatalogueIngredientsByIDs(gomock.Any(), []string{}).Times(1).Return([]db.CatalogueIngredient{}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var gotRecipe RecipeResponse
+				err := json.NewDecoder(recorder.Body).Decode(&gotRecipe)
+				require.NoError(t, err)
+
+				requireRecipeComparison(t, recipes[0].ScaleToServings(recipes[0].Servings*2), gotRecipe)
+			},
+		},
+		{
+			name: "Success calculating the nutrition from the linked catalogue ingredients",
+			id:   linkedRecipe.ID,
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().GetRecipeByID(gomock.Any(), linkedRecipe.ID).Times(1).Return(linkedRecipe, nil)
+				store.EXPECT().GetCatalogueIngredientsByIDs(gomock.Any(), []string{flourID, bananaID}).Times(1).Return(catalogueIngredients, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var gotRecipe RecipeResponse
+				err := json.NewDecoder(recorder.Body).Decode(&gotRecipe)
+				require.NoError(t, err)
+
+				require.Equal(t, &db.RecipeNutrition{
+					Total:              db.Nutrients{KCal: 938, Protein: 23.2, Fat: 2.7, Carbs: 206.4, Fibre: 11.5},
+					PerServing:         &db.Nutrients{KCal: 469, Protein: 11.6, Fat: 1.4, Carbs: 103.2, Fibre: 5.8},
+					MissingIngredients: []string{"Vanilla"},
+				}, gotRecipe.Nutrition)
+			},
+		},
+		{
+			name: "Fail with error getting the catalogue ingredients",
+			id:   linkedRecipe.ID,
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().GetRecipeByID(gomock.Any(), linkedRecipe.ID).Times(1).Return(linkedRecipe, nil)
+				store.EXPECT().GetCatalogueIngredientsByIDs(gomock.Any(), gomock.Any()).Times(1).Return(nil, fmt.Errorf("connection lost"))
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name:  "Fail with invalid servings",
+			id:    recipes[0].ID,
+			query: "?servings=101",
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().GetRecipeByID(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "Success converting the ingredients to metric units",
+			id:    imperialRecipe.ID,
+			query: "?units=metric",
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().GetRecipeByID(gomock.Any(), imperialRecipe.ID).Times(1).Return(imperialRecipe, nil)
+				store.EXPECT().GetCatalogueIngredientsByIDs(gomock.Any(), []string{}).Times(1).Return([]db.CatalogueIngredient{}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var gotRecipe RecipeResponse
+				err := json.NewDecoder(recorder.Body).Decode(&gotRecipe)
+				require.NoError(t, err)
+
+				require.Equal(t, []db.Ingredient{
+					{Name: "flour", Amount: 251, Unit: db.Grams},
+					{Name: "butter", Amount: 113, Unit: db.Grams},
+					{Name: "eggs", Amount: 2, Unit: db.Piece},
+				}, gotRecipe.Ingredients)
+			},
+		},
+		{
+			name:  "Fail with unsupported unit system",
+			id:    imperialRecipe.ID,
+			query: "?units=nautical",
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().GetRecipeByID(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "Fail scaling a recipe without servings",
+			id:    recipeWithoutServings.ID,
+			query: "?servings=4",
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().GetRecipeByID(gomock.Any(), recipeWithoutServings.ID).Times(1).Return(recipeWithoutServings, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+			},
+		},
 		{
 			name: "Fail with non-existent ID",
 			id:   "notexisting",
 			buildStubs: func(store *mock_db.MockDBStore) {
-				store.EXPECT().GetRecipeByID(gomock.Any(), "notexisting").Times(1).Return(db.Recipe{}, fmt.Errorf("failed to find recipe with recipeID: notexisting"))
+				store.EXPECT().GetRecipeByID(gomock.Any(), "notexisting").Times(1).Return(db.Recipe{}, db.ErrNotFound)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusNotFound, recorder.Code)
@@ -263,7 +747,132 @@ func TestUnitGetRecipeByID(t *testing.T) {
 			name: "Fail with non-parsable ID",
 			id:   "notexisting",
 			buildStubs: func(store *mock_db.MockDBStore) {
-				store.EXPECT().GetRecipeByID(gomock.Any(), "notexisting").Times(1).Return(db.Recipe{}, fmt.Errorf("failed to parse recipeID"))
+				store.EXPECT().GetRecipeByID(gomock.Any(), "notexisting").Times(1).Return(db.Recipe{}, db.ErrInvalidID)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mock_db.NewMockDBStore(ctrl)
+			expectActiveSession(store)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/api/v1/recipes/%s%s", tc.id, tc.query)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Email, db.UserRole, time.Minute)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestUnitListRecipeSubstitutions(t *testing.T) {
+	user, _ := randomUser(t)
+
+	eggWhiteID := primitive.NewObjectID().Hex()
+	aquafabaID := primitive.NewObjectID().Hex()
+	recipe, _ := randomRecipe(t)
+	recipe.Servings = 2
+	recipe.Ingredients = []db.Ingredient{
+		{Name: "Egg whites", Amount: 2, Unit: db.Piece, IngredientID: eggWhiteID},
+		{Name: "Sugar", Amount: 100, Unit: db.Grams},
+	}
+	substitutions := []db.Substitution{
+		{ID: primitive.NewObjectID().Hex(), IngredientID: eggWhiteID, SubstituteID: aquafabaID, SubstituteName: "aquafaba", Ratio: 2, SourceUnit: db.Piece, Unit: db.Tablespoon},
+	}
+
+	recipeWithoutServings, _ := randomRecipe(t)
+	recipeWithoutServings.Servings = 0
+
+	testCases := []struct {
+		name          string
+		id            string
+		query         string
+		buildStubs    func(store *mock_db.MockDBStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "Success listing the substitutions for the linked ingredients",
+			id:   recipe.ID,
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().GetRecipeByID(gomock.Any(), recipe.ID).Times(1).Return(recipe, nil)
+				store.EXPECT().GetSubstitutionsByIngredientIDs(gomock.Any(), []string{eggWhiteID}).Times(1).Return(substitutions, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var gotSubstitutions []db.RecipeSubstitution
+				err := json.NewDecoder(recorder.Body).Decode(&gotSubstitutions)
+				require.NoError(t, err)
+
+				require.Len(t, gotSubstitutions, 1)
+				require.Equal(t, substitutions[0].ID, gotSubstitutions[0].SubstitutionID)
+				require.Equal(t, recipe.Ingredients[0], gotSubstitutions[0].Ingredient)
+				require.Equal(t, db.Ingredient{Name: "aquafaba", Amount: 4, Unit: db.Tablespoon, IngredientID: aquafabaID}, gotSubstitutions[0].Substitute)
+			},
+		},
+		{
+			name:  "Success scaling the substitutes to the requested servings",
+			id:    recipe.ID,
+			query: "?servings=4",
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().GetRecipeByID(gomock.Any(), recipe.ID).Times(1).Return(recipe, nil)
+				store.EXPECT().GetSubstitutionsByIngredientIDs(gomock.Any(), []string{eggWhiteID}).Times(1).Return(substitutions, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var gotSubstitutions []db.RecipeSubstitution
+				err := json.NewDecoder(recorder.Body).Decode(&gotSubstitutions)
+				require.NoError(t, err)
+
+				require.Len(t, gotSubstitutions, 1)
+				require.Equal(t, db.Amount(4), gotSubstitutions[0].Ingredient.Amount)
+				require.Equal(t, db.Amount(8), gotSubstitutions[0].Substitute.Amount)
+			},
+		},
+		{
+			name:  "Fail scaling a recipe without servings",
+			id:    recipeWithoutServings.ID,
+			query: "?servings=4",
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().GetRecipeByID(gomock.Any(), recipeWithoutServings.ID).Times(1).Return(recipeWithoutServings, nil)
+				store.EXPECT().GetSubstitutionsByIngredientIDs(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+			},
+		},
+		{
+			name: "Fail with recipe not found",
+			id:   recipe.ID,
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().GetRecipeByID(gomock.Any(), recipe.ID).Times(1).Return(db.Recipe{}, db.ErrNotFound)
+				store.EXPECT().GetSubstitutionsByIngredientIDs(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "Fail with internal server error",
+			id:   recipe.ID,
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().GetRecipeByID(gomock.Any(), recipe.ID).Times(1).Return(recipe, nil)
+				store.EXPECT().GetSubstitutionsByIngredientIDs(gomock.Any(), gomock.Any()).Times(1).Return(nil, fmt.Errorf("connection lost"))
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusInternalServerError, recorder.Code)
@@ -277,16 +886,17 @@ func TestUnitGetRecipeByID(t *testing.T) {
 			defer ctrl.Finish()
 
 			store := mock_db.NewMockDBStore(ctrl)
+			expectActiveSession(store)
 			tc.buildStubs(store)
 
 			server := newTestServer(t, store)
 			recorder := httptest.NewRecorder()
 
-			url := fmt.Sprintf("/api/v1/recipes/%s", tc.id)
+			url := fmt.Sprintf("/api/v1/recipes/%s/substitutions%s", tc.id, tc.query)
 			request, err := http.NewRequest(http.MethodGet, url, nil)
 			require.NoError(t, err)
 
-			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Email, time.Minute)
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.ID, db.UserRole, time.Minute)
 
 			server.router.ServeHTTP(recorder, request)
 			tc.checkResponse(recorder)
@@ -315,7 +925,6 @@ func TestUnitCreateRecipe(t *testing.T) {
 				"ingredients": recipe.Ingredients,
 				"prepSteps":   recipe.PrepSteps,
 				"authorId":    recipe.AuthorID,
-				"userId":      recipe.UserID,
 			},
 			buildStubs: func(store *mock_db.MockDBStore) {
 				recipe.ImageName = "unique-" + recipe.ImageName
@@ -326,15 +935,34 @@ func TestUnitCreateRecipe(t *testing.T) {
 					TimeM:       recipe.TimeM,
 					Category:    recipe.Category,
 					Ingredients: recipe.Ingredients,
+					Allergens:   allergens.Detect(recipe.Ingredients),
 					PrepSteps:   recipe.PrepSteps,
 					AuthorID:    recipe.AuthorID,
-					UserID:      recipe.UserID,
+					UserID:      user.ID,
 				}).Times(1).Return(primitiveID, nil)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusOK, recorder.Code)
 			},
 		},
+		{
+			name: "Fail due to dietary label contradicting the allergens",
+			body: gin.H{
+				"name":          recipe.Name,
+				"timeM":         recipe.TimeM,
+				"category":      recipe.Category,
+				"dietaryLabels": []db.DietaryLabel{db.NutFree},
+				"ingredients":   []db.Ingredient{{Name: "Almonds", Amount: 100, Unit: db.Grams}},
+				"prepSteps":     recipe.PrepSteps,
+				"authorId":      recipe.AuthorID,
+			},
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().CreateRecipe(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+			},
+		},
 		{
 			name: "Fail due to missing name",
 			body: gin.H{
@@ -345,7 +973,6 @@ func TestUnitCreateRecipe(t *testing.T) {
 				"ingredients": recipe.Ingredients,
 				"prepSteps":   recipe.PrepSteps,
 				"authorId":    recipe.AuthorID,
-				"userId":      recipe.UserID,
 			},
 			buildStubs: func(store *mock_db.MockDBStore) {
 				store.EXPECT().CreateRecipe(gomock.Any(), gomock.Any()).Times(0)
@@ -355,7 +982,81 @@ func TestUnitCreateRecipe(t *testing.T) {
 			},
 		},
 		{
-			name: "Fail due to missing userID",
+			name: "Success creating a recipe with fractional amounts",
+			body: gin.H{
+				"name":     recipe.Name,
+				"timeM":    recipe.TimeM,
+				"category": recipe.Category,
+				"ingredients": []gin.H{
+					{"name": "flour", "amount": "1 1/2", "unit": db.Cup},
+					{"name": "salt", "amount": 0.5, "unit": db.Teaspoon},
+				},
+				"prepSteps": recipe.PrepSteps,
+				"authorId":  recipe.AuthorID,
+			},
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().CreateRecipe(gomock.Any(), db.RecipeToCreate{
+					Name:     recipe.Name,
+					TimeM:    recipe.TimeM,
+					Category: recipe.Category,
+					Ingredients: []db.Ingredient{
+						{Name: "flour", Amount: 1.5, Unit: db.Cup},
+						{Name: "salt", Amount: 0.5, Unit: db.Teaspoon},
+					},
+					Allergens: []db.Allergen{db.Gluten},
+					PrepSteps: recipe.PrepSteps,
+					AuthorID:  recipe.AuthorID,
+					UserID:    user.ID,
+				}).Times(1).Return(primitiveID, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "Fail due to unsupported ingredient unit",
+			body: gin.H{
+				"name":     recipe.Name,
+				"timeM":    recipe.TimeM,
+				"category": recipe.Category,
+				"ingredients": []gin.H{
+					{"name": "flour", "amount": 1, "unit": "handful"},
+				},
+				"prepSteps": recipe.PrepSteps,
+				"authorId":  recipe.AuthorID,
+			},
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().CreateRecipe(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+
+				problem := decodeProblemDetails(t, recorder)
+				require.Len(t, problem.Errors, 1)
+				require.Equal(t, "ingredients[0].unit", problem.Errors[0].Field)
+			},
+		},
+		{
+			name: "Fail due to invalid fractional amount",
+			body: gin.H{
+				"name":     recipe.Name,
+				"timeM":    recipe.TimeM,
+				"category": recipe.Category,
+				"ingredients": []gin.H{
+					{"name": "flour", "amount": "1/0", "unit": db.Cup},
+				},
+				"prepSteps": recipe.PrepSteps,
+				"authorId":  recipe.AuthorID,
+			},
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().CreateRecipe(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "Success ignoring a client supplied userId",
 			body: gin.H{
 				"name":        recipe.Name,
 				"imageName":   recipe.ImageName,
@@ -365,12 +1066,24 @@ func TestUnitCreateRecipe(t *testing.T) {
 				"ingredients": recipe.Ingredients,
 				"prepSteps":   recipe.PrepSteps,
 				"authorId":    recipe.AuthorID,
+				"userId":      primitive.NewObjectID().Hex(),
 			},
 			buildStubs: func(store *mock_db.MockDBStore) {
-				store.EXPECT().CreateRecipe(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().CreateRecipe(gomock.Any(), db.RecipeToCreate{
+					Name:        recipe.Name,
+					ImageName:   recipe.ImageName,
+					RecipeURL:   recipe.RecipeURL,
+					TimeM:       recipe.TimeM,
+					Category:    recipe.Category,
+					Ingredients: recipe.Ingredients,
+					Allergens:   allergens.Detect(recipe.Ingredients),
+					PrepSteps:   recipe.PrepSteps,
+					AuthorID:    recipe.AuthorID,
+					UserID:      user.ID,
+				}).Times(1).Return(primitiveID, nil)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Equal(t, http.StatusOK, recorder.Code)
 			},
 		},
 		{
@@ -383,7 +1096,6 @@ func TestUnitCreateRecipe(t *testing.T) {
 				"category":    recipe.Category,
 				"ingredients": recipe.Ingredients,
 				"prepSteps":   recipe.PrepSteps,
-				"userId":      recipe.UserID,
 			},
 			buildStubs: func(store *mock_db.MockDBStore) {
 				store.EXPECT().CreateRecipe(gomock.Any(), gomock.Any()).Times(0)
@@ -403,7 +1115,6 @@ func TestUnitCreateRecipe(t *testing.T) {
 				"ingredients": recipe.Ingredients,
 				"prepSteps":   recipe.PrepSteps,
 				"authorId":    recipe.AuthorID,
-				"userId":      recipe.UserID,
 			},
 			buildStubs: func(store *mock_db.MockDBStore) {
 				store.EXPECT().CreateRecipe(gomock.Any(), db.RecipeToCreate{
@@ -413,13 +1124,33 @@ func TestUnitCreateRecipe(t *testing.T) {
 					TimeM:       recipe.TimeM,
 					Category:    recipe.Category,
 					Ingredients: recipe.Ingredients,
+					Allergens:   allergens.Detect(recipe.Ingredients),
 					PrepSteps:   recipe.PrepSteps,
 					AuthorID:    recipe.AuthorID,
-					UserID:      recipe.UserID,
-				}).Times(1).Return(primitive.NilObjectID, fmt.Errorf("recipe with name %s already exists", recipe.Name))
+					UserID:      user.ID,
+				}).Times(1).Return(primitive.NilObjectID, db.ErrDuplicateKey)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "Fail due to ingredientId of a catalogue ingredient not found",
+			body: gin.H{
+				"name":        recipe.Name,
+				"imageName":   recipe.ImageName,
+				"recipeUrl":   recipe.RecipeURL,
+				"timeM":       recipe.TimeM,
+				"category":    recipe.Category,
+				"ingredients": recipe.Ingredients,
+				"prepSteps":   recipe.PrepSteps,
+				"authorId":    recipe.AuthorID,
+			},
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().CreateRecipe(gomock.Any(), gomock.Any()).Times(1).Return(primitive.NilObjectID, db.ErrNotFound)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
 			},
 		},
 	}
@@ -430,6 +1161,7 @@ func TestUnitCreateRecipe(t *testing.T) {
 			defer ctrl.Finish()
 
 			store := mock_db.NewMockDBStore(ctrl)
+			expectActiveSession(store)
 			tc.buildStubs(store)
 
 			server := newTestServer(t, store)
@@ -443,7 +1175,7 @@ func TestUnitCreateRecipe(t *testing.T) {
 			request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
 			require.NoError(t, err)
 
-			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Email, time.Minute)
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.ID, db.UserRole, time.Minute)
 
 			server.router.ServeHTTP(recorder, request)
 			tc.checkResponse(recorder)
@@ -453,6 +1185,7 @@ func TestUnitCreateRecipe(t *testing.T) {
 
 func TestUnitPatchRecipeByID(t *testing.T) {
 	user, _ := randomUser(t)
+	admin := randomAdmin(t)
 	recipe, _ := randomRecipe(t)
 	nonMatchingID := primitive.NewObjectID().Hex()
 
@@ -461,7 +1194,7 @@ func TestUnitPatchRecipeByID(t *testing.T) {
 	fullRecipePatch := db.RecipeUpdate{
 		Name:        util.RandomString(6),
 		ImageName:   util.RandomString(6),
-		RecipeURL:   util.RandomString(10),
+		RecipeURL:   util.RandomURL(),
 		TimeM:       int(util.RandomInt(15, 180)),
 		Category:    "breakfast",
 		Ingredients: ingredients,
@@ -472,13 +1205,17 @@ func TestUnitPatchRecipeByID(t *testing.T) {
 	testCases := []struct {
 		name          string
 		id            string
+		userID        string
+		role          db.Role
 		body          gin.H
 		buildStubs    func(store *mock_db.MockDBStore)
 		checkResponse func(recorder *httptest.ResponseRecorder)
 	}{
 		{
-			name: "Success with full update of the recipe",
-			id:   recipe.ID,
+			name:   "Success with full update of the recipe",
+			id:     recipe.ID,
+			userID: recipe.UserID,
+			role:   db.UserRole,
 			body: gin.H{
 				"name":        fullRecipePatch.Name,
 				"imageName":   fullRecipePatch.ImageName,
@@ -493,6 +1230,7 @@ func TestUnitPatchRecipeByID(t *testing.T) {
 				store.EXPECT().GetRecipeByID(gomock.Any(), recipe.ID).Times(1).Return(recipe, nil)
 
 				fullRecipePatch.ImageName = "unique-" + fullRecipePatch.ImageName
+				fullRecipePatch.Allergens = allergens.Detect(fullRecipePatch.Ingredients)
 				store.EXPECT().UpdateRecipeByID(gomock.Any(), recipe.ID, fullRecipePatch).Times(1).Return(int64(1), nil)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
@@ -500,8 +1238,10 @@ func TestUnitPatchRecipeByID(t *testing.T) {
 			},
 		},
 		{
-			name: "Success with partial update of the recipe",
-			id:   recipe.ID,
+			name:   "Success with partial update of the recipe",
+			id:     recipe.ID,
+			userID: recipe.UserID,
+			role:   db.UserRole,
 			body: gin.H{
 				"name": fullRecipePatch.Name,
 			},
@@ -516,8 +1256,62 @@ func TestUnitPatchRecipeByID(t *testing.T) {
 			},
 		},
 		{
-			name: "Fail due to missing id",
-			id:   "",
+			name:   "Success with update by an admin not being the owner",
+			id:     recipe.ID,
+			userID: admin.ID,
+			role:   db.AdminRole,
+			body: gin.H{
+				"name": fullRecipePatch.Name,
+			},
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().GetRecipeByID(gomock.Any(), recipe.ID).Times(1).Return(recipe, nil)
+				store.EXPECT().UpdateRecipeByID(gomock.Any(), recipe.ID, db.RecipeUpdate{
+					Name: fullRecipePatch.Name,
+				}).Times(1).Return(int64(1), nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:   "Fail due to dietary label contradicting the allergens of the recipe",
+			id:     recipe.ID,
+			userID: recipe.UserID,
+			role:   db.UserRole,
+			body: gin.H{
+				"dietaryLabels": []db.DietaryLabel{db.NutFree},
+			},
+			buildStubs: func(store *mock_db.MockDBStore) {
+				recipeWithNuts := recipe
+				recipeWithNuts.Allergens = []db.Allergen{db.Nuts}
+				store.EXPECT().GetRecipeByID(gomock.Any(), recipe.ID).Times(1).Return(recipeWithNuts, nil)
+				store.EXPECT().UpdateRecipeByID(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+			},
+		},
+		{
+			name:   "Fail due to the user not being the owner",
+			id:     recipe.ID,
+			userID: user.ID,
+			role:   db.UserRole,
+			body: gin.H{
+				"name": fullRecipePatch.Name,
+			},
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().GetRecipeByID(gomock.Any(), recipe.ID).Times(1).Return(recipe, nil)
+				store.EXPECT().UpdateRecipeByID(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:   "Fail due to missing id",
+			id:     "",
+			userID: recipe.UserID,
+			role:   db.UserRole,
 			body: gin.H{
 				"name": fullRecipePatch.Name,
 			},
@@ -530,9 +1324,11 @@ func TestUnitPatchRecipeByID(t *testing.T) {
 			},
 		},
 		{
-			name: "Fail due to missing body",
-			id:   recipe.ID,
-			body: gin.H{},
+			name:   "Fail due to missing body",
+			id:     recipe.ID,
+			userID: recipe.UserID,
+			role:   db.UserRole,
+			body:   gin.H{},
 			buildStubs: func(store *mock_db.MockDBStore) {
 				store.EXPECT().GetRecipeByID(gomock.Any(), recipe.ID).Times(0)
 				store.EXPECT().UpdateRecipeByID(gomock.Any(), recipe.ID, gomock.Any()).Times(0)
@@ -542,13 +1338,15 @@ func TestUnitPatchRecipeByID(t *testing.T) {
 			},
 		},
 		{
-			name: "Fail due to the provided recipeID not being valid",
-			id:   "not-valid-id",
+			name:   "Fail due to the provided recipeID not being valid",
+			id:     "not-valid-id",
+			userID: recipe.UserID,
+			role:   db.UserRole,
 			body: gin.H{
 				"name": fullRecipePatch.Name,
 			},
 			buildStubs: func(store *mock_db.MockDBStore) {
-				store.EXPECT().GetRecipeByID(gomock.Any(), "not-valid-id").Times(1).Return(db.Recipe{}, fmt.Errorf("failed to parse recipeID"))
+				store.EXPECT().GetRecipeByID(gomock.Any(), "not-valid-id").Times(1).Return(db.Recipe{}, db.ErrInvalidID)
 				store.EXPECT().UpdateRecipeByID(gomock.Any(), "not-valid-id", db.RecipeUpdate{
 					Name: fullRecipePatch.Name,
 				}).Times(0)
@@ -558,13 +1356,15 @@ func TestUnitPatchRecipeByID(t *testing.T) {
 			},
 		},
 		{
-			name: "Fail due to no matching recipe for recipe ID",
-			id:   nonMatchingID,
+			name:   "Fail due to no matching recipe for recipe ID",
+			id:     nonMatchingID,
+			userID: recipe.UserID,
+			role:   db.UserRole,
 			body: gin.H{
 				"name": fullRecipePatch.Name,
 			},
 			buildStubs: func(store *mock_db.MockDBStore) {
-				store.EXPECT().GetRecipeByID(gomock.Any(), nonMatchingID).Times(1).Return(db.Recipe{}, fmt.Errorf("failed to find recipe"))
+				store.EXPECT().GetRecipeByID(gomock.Any(), nonMatchingID).Times(1).Return(db.Recipe{}, db.ErrNotFound)
 				store.EXPECT().UpdateRecipeByID(gomock.Any(), nonMatchingID, db.RecipeUpdate{
 					Name: fullRecipePatch.Name,
 				}).Times(0)
@@ -581,6 +1381,7 @@ func TestUnitPatchRecipeByID(t *testing.T) {
 			defer ctrl.Finish()
 
 			store := mock_db.NewMockDBStore(ctrl)
+			expectActiveSession(store)
 			tc.buildStubs(store)
 
 			server := newTestServer(t, store)
@@ -594,7 +1395,7 @@ func TestUnitPatchRecipeByID(t *testing.T) {
 			request, err := http.NewRequest(http.MethodPatch, url, bytes.NewReader(data))
 			require.NoError(t, err)
 
-			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Email, time.Minute)
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, tc.userID, tc.role, time.Minute)
 
 			server.router.ServeHTTP(recorder, request)
 			tc.checkResponse(recorder)
@@ -604,18 +1405,23 @@ func TestUnitPatchRecipeByID(t *testing.T) {
 
 func TestUnitDeleteRecipeByID(t *testing.T) {
 	user, _ := randomUser(t)
+	admin := randomAdmin(t)
 	recipe, _ := randomRecipe(t)
 	nonMatchingID := primitive.NewObjectID().Hex()
 
 	testCases := []struct {
 		name          string
 		id            string
+		userID        string
+		role          db.Role
 		buildStubs    func(store *mock_db.MockDBStore)
 		checkResponse func(recorder *httptest.ResponseRecorder)
 	}{
 		{
-			name: "Success deleting the recipe",
-			id:   recipe.ID,
+			name:   "Success deleting the recipe",
+			id:     recipe.ID,
+			userID: recipe.UserID,
+			role:   db.UserRole,
 			buildStubs: func(store *mock_db.MockDBStore) {
 				store.EXPECT().GetRecipeByID(gomock.Any(), recipe.ID).Times(1).Return(recipe, nil)
 				store.EXPECT().DeleteRecipeByID(gomock.Any(), recipe.ID).Times(1).Return(int64(1), nil)
@@ -625,8 +1431,36 @@ func TestUnitDeleteRecipeByID(t *testing.T) {
 			},
 		},
 		{
-			name: "Fail due to missing id",
-			id:   "",
+			name:   "Success deleting the recipe as an admin not being the owner",
+			id:     recipe.ID,
+			userID: admin.ID,
+			role:   db.AdminRole,
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().GetRecipeByID(gomock.Any(), recipe.ID).Times(1).Return(recipe, nil)
+				store.EXPECT().DeleteRecipeByID(gomock.Any(), recipe.ID).Times(1).Return(int64(1), nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:   "Fail due to the user not being the owner",
+			id:     recipe.ID,
+			userID: user.ID,
+			role:   db.UserRole,
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().GetRecipeByID(gomock.Any(), recipe.ID).Times(1).Return(recipe, nil)
+				store.EXPECT().DeleteRecipeByID(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:   "Fail due to missing id",
+			id:     "",
+			userID: recipe.UserID,
+			role:   db.UserRole,
 			buildStubs: func(store *mock_db.MockDBStore) {
 				store.EXPECT().GetRecipeByID(gomock.Any(), "").Times(0)
 				store.EXPECT().DeleteRecipeByID(gomock.Any(), "").Times(0)
@@ -636,10 +1470,12 @@ func TestUnitDeleteRecipeByID(t *testing.T) {
 			},
 		},
 		{
-			name: "Fail due to the provided recipeID not being valid",
-			id:   "not-valid-id",
+			name:   "Fail due to the provided recipeID not being valid",
+			id:     "not-valid-id",
+			userID: recipe.UserID,
+			role:   db.UserRole,
 			buildStubs: func(store *mock_db.MockDBStore) {
-				store.EXPECT().GetRecipeByID(gomock.Any(), "not-valid-id").Times(1).Return(db.Recipe{}, fmt.Errorf("failed to parse recipeID"))
+				store.EXPECT().GetRecipeByID(gomock.Any(), "not-valid-id").Times(1).Return(db.Recipe{}, db.ErrInvalidID)
 				store.EXPECT().DeleteRecipeByID(gomock.Any(), "not-valid-id").Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
@@ -647,10 +1483,12 @@ func TestUnitDeleteRecipeByID(t *testing.T) {
 			},
 		},
 		{
-			name: "Fail due to no matching recipe for recipe ID",
-			id:   nonMatchingID,
+			name:   "Fail due to no matching recipe for recipe ID",
+			id:     nonMatchingID,
+			userID: recipe.UserID,
+			role:   db.UserRole,
 			buildStubs: func(store *mock_db.MockDBStore) {
-				store.EXPECT().GetRecipeByID(gomock.Any(), nonMatchingID).Times(1).Return(db.Recipe{}, fmt.Errorf("failed to find recipe"))
+				store.EXPECT().GetRecipeByID(gomock.Any(), nonMatchingID).Times(1).Return(db.Recipe{}, db.ErrNotFound)
 				store.EXPECT().DeleteRecipeByID(gomock.Any(), "not-valid-id").Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
@@ -665,6 +1503,7 @@ func TestUnitDeleteRecipeByID(t *testing.T) {
 			defer ctrl.Finish()
 
 			store := mock_db.NewMockDBStore(ctrl)
+			expectActiveSession(store)
 			tc.buildStubs(store)
 
 			server := newTestServer(t, store)
@@ -675,7 +1514,7 @@ func TestUnitDeleteRecipeByID(t *testing.T) {
 			request, err := http.NewRequest(http.MethodDelete, url, nil)
 			require.NoError(t, err)
 
-			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Email, time.Minute)
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, tc.userID, tc.role, time.Minute)
 
 			server.router.ServeHTTP(recorder, request)
 			tc.checkResponse(recorder)
@@ -690,12 +1529,17 @@ func requireRecipeComparison(t *testing.T, expectedRecipe db.Recipe, gotRecipe R
 	require.Equal(t, expectedRecipe.Name, gotRecipe.Name)
 	require.Equal(t, expectedRecipe.ImageName, gotRecipe.ImageName)
 	require.Equal(t, expectedRecipe.Category, gotRecipe.Category)
+	require.Equal(t, expectedRecipe.Tags, gotRecipe.Tags)
+	require.Equal(t, expectedRecipe.DietaryLabels, gotRecipe.DietaryLabels)
 	require.Equal(t, expectedRecipe.TimeM, gotRecipe.TimeM)
+	require.Equal(t, expectedRecipe.Servings, gotRecipe.Servings)
 	require.Equal(t, expectedRecipe.RecipeURL, gotRecipe.RecipeURL)
 	require.Equal(t, expectedRecipe.AuthorID, gotRecipe.AuthorID)
 	require.Equal(t, expectedRecipe.UserID, gotRecipe.UserID)
 	require.Equal(t, expectedRecipe.Ingredients, gotRecipe.Ingredients)
 	require.Equal(t, expectedRecipe.PrepSteps, gotRecipe.PrepSteps)
+	require.Equal(t, expectedRecipe.AverageRating, gotRecipe.AverageRating)
+	require.Equal(t, expectedRecipe.RatingCount, gotRecipe.RatingCount)
 
 	requireAuthorComparison(t, expectedRecipe.Author, gotRecipe.Author)
 	requireUserComparison(t, expectedRecipe.UserCreated, gotRecipe.UserCreated)
@@ -0,0 +1,259 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/PfMartin/wegonice-api/db"
+	"github.com/PfMartin/wegonice-api/token"
+	"github.com/gin-gonic/gin"
+)
+
+// listRecipeReviews
+//
+// @Summary			List the reviews of a recipe
+// @Description	All reviews of the recipe, which matches the ID, are listed in a paginated manner. They are sorted by their creation, if no sorting is provided.
+// @ID					recipes-list-recipe-reviews
+// @Tags				recipes
+// @Accept			json
+// @Produce			json
+// @Param				authorization						header			string							false	"Authorization header for bearer token"
+// @Param				id											path 				string							true	"ID of the desired recipe"
+// @Param				page_id									query 			int									false	"Offset for the pagination, required without cursor"
+// @Param				page_size								query 			int									true	"Number of elements in one page"
+// @Param				cursor									query 			string							false	"Cursor of the next page, which was returned with the previous page"
+// @Param				sort										query 			[]string						false	"Keys to sort by"	collectionFormat(multi)	Enums(rating, createdAt, modifiedAt)
+// @Param				order										query 			[]string						false	"Sort order per key"	collectionFormat(multi)	Enums(asc, desc)
+// @Success			200											{object}		ReviewListResponse				"Page of reviews of the recipe"
+// @Failure			400											{object}		ProblemDetails						"Bad Request"
+// @Failure			401											{object}		ProblemDetails						"Unauthorized"
+// @Failure			404											{object}		ProblemDetails						"Not Found"
+// @Failure 		500											{object}		ProblemDetails						"Internal Server Error"
+// @Router			/recipes/{id}/reviews		[get]
+func (server *Server) listRecipeReviews(ctx *gin.Context) {
+	var uriParam getByIDRequest
+	if err := ctx.ShouldBindUri(&uriParam); err != nil {
+		NewErrorBadRequest(err).Send(ctx)
+		return
+	}
+
+	var pagination db.Pagination
+	if err := ctx.ShouldBindQuery(&pagination); err != nil {
+		NewErrorBadRequest(err).Send(ctx)
+		return
+	}
+
+	var sorting db.Sorting
+	if err := ctx.ShouldBindQuery(&sorting); err != nil {
+		NewErrorBadRequest(err).Send(ctx)
+		return
+	}
+
+	if _, err := server.store.GetRecipeByID(ctx, uriParam.ID); err != nil {
+		newErrorFromDB(err).Send(ctx)
+		return
+	}
+
+	reviews, pageInfo, err := server.store.GetReviewsByRecipeID(ctx, uriParam.ID, pagination, sorting)
+	if err != nil {
+		newErrorFromDB(err).Send(ctx)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newListResponse(ctx, reviews, pagination, pageInfo))
+}
+
+// createRecipeReview
+//
+// @Summary			Create new review of a recipe
+// @Description	Creates a new review of the recipe, which matches the ID, for the current user. Each user can review a recipe once.
+// @ID					recipes-create-recipe-review
+// @Tags				recipes
+// @Accept			json
+// @Produce			json
+// @Param				authorization						header			string							false	"Authorization header for bearer token"
+// @Param				id											path 				string							true	"ID of the recipe to review"
+// @Param				data										body 				ReviewToCreate			true	"Data for the review to create"
+// @Success			201											string			string										"ID of the created review"
+// @Failure			400											{object}		ProblemDetails						"Bad Request"
+// @Failure			401											{object}		ProblemDetails						"Unauthorized"
+// @Failure			403											{object}		ProblemDetails						"Forbidden"
+// @Failure			404											{object}		ProblemDetails						"Not Found"
+// @Failure			409											{object}		ProblemDetails						"Conflict, the user already reviewed the recipe"
+// @Failure 		500											{object}		ProblemDetails						"Internal Server Error"
+// @Router			/recipes/{id}/reviews		[post]
+func (server *Server) createRecipeReview(ctx *gin.Context) {
+	var uriParam getByIDRequest
+	if err := ctx.ShouldBindUri(&uriParam); err != nil {
+		NewErrorBadRequest(err).Send(ctx)
+		return
+	}
+
+	var reviewBody db.ReviewToCreate
+	if err := ctx.ShouldBindJSON(&reviewBody); err != nil {
+		NewErrorBadRequest(err).Send(ctx)
+		return
+	}
+
+	if _, err := server.store.GetRecipeByID(ctx, uriParam.ID); err != nil {
+		newErrorFromDB(err).Send(ctx)
+		return
+	}
+
+	payload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	reviewBody.RecipeID = uriParam.ID
+	reviewBody.UserID = payload.UserID
+
+	reviewID, err := server.store.CreateReview(ctx, reviewBody)
+	if err != nil {
+		newErrorFromDB(err).Send(ctx)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, reviewID)
+}
+
+// getReviewByID
+//
+// @Summary			Get one review by ID
+// @Description	One review, which matches the ID, is returned
+// @ID					reviews-get-review-by-id
+// @Tags				reviews
+// @Accept			json
+// @Produce			json
+// @Param				authorization		header			string							false	"Authorization header for bearer token"
+// @Param				id							path 				string							true	"ID of the desired review"
+// @Success			200							{object}		ReviewResponse						"Review that matches the ID"
+// @Failure			400							{object}		ProblemDetails						"Bad Request"
+// @Failure			401							{object}		ProblemDetails						"Unauthorized"
+// @Failure			404							{object}		ProblemDetails						"Not Found"
+// @Failure 		500							{object}		ProblemDetails						"Internal Server Error"
+// @Router			/reviews/{id}		[get]
+func (server *Server) getReviewByID(ctx *gin.Context) {
+	var uriParam getByIDRequest
+	if err := ctx.ShouldBindUri(&uriParam); err != nil {
+		NewErrorBadRequest(err).Send(ctx)
+		return
+	}
+
+	review, err := server.store.GetReviewByID(ctx, uriParam.ID)
+	if err != nil {
+		newErrorFromDB(err).Send(ctx)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, review)
+}
+
+// patchReviewByID
+//
+// @Summary			Patch one review by ID
+// @Description	One review, which matches the ID, is modified with the provided patch. An empty text clears the text of the review. Only the user, who created the review, is allowed to patch it.
+// @ID					reviews-patch-review-by-id
+// @Tags				reviews
+// @Accept			json
+// @Produce			json
+// @Param				authorization		header			string							false	"Authorization header for bearer token"
+// @Param				id							path 				string							true	"ID of the desired review to patch"
+// @Param				data						body 				ReviewUpdate				true	"Patch for modifying the review"
+// @Success			200
+// @Failure			400							{object}		ProblemDetails						"Bad Request"
+// @Failure			401							{object}		ProblemDetails						"Unauthorized"
+// @Failure			403							{object}		ProblemDetails						"Forbidden"
+// @Failure			404							{object}		ProblemDetails						"Not Found"
+// @Failure 		500							{object}		ProblemDetails						"Internal Server Error"
+// @Router			/reviews/{id}		[patch]
+func (server *Server) patchReviewByID(ctx *gin.Context) {
+	var uriParam getByIDRequest
+	if err := ctx.ShouldBindUri(&uriParam); err != nil {
+		NewErrorBadRequest(err).Send(ctx)
+		return
+	}
+
+	var reviewPatch db.ReviewUpdate
+	if err := ctx.ShouldBindJSON(&reviewPatch); err != nil {
+		NewErrorBadRequest(err).Send(ctx)
+		return
+	}
+
+	if reviewPatch.Rating == nil && reviewPatch.Text == nil {
+		NewErrorBadRequest(fmt.Errorf("missing review patch")).Send(ctx)
+		return
+	}
+
+	existingReview, err := server.store.GetReviewByID(ctx, uriParam.ID)
+	if err != nil {
+		newErrorFromDB(err).Send(ctx)
+		return
+	}
+
+	// Reviews are the opinion of their user, so not even admins are allowed to modify them.
+	payload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if payload.UserID != existingReview.UserID {
+		NewErrorForbidden(fmt.Errorf("not allowed to modify review with ID: %s", uriParam.ID)).Send(ctx)
+		return
+	}
+
+	modifiedCount, err := server.store.UpdateReviewByID(ctx, uriParam.ID, reviewPatch)
+	if err != nil {
+		newErrorFromDB(err).Send(ctx)
+		return
+	}
+
+	if modifiedCount < 1 {
+		NewErrorNotFound(fmt.Errorf("could not find review with ID: %s", uriParam.ID)).Send(ctx)
+		return
+	}
+
+	ctx.Status(http.StatusOK)
+}
+
+// deleteReviewByID
+//
+// @Summary			Delete one review by ID
+// @Description	One review, which matches the ID, is deleted. Only the user, who created the review, or an admin is allowed to delete it.
+// @ID					reviews-delete-review-by-id
+// @Tags				reviews
+// @Accept			json
+// @Produce			json
+// @Param				authorization		header			string							false	"Authorization header for bearer token"
+// @Param				id							path 				string							true	"ID of the desired review to delete"
+// @Success			200
+// @Failure			400							{object}		ProblemDetails						"Bad Request"
+// @Failure			401							{object}		ProblemDetails						"Unauthorized"
+// @Failure			403							{object}		ProblemDetails						"Forbidden"
+// @Failure			404							{object}		ProblemDetails						"Not Found"
+// @Failure 		500							{object}		ProblemDetails						"Internal Server Error"
+// @Router			/reviews/{id}		[delete]
+func (server *Server) deleteReviewByID(ctx *gin.Context) {
+	var uriParam getByIDRequest
+	if err := ctx.ShouldBindUri(&uriParam); err != nil {
+		NewErrorBadRequest(err).Send(ctx)
+		return
+	}
+
+	existingReview, err := server.store.GetReviewByID(ctx, uriParam.ID)
+	if err != nil {
+		newErrorFromDB(err).Send(ctx)
+		return
+	}
+
+	payload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if !isOwnerOrPermitted(payload, existingReview.UserID, permissionManageContent) {
+		NewErrorForbidden(fmt.Errorf("not allowed to delete review with ID: %s", uriParam.ID)).Send(ctx)
+		return
+	}
+
+	deleteCount, err := server.store.DeleteReviewByID(ctx, uriParam.ID)
+	if err != nil {
+		newErrorFromDB(err).Send(ctx)
+		return
+	}
+
+	if deleteCount < 1 {
+		NewErrorNotFound(fmt.Errorf("could not find review with ID: %s", uriParam.ID)).Send(ctx)
+		return
+	}
+
+	ctx.Status(http.StatusOK)
+}
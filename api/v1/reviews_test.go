@@ -0,0 +1,572 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/PfMartin/wegonice-api/db"
+	mock_db "github.com/PfMartin/wegonice-api/db/mock"
+	"github.com/PfMartin/wegonice-api/util"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func randomReview(t *testing.T, recipeID string, user db.User) (db.Review, primitive.ObjectID) {
+	t.Helper()
+
+	reviewID := primitive.NewObjectID()
+
+	return db.Review{
+		ID:       reviewID.Hex(),
+		RecipeID: recipeID,
+		Rating:   int(util.RandomInt(1, 5)),
+		Text:     util.RandomString(20),
+		UserID:   user.ID,
+		UserCreated: db.User{
+			ID:    user.ID,
+			Email: user.Email,
+		},
+		CreatedAt:  time.Now().Unix(),
+		ModifiedAt: time.Now().Unix(),
+	}, reviewID
+}
+
+func TestUnitListRecipeReviews(t *testing.T) {
+	user, _ := randomUser(t)
+	recipe, _ := randomRecipe(t)
+	var reviews []db.Review
+	for i := 0; i < 5; i++ {
+		reviewer, _ := randomUser(t)
+		review, _ := randomReview(t, recipe.ID, reviewer)
+
+		reviews = append(reviews, review)
+	}
+
+	testCases := []struct {
+		name          string
+		id            string
+		query         string
+		buildStubs    func(store *mock_db.MockDBStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "Success with pagination from 1 to 5",
+			id:    recipe.ID,
+			query: "?page_id=1&page_size=5",
+			buildStubs: func(store *mock_db.MockDBStore) {
+				pagination := db.Pagination{
+					PageID:   1,
+					PageSize: 5,
+				}
+
+				store.EXPECT().GetRecipeByID(gomock.Any(), recipe.ID).Times(1).Return(recipe, nil)
+				store.EXPECT().GetReviewsByRecipeID(gomock.Any(), recipe.ID, pagination, db.Sorting{}).Times(1).Return(reviews, db.PageInfo{TotalCount: 5}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var gotPage ReviewListResponse
+				err := json.NewDecoder(recorder.Body).Decode(&gotPage)
+				require.NoError(t, err)
+
+				require.Len(t, gotPage.Items, len(reviews))
+				for i, gotReview := range gotPage.Items {
+					require.Equal(t, reviews[i].ID, gotReview.ID)
+					require.Equal(t, reviews[i].Rating, gotReview.Rating)
+					require.Equal(t, reviews[i].Text, gotReview.Text)
+					require.Equal(t, reviews[i].UserCreated.Email, gotReview.UserCreated.Email)
+				}
+				require.Equal(t, int64(5), gotPage.TotalCount)
+			},
+		},
+		{
+			name:  "Success sorting by rating",
+			id:    recipe.ID,
+			query: "?page_id=1&page_size=5&sort=rating&order=desc",
+			buildStubs: func(store *mock_db.MockDBStore) {
+				pagination := db.Pagination{
+					PageID:   1,
+					PageSize: 5,
+				}
+				sorting := db.Sorting{
+					Sort:  []string{"rating"},
+					Order: []string{"desc"},
+				}
+
+				store.EXPECT().GetRecipeByID(gomock.Any(), recipe.ID).Times(1).Return(recipe, nil)
+				store.EXPECT().GetReviewsByRecipeID(gomock.Any(), recipe.ID, pagination, sorting).Times(1).Return(reviews, db.PageInfo{TotalCount: 5}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:  "Fail with recipe not found",
+			id:    recipe.ID,
+			query: "?page_id=1&page_size=5",
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().GetRecipeByID(gomock.Any(), recipe.ID).Times(1).Return(db.Recipe{}, db.ErrNotFound)
+				store.EXPECT().GetReviewsByRecipeID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:  "Fail with invalid recipe ID",
+			id:    "test",
+			query: "?page_id=1&page_size=5",
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().GetRecipeByID(gomock.Any(), "test").Times(1).Return(db.Recipe{}, db.ErrInvalidID)
+				store.EXPECT().GetReviewsByRecipeID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "Fail with missing page_size",
+			id:    recipe.ID,
+			query: "?page_id=1",
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().GetReviewsByRecipeID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mock_db.NewMockDBStore(ctrl)
+			expectActiveSession(store)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/api/v1/recipes/%s/reviews%s", tc.id, tc.query)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.ID, db.UserRole, time.Minute)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestUnitCreateRecipeReview(t *testing.T) {
+	user, _ := randomUser(t)
+	recipe, _ := randomRecipe(t)
+	review, primitiveID := randomReview(t, recipe.ID, user)
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mock_db.MockDBStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "Success creating a review for the current user",
+			body: gin.H{
+				"rating": review.Rating,
+				"text":   review.Text,
+			},
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().GetRecipeByID(gomock.Any(), recipe.ID).Times(1).Return(recipe, nil)
+				store.EXPECT().CreateReview(gomock.Any(), db.ReviewToCreate{
+					RecipeID: recipe.ID,
+					Rating:   review.Rating,
+					Text:     review.Text,
+					UserID:   user.ID,
+				}).Times(1).Return(primitiveID, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "Fail due to rating above 5 stars",
+			body: gin.H{
+				"rating": 6,
+			},
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().GetRecipeByID(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().CreateReview(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "Fail due to missing rating",
+			body: gin.H{
+				"text": review.Text,
+			},
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().GetRecipeByID(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().CreateReview(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "Fail with recipe not found",
+			body: gin.H{
+				"rating": review.Rating,
+			},
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().GetRecipeByID(gomock.Any(), recipe.ID).Times(1).Return(db.Recipe{}, db.ErrNotFound)
+				store.EXPECT().CreateReview(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "Fail due to already reviewed recipe",
+			body: gin.H{
+				"rating": review.Rating,
+			},
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().GetRecipeByID(gomock.Any(), recipe.ID).Times(1).Return(recipe, nil)
+				store.EXPECT().CreateReview(gomock.Any(), gomock.Any()).Times(1).Return(primitive.NilObjectID, db.ErrDuplicateKey)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mock_db.NewMockDBStore(ctrl)
+			expectActiveSession(store)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/api/v1/recipes/%s/reviews", recipe.ID)
+			request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.ID, db.UserRole, time.Minute)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestUnitGetReviewByID(t *testing.T) {
+	user, _ := randomUser(t)
+	recipe, _ := randomRecipe(t)
+	review, _ := randomReview(t, recipe.ID, user)
+	nonMatchingID := primitive.NewObjectID().Hex()
+
+	testCases := []struct {
+		name          string
+		id            string
+		buildStubs    func(store *mock_db.MockDBStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "Success getting the review",
+			id:   review.ID,
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().GetReviewByID(gomock.Any(), review.ID).Times(1).Return(review, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var gotReview ReviewResponse
+				err := json.NewDecoder(recorder.Body).Decode(&gotReview)
+				require.NoError(t, err)
+
+				require.Equal(t, review.ID, gotReview.ID)
+				require.Equal(t, review.RecipeID, gotReview.RecipeID)
+				require.Equal(t, review.Rating, gotReview.Rating)
+				require.Equal(t, review.Text, gotReview.Text)
+				require.Equal(t, review.UserID, gotReview.UserID)
+			},
+		},
+		{
+			name: "Fail with review not found",
+			id:   nonMatchingID,
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().GetReviewByID(gomock.Any(), nonMatchingID).Times(1).Return(db.Review{}, db.ErrNotFound)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mock_db.NewMockDBStore(ctrl)
+			expectActiveSession(store)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodGet, fmt.Sprintf("/api/v1/reviews/%s", tc.id), nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.ID, db.UserRole, time.Minute)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestUnitPatchReviewByID(t *testing.T) {
+	user, _ := randomUser(t)
+	otherUser, _ := randomUser(t)
+	admin := randomAdmin(t)
+	recipe, _ := randomRecipe(t)
+	review, _ := randomReview(t, recipe.ID, user)
+
+	rating := 3
+	text := "Too salty"
+	emptyText := ""
+
+	testCases := []struct {
+		name          string
+		userID        string
+		role          db.Role
+		body          gin.H
+		buildStubs    func(store *mock_db.MockDBStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "Success patching the own review",
+			userID: user.ID,
+			role:   db.UserRole,
+			body:   gin.H{"rating": 3, "text": "Too salty"},
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().GetReviewByID(gomock.Any(), review.ID).Times(1).Return(review, nil)
+				store.EXPECT().UpdateReviewByID(gomock.Any(), review.ID, db.ReviewUpdate{Rating: &rating, Text: &text}).Times(1).Return(int64(1), nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:   "Success clearing the text of the own review",
+			userID: user.ID,
+			role:   db.UserRole,
+			body:   gin.H{"text": ""},
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().GetReviewByID(gomock.Any(), review.ID).Times(1).Return(review, nil)
+				store.EXPECT().UpdateReviewByID(gomock.Any(), review.ID, db.ReviewUpdate{Text: &emptyText}).Times(1).Return(int64(1), nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:   "Fail patching the review of another user",
+			userID: otherUser.ID,
+			role:   db.UserRole,
+			body:   gin.H{"rating": 1},
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().GetReviewByID(gomock.Any(), review.ID).Times(1).Return(review, nil)
+				store.EXPECT().UpdateReviewByID(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:   "Fail patching the review of another user as an admin",
+			userID: admin.ID,
+			role:   db.AdminRole,
+			body:   gin.H{"rating": 1},
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().GetReviewByID(gomock.Any(), review.ID).Times(1).Return(review, nil)
+				store.EXPECT().UpdateReviewByID(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:   "Fail due to empty patch",
+			userID: user.ID,
+			role:   db.UserRole,
+			body:   gin.H{},
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().GetReviewByID(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().UpdateReviewByID(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:   "Fail due to rating below 1 star",
+			userID: user.ID,
+			role:   db.UserRole,
+			body:   gin.H{"rating": -1},
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().GetReviewByID(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().UpdateReviewByID(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:   "Fail with review not found",
+			userID: user.ID,
+			role:   db.UserRole,
+			body:   gin.H{"rating": 3},
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().GetReviewByID(gomock.Any(), review.ID).Times(1).Return(db.Review{}, db.ErrNotFound)
+				store.EXPECT().UpdateReviewByID(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mock_db.NewMockDBStore(ctrl)
+			expectActiveSession(store)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPatch, fmt.Sprintf("/api/v1/reviews/%s", review.ID), bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, tc.userID, tc.role, time.Minute)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestUnitDeleteReviewByID(t *testing.T) {
+	user, _ := randomUser(t)
+	otherUser, _ := randomUser(t)
+	admin := randomAdmin(t)
+	recipe, _ := randomRecipe(t)
+	review, _ := randomReview(t, recipe.ID, user)
+
+	testCases := []struct {
+		name          string
+		userID        string
+		role          db.Role
+		buildStubs    func(store *mock_db.MockDBStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "Success deleting the own review",
+			userID: user.ID,
+			role:   db.UserRole,
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().GetReviewByID(gomock.Any(), review.ID).Times(1).Return(review, nil)
+				store.EXPECT().DeleteReviewByID(gomock.Any(), review.ID).Times(1).Return(int64(1), nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:   "Success deleting the review of another user as an admin",
+			userID: admin.ID,
+			role:   db.AdminRole,
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().GetReviewByID(gomock.Any(), review.ID).Times(1).Return(review, nil)
+				store.EXPECT().DeleteReviewByID(gomock.Any(), review.ID).Times(1).Return(int64(1), nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:   "Fail deleting the review of another user",
+			userID: otherUser.ID,
+			role:   db.UserRole,
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().GetReviewByID(gomock.Any(), review.ID).Times(1).Return(review, nil)
+				store.EXPECT().DeleteReviewByID(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:   "Fail with review not found",
+			userID: user.ID,
+			role:   db.UserRole,
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().GetReviewByID(gomock.Any(), review.ID).Times(1).Return(db.Review{}, db.ErrNotFound)
+				store.EXPECT().DeleteReviewByID(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mock_db.NewMockDBStore(ctrl)
+			expectActiveSession(store)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodDelete, fmt.Sprintf("/api/v1/reviews/%s", review.ID), nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, tc.userID, tc.role, time.Minute)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
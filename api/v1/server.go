@@ -62,6 +62,7 @@ func NewServer(
 		imageManager: *imageManager,
 	}
 
+	registerValidators()
 	server.setupRoutes()
 
 	return server
@@ -69,9 +70,12 @@ func NewServer(
 
 func (server *Server) setupRoutes() {
 	router := gin.Default()
+	router.Use(requestIDMiddleware())
 	router.Use(cors.New(cors.Config{
-		AllowOrigins: server.config.corsAllowedOrigins,
-		AllowMethods: []string{"GET", "POST", "PATCH", "DELETE"},
+		AllowOrigins:  server.config.corsAllowedOrigins,
+		AllowMethods:  []string{"GET", "POST", "PATCH", "DELETE"},
+		AllowHeaders:  []string{"Origin", "Content-Length", "Content-Type", "Authorization", requestIDHeaderKey},
+		ExposeHeaders: []string{requestIDHeaderKey},
 	}))
 
 	v1Routes := router.Group(server.config.basePath)
@@ -82,26 +86,80 @@ func (server *Server) setupRoutes() {
 	authRoutes := v1Routes.Group("/auth")
 	authRoutes.POST("/register", server.registerUser)
 	authRoutes.POST("/login", server.loginUser)
+	authRoutes.POST("/refresh", server.refreshAccessToken)
+	authRoutes.POST("/logout", authMiddleware(server.tokenMaker, server.store), server.logoutUser)
+	authRoutes.POST("/logout-all", authMiddleware(server.tokenMaker, server.store), server.logoutUserEverywhere)
+
+	adminRoutes := v1Routes.Group("/admin")
+	adminRoutes.Use(authMiddleware(server.tokenMaker, server.store), requireRole(db.AdminRole))
+	adminRoutes.GET("/users/pending", requirePermission(permissionManageUsers), server.listPendingUsers)
+	adminRoutes.POST("/users/:id/approve", requirePermission(permissionManageUsers), server.approveUser)
+	adminRoutes.POST("/users/:id/reject", requirePermission(permissionManageUsers), server.rejectUser)
+	adminRoutes.GET("/users/:id/sessions", requirePermission(permissionManageSessions), server.listUserSessions)
+	adminRoutes.DELETE("/users/:id/sessions", requirePermission(permissionManageSessions), server.revokeUserSessions)
+	adminRoutes.DELETE("/sessions/:id", requirePermission(permissionManageSessions), server.revokeSessionByID)
+	adminRoutes.POST("/ingredients/import", requirePermission(permissionManageContent), server.importIngredientNutrients)
+
+	userRoutes := v1Routes.Group("/users")
+	userRoutes.Use(authMiddleware(server.tokenMaker, server.store))
+	userRoutes.GET("", requirePermission(permissionManageUsers), server.listUsers)
+	userRoutes.GET("/me", server.getCurrentUser)
+	userRoutes.GET("/:id", server.getUserByID)
+	userRoutes.GET("/:id/recipes", server.listUserRecipes)
+	userRoutes.PATCH("/:id", server.patchUserByID)
+	userRoutes.DELETE("/:id", server.deleteUserByID)
 
 	authorRoutes := v1Routes.Group("/authors")
-	authorRoutes.Use(authMiddleware(server.tokenMaker))
+	authorRoutes.Use(authMiddleware(server.tokenMaker, server.store))
 	authorRoutes.GET("", server.listAuthors)
-	authorRoutes.POST("/", server.createAuthor)
+	authorRoutes.POST("/", requirePermission(permissionWriteContent), server.createAuthor)
 	authorRoutes.GET("/:id", server.getAuthorByID)
-	authorRoutes.PATCH("/:id", server.patchAuthorByID)
-	authorRoutes.DELETE("/:id", server.deleteAuthorByID)
+	authorRoutes.GET("/:id/recipes", server.listAuthorRecipes)
+	authorRoutes.PATCH("/:id", requirePermission(permissionWriteContent), server.patchAuthorByID)
+	authorRoutes.DELETE("/:id", requirePermission(permissionDeleteContent), server.deleteAuthorByID)
 
 	recipeRoutes := v1Routes.Group("/recipes")
-	recipeRoutes.Use(authMiddleware(server.tokenMaker))
+	recipeRoutes.Use(authMiddleware(server.tokenMaker, server.store))
 	recipeRoutes.GET("", server.listRecipes)
-	recipeRoutes.POST("/", server.createRecipe)
+	recipeRoutes.GET("/search", server.searchRecipes)
+	recipeRoutes.POST("/", requirePermission(permissionWriteContent), server.createRecipe)
 	recipeRoutes.GET("/:id", server.getRecipeByID)
-	recipeRoutes.PATCH("/:id", server.patchRecipeByID)
-	recipeRoutes.DELETE("/:id", server.deleteRecipeByID)
+	recipeRoutes.GET("/:id/substitutions", server.listRecipeSubstitutions)
+	recipeRoutes.GET("/:id/reviews", server.listRecipeReviews)
+	recipeRoutes.POST("/:id/reviews", requirePermission(permissionWriteContent), server.createRecipeReview)
+	recipeRoutes.PATCH("/:id", requirePermission(permissionWriteContent), server.patchRecipeByID)
+	recipeRoutes.DELETE("/:id", requirePermission(permissionDeleteContent), server.deleteRecipeByID)
+
+	reviewRoutes := v1Routes.Group("/reviews")
+	reviewRoutes.Use(authMiddleware(server.tokenMaker, server.store))
+	reviewRoutes.GET("/:id", server.getReviewByID)
+	reviewRoutes.PATCH("/:id", requirePermission(permissionWriteContent), server.patchReviewByID)
+	reviewRoutes.DELETE("/:id", requirePermission(permissionDeleteContent), server.deleteReviewByID)
+
+	ingredientRoutes := v1Routes.Group("/ingredients")
+	ingredientRoutes.Use(authMiddleware(server.tokenMaker, server.store))
+	ingredientRoutes.GET("", server.listIngredients)
+	ingredientRoutes.GET("/autocomplete", server.autocompleteIngredients)
+	ingredientRoutes.POST("/", requirePermission(permissionWriteContent), server.createIngredient)
+	ingredientRoutes.GET("/:id", server.getIngredientByID)
+	ingredientRoutes.PATCH("/:id", requirePermission(permissionManageContent), server.patchIngredientByID)
+	ingredientRoutes.DELETE("/:id", requirePermission(permissionManageContent), server.deleteIngredientByID)
+
+	substitutionRoutes := v1Routes.Group("/substitutions")
+	substitutionRoutes.Use(authMiddleware(server.tokenMaker, server.store))
+	substitutionRoutes.GET("", server.listSubstitutions)
+	substitutionRoutes.POST("/", requirePermission(permissionWriteContent), server.createSubstitution)
+	substitutionRoutes.GET("/:id", server.getSubstitutionByID)
+	substitutionRoutes.PATCH("/:id", requirePermission(permissionManageContent), server.patchSubstitutionByID)
+	substitutionRoutes.DELETE("/:id", requirePermission(permissionManageContent), server.deleteSubstitutionByID)
+
+	tagRoutes := v1Routes.Group("/tags")
+	tagRoutes.Use(authMiddleware(server.tokenMaker, server.store))
+	tagRoutes.GET("", server.listTags)
 
 	imagesRoutes := v1Routes.Group("/images")
-	imagesRoutes.Use(authMiddleware(server.tokenMaker))
-	imagesRoutes.POST("", server.SaveImage)
+	imagesRoutes.Use(authMiddleware(server.tokenMaker, server.store))
+	imagesRoutes.POST("", requirePermission(permissionWriteContent), server.SaveImage)
 	imagesRoutes.GET("/:imageName", server.GetImage)
 
 	server.router = router
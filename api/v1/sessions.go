@@ -0,0 +1,124 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/PfMartin/wegonice-api/db"
+	"github.com/gin-gonic/gin"
+)
+
+func newSessionResponse(session db.Session) SessionResponse {
+	return SessionResponse{
+		ID:        session.ID,
+		UserID:    session.UserID,
+		UserAgent: session.UserAgent,
+		ClientIP:  session.ClientIP,
+		IsBlocked: session.IsBlocked,
+		ExpiresAt: session.ExpiresAt,
+	}
+}
+
+// listUserSessions
+//
+// @Summary			List all sessions of a user
+// @Description	All sessions of the user, which matches the ID, are listed. Only admins are allowed to list sessions.
+// @ID					admin-list-user-sessions
+// @Tags				admin
+// @Accept			json
+// @Produce			json
+// @Param				authorization						header			string							false	"Authorization header for bearer token"
+// @Param				id											path 				string							true	"ID of the user"
+// @Success			200											{array}			SessionResponse						"List of sessions of the user"
+// @Failure			400											{object}		ProblemDetails						"Bad Request"
+// @Failure			401											{object}		ProblemDetails						"Unauthorized"
+// @Failure			403											{object}		ProblemDetails						"Forbidden"
+// @Failure 		500											{object}		ProblemDetails						"Internal Server Error"
+// @Router			/admin/users/{id}/sessions	[get]
+func (server *Server) listUserSessions(ctx *gin.Context) {
+	var uriParam getByIDRequest
+	if err := ctx.ShouldBindUri(&uriParam); err != nil {
+		NewErrorBadRequest(err).Send(ctx)
+		return
+	}
+
+	sessions, err := server.store.GetSessionsByUserID(ctx, uriParam.ID)
+	if err != nil {
+		newErrorFromDB(err).Send(ctx)
+		return
+	}
+
+	res := []SessionResponse{}
+	for _, session := range sessions {
+		res = append(res, newSessionResponse(session))
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+// revokeUserSessions
+//
+// @Summary			Revoke all sessions of a user
+// @Description	All sessions of the user, which matches the ID, are revoked. Only admins are allowed to revoke sessions.
+// @ID					admin-revoke-user-sessions
+// @Tags				admin
+// @Accept			json
+// @Produce			json
+// @Param				authorization						header			string							false	"Authorization header for bearer token"
+// @Param				id											path 				string							true	"ID of the user"
+// @Success			200
+// @Failure			400											{object}		ProblemDetails						"Bad Request"
+// @Failure			401											{object}		ProblemDetails						"Unauthorized"
+// @Failure			403											{object}		ProblemDetails						"Forbidden"
+// @Failure 		500											{object}		ProblemDetails						"Internal Server Error"
+// @Router			/admin/users/{id}/sessions	[delete]
+func (server *Server) revokeUserSessions(ctx *gin.Context) {
+	var uriParam getByIDRequest
+	if err := ctx.ShouldBindUri(&uriParam); err != nil {
+		NewErrorBadRequest(err).Send(ctx)
+		return
+	}
+
+	if _, err := server.store.BlockSessionsByUserID(ctx, uriParam.ID); err != nil {
+		newErrorFromDB(err).Send(ctx)
+		return
+	}
+
+	ctx.Status(http.StatusOK)
+}
+
+// revokeSessionByID
+//
+// @Summary			Revoke one session by ID
+// @Description	One session, which matches the ID, is revoked. Only admins are allowed to revoke sessions.
+// @ID					admin-revoke-session-by-id
+// @Tags				admin
+// @Accept			json
+// @Produce			json
+// @Param				authorization				header			string							false	"Authorization header for bearer token"
+// @Param				id									path 				string							true	"ID of the session to revoke"
+// @Success			200
+// @Failure			400									{object}		ProblemDetails						"Bad Request"
+// @Failure			401									{object}		ProblemDetails						"Unauthorized"
+// @Failure			403									{object}		ProblemDetails						"Forbidden"
+// @Failure			404									{object}		ProblemDetails						"Not Found"
+// @Router			/admin/sessions/{id}	[delete]
+func (server *Server) revokeSessionByID(ctx *gin.Context) {
+	var uriParam getByIDRequest
+	if err := ctx.ShouldBindUri(&uriParam); err != nil {
+		NewErrorBadRequest(err).Send(ctx)
+		return
+	}
+
+	session, err := server.store.GetSessionByID(ctx, uriParam.ID)
+	if err != nil {
+		newErrorFromDB(err).Send(ctx)
+		return
+	}
+
+	if _, err = server.store.BlockSessionByID(ctx, session.ID); err != nil {
+		newErrorFromDB(err).Send(ctx)
+		return
+	}
+
+	ctx.Status(http.StatusOK)
+}
@@ -0,0 +1,259 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/PfMartin/wegonice-api/db"
+	mock_db "github.com/PfMartin/wegonice-api/db/mock"
+	"github.com/PfMartin/wegonice-api/util"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func randomAdmin(t *testing.T) db.User {
+	t.Helper()
+
+	admin, _ := randomUser(t)
+	admin.Role = db.AdminRole
+
+	return admin
+}
+
+func randomSession(t *testing.T, userID string) db.Session {
+	t.Helper()
+
+	return db.Session{
+		ID:           primitive.NewObjectID().Hex(),
+		UserID:       userID,
+		RefreshToken: util.RandomString(10),
+		UserAgent:    util.RandomString(6),
+		ClientIP:     util.RandomString(12),
+		ExpiresAt:    time.Now().Add(time.Hour).Unix(),
+	}
+}
+
+func TestUnitListUserSessions(t *testing.T) {
+	admin := randomAdmin(t)
+	user, _ := randomUser(t)
+
+	var sessions []db.Session
+	for i := 0; i < 3; i++ {
+		sessions = append(sessions, randomSession(t, user.ID))
+	}
+
+	testCases := []struct {
+		name          string
+		caller        db.User
+		buildStubs    func(store *mock_db.MockDBStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "Success",
+			caller: admin,
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().GetSessionsByUserID(gomock.Any(), user.ID).Times(1).Return(sessions, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var gotSessions []SessionResponse
+				err := json.NewDecoder(recorder.Body).Decode(&gotSessions)
+				require.NoError(t, err)
+
+				require.Equal(t, len(sessions), len(gotSessions))
+				for i, session := range sessions {
+					require.Equal(t, session.ID, gotSessions[i].ID)
+					require.Equal(t, session.UserID, gotSessions[i].UserID)
+					require.Equal(t, session.ExpiresAt, gotSessions[i].ExpiresAt)
+				}
+
+				require.NotContains(t, recorder.Body.String(), sessions[0].RefreshToken)
+			},
+		},
+		{
+			name:   "Fails due to caller not being an admin",
+			caller: user,
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().GetSessionsByUserID(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:   "Fails due to error while getting the sessions",
+			caller: admin,
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().GetSessionsByUserID(gomock.Any(), user.ID).Times(1).Return(nil, fmt.Errorf("failed to get sessions"))
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mock_db.NewMockDBStore(ctrl)
+			expectActiveSession(store)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/api/v1/admin/users/%s/sessions", user.ID)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, tc.caller.ID, tc.caller.Role, time.Minute)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestUnitRevokeUserSessions(t *testing.T) {
+	admin := randomAdmin(t)
+	user, _ := randomUser(t)
+
+	testCases := []struct {
+		name          string
+		caller        db.User
+		buildStubs    func(store *mock_db.MockDBStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "Success",
+			caller: admin,
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().BlockSessionsByUserID(gomock.Any(), user.ID).Times(1).Return(int64(2), nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:   "Fails due to caller not being an admin",
+			caller: user,
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().BlockSessionsByUserID(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:   "Fails due to error while blocking the sessions",
+			caller: admin,
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().BlockSessionsByUserID(gomock.Any(), user.ID).Times(1).Return(int64(0), fmt.Errorf("failed to block sessions"))
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mock_db.NewMockDBStore(ctrl)
+			expectActiveSession(store)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/api/v1/admin/users/%s/sessions", user.ID)
+			request, err := http.NewRequest(http.MethodDelete, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, tc.caller.ID, tc.caller.Role, time.Minute)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestUnitRevokeSessionByID(t *testing.T) {
+	admin := randomAdmin(t)
+	user, _ := randomUser(t)
+	session := randomSession(t, user.ID)
+
+	testCases := []struct {
+		name          string
+		caller        db.User
+		buildStubs    func(store *mock_db.MockDBStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "Success",
+			caller: admin,
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().GetSessionByID(gomock.Any(), session.ID).Times(1).Return(session, nil)
+				store.EXPECT().BlockSessionByID(gomock.Any(), session.ID).Times(1).Return(int64(1), nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:   "Fails due to caller not being an admin",
+			caller: user,
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().GetSessionByID(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().BlockSessionByID(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:   "Fails due to session not found",
+			caller: admin,
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().GetSessionByID(gomock.Any(), session.ID).Times(1).Return(db.Session{}, db.ErrNotFound)
+				store.EXPECT().BlockSessionByID(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mock_db.NewMockDBStore(ctrl)
+			expectActiveSession(store)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/api/v1/admin/sessions/%s", session.ID)
+			request, err := http.NewRequest(http.MethodDelete, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, tc.caller.ID, tc.caller.Role, time.Minute)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
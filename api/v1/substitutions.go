@@ -0,0 +1,222 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/PfMartin/wegonice-api/db"
+	"github.com/gin-gonic/gin"
+)
+
+// listSubstitutions
+//
+// @Summary			List all substitutions
+// @Description	All ingredient substitutions are listed in a paginated manner. They are sorted by the name of the replaced ingredient, if no sorting is provided.
+// @ID					substitutions-list-substitutions
+// @Tags				substitutions
+// @Accept			json
+// @Produce			json
+// @Param				authorization		header			string							false	"Authorization header for bearer token"
+// @Param				page_id					query 			int									false	"Offset for the pagination, required without cursor"
+// @Param				page_size				query 			int									true	"Number of elements in one page"
+// @Param				cursor					query 			string							false	"Cursor of the next page, which was returned with the previous page"
+// @Param				ingredient_id		query 			string							false	"ID of the catalogue ingredient, which is replaced"
+// @Param				sort						query 			[]string						false	"Keys to sort by"	collectionFormat(multi)	Enums(ingredientName, substituteName, ratio, createdAt, modifiedAt)
+// @Param				order						query 			[]string						false	"Sort order per key"	collectionFormat(multi)	Enums(asc, desc)
+// @Success			200							{object}		SubstitutionListResponse	"Page of substitutions matching the given pagination parameters"
+// @Failure			400							{object}		ProblemDetails						"Bad Request"
+// @Failure			401							{object}		ProblemDetails						"Unauthorized"
+// @Failure 		500							{object}		ProblemDetails						"Internal Server Error"
+// @Router			/substitutions	[get]
+func (server *Server) listSubstitutions(ctx *gin.Context) {
+	var pagination db.Pagination
+	if err := ctx.ShouldBindQuery(&pagination); err != nil {
+		NewErrorBadRequest(err).Send(ctx)
+		return
+	}
+
+	var filter db.SubstitutionFilter
+	if err := ctx.ShouldBindQuery(&filter); err != nil {
+		NewErrorBadRequest(err).Send(ctx)
+		return
+	}
+
+	var sorting db.Sorting
+	if err := ctx.ShouldBindQuery(&sorting); err != nil {
+		NewErrorBadRequest(err).Send(ctx)
+		return
+	}
+
+	substitutions, pageInfo, err := server.store.GetAllSubstitutions(ctx, pagination, filter, sorting)
+	if err != nil {
+		newErrorFromDB(err).Send(ctx)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newListResponse(ctx, substitutions, pagination, pageInfo))
+}
+
+// createSubstitution
+//
+// @Summary			Create new substitution
+// @Description	Creates a new substitution of one catalogue ingredient with another one. Both catalogue ingredients have to exist. A substitute in another unit requires the source unit, which the ratio applies to.
+// @ID					substitutions-create-substitution
+// @Tags				substitutions
+// @Accept			json
+// @Produce			json
+// @Param				authorization		header			string									false	"Authorization header for bearer token"
+// @Param				data						body 				SubstitutionToCreate		true	"Data for the substitution to create"
+// @Success			201							string			string										"ID of the created substitution"
+// @Failure			400							{object}		ProblemDetails						"Bad Request"
+// @Failure			401							{object}		ProblemDetails						"Unauthorized"
+// @Failure			403							{object}		ProblemDetails						"Forbidden"
+// @Failure			409							{object}		ProblemDetails						"Conflict, the substitution already exists"
+// @Failure			422							{object}		ProblemDetails						"Unprocessable Entity, a catalogue ingredient does not exist"
+// @Failure 		500							{object}		ProblemDetails						"Internal Server Error"
+// @Router			/substitutions	[post]
+func (server *Server) createSubstitution(ctx *gin.Context) {
+	var substitutionBody db.SubstitutionToCreate
+	if err := ctx.ShouldBindJSON(&substitutionBody); err != nil {
+		NewErrorBadRequest(err).Send(ctx)
+		return
+	}
+
+	catalogueIngredients, err := server.store.GetCatalogueIngredientsByIDs(ctx, []string{substitutionBody.IngredientID, substitutionBody.SubstituteID})
+	if err != nil {
+		newErrorFromDB(err).Send(ctx)
+		return
+	}
+
+	if len(catalogueIngredients) < 2 {
+		NewErrorUnprocessableEntity(fmt.Errorf("could not find catalogue ingredients with IDs %s and %s", substitutionBody.IngredientID, substitutionBody.SubstituteID)).Send(ctx)
+		return
+	}
+
+	substitutionID, err := server.store.CreateSubstitution(ctx, substitutionBody)
+	if err != nil {
+		newErrorFromDB(err).Send(ctx)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, substitutionID)
+}
+
+// getSubstitutionByID
+//
+// @Summary			Get one substitution by ID
+// @Description	One substitution, which matches the ID, is returned
+// @ID					substitutions-get-substitution-by-id
+// @Tags				substitutions
+// @Accept			json
+// @Produce			json
+// @Param				authorization				header			string							false	"Authorization header for bearer token"
+// @Param				id									path 				string							true	"ID of the desired substitution"
+// @Success			200									{object}		Substitution							"Substitution that matches the ID"
+// @Failure			400									{object}		ProblemDetails						"Bad Request"
+// @Failure			401									{object}		ProblemDetails						"Unauthorized"
+// @Failure			404									{object}		ProblemDetails						"Not Found"
+// @Failure 		500									{object}		ProblemDetails						"Internal Server Error"
+// @Router			/substitutions/{id}	[get]
+func (server *Server) getSubstitutionByID(ctx *gin.Context) {
+	var uriParam getByIDRequest
+	if err := ctx.ShouldBindUri(&uriParam); err != nil {
+		NewErrorBadRequest(err).Send(ctx)
+		return
+	}
+
+	substitution, err := server.store.GetSubstitutionByID(ctx, uriParam.ID)
+	if err != nil {
+		newErrorFromDB(err).Send(ctx)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, substitution)
+}
+
+// patchSubstitutionByID
+//
+// @Summary			Patch one substitution by ID
+// @Description	One substitution, which matches the ID, is modified with the provided patch. Substitutions are shared by all users, so only users, who are allowed to manage content, are allowed to patch them.
+// @ID					substitutions-patch-substitution-by-id
+// @Tags				substitutions
+// @Accept			json
+// @Produce			json
+// @Param				authorization				header			string									false	"Authorization header for bearer token"
+// @Param				id									path 				string									true	"ID of the desired substitution to patch"
+// @Param				data								body 				SubstitutionUpdate			true	"Patch for modifying the substitution"
+// @Success			200
+// @Failure			400									{object}		ProblemDetails						"Bad Request"
+// @Failure			401									{object}		ProblemDetails						"Unauthorized"
+// @Failure			403									{object}		ProblemDetails						"Forbidden"
+// @Failure			404									{object}		ProblemDetails						"Not Found"
+// @Failure 		500									{object}		ProblemDetails						"Internal Server Error"
+// @Router			/substitutions/{id}	[patch]
+func (server *Server) patchSubstitutionByID(ctx *gin.Context) {
+	var uriParam getByIDRequest
+	if err := ctx.ShouldBindUri(&uriParam); err != nil {
+		NewErrorBadRequest(err).Send(ctx)
+		return
+	}
+
+	var substitutionPatch db.SubstitutionUpdate
+	if err := ctx.ShouldBindJSON(&substitutionPatch); err != nil {
+		NewErrorBadRequest(err).Send(ctx)
+		return
+	}
+
+	if substitutionPatch.Ratio == 0 && substitutionPatch.SourceUnit == "" && substitutionPatch.Unit == "" && substitutionPatch.Notes == "" {
+		NewErrorBadRequest(fmt.Errorf("missing substitution patch")).Send(ctx)
+		return
+	}
+
+	modifiedCount, err := server.store.UpdateSubstitutionByID(ctx, uriParam.ID, substitutionPatch)
+	if err != nil {
+		newErrorFromDB(err).Send(ctx)
+		return
+	}
+
+	if modifiedCount < 1 {
+		NewErrorNotFound(fmt.Errorf("could not find substitution with ID: %s", uriParam.ID)).Send(ctx)
+		return
+	}
+
+	ctx.Status(http.StatusOK)
+}
+
+// deleteSubstitutionByID
+//
+// @Summary			Delete one substitution by ID
+// @Description	One substitution, which matches the ID, is deleted. Only users, who are allowed to manage content, are allowed to delete it.
+// @ID					substitutions-delete-substitution-by-id
+// @Tags				substitutions
+// @Accept			json
+// @Produce			json
+// @Param				authorization				header			string							false	"Authorization header for bearer token"
+// @Param				id									path 				string							true	"ID of the desired substitution to delete"
+// @Success			200
+// @Failure			400									{object}		ProblemDetails						"Bad Request"
+// @Failure			401									{object}		ProblemDetails						"Unauthorized"
+// @Failure			403									{object}		ProblemDetails						"Forbidden"
+// @Failure			404									{object}		ProblemDetails						"Not Found"
+// @Failure 		500									{object}		ProblemDetails						"Internal Server Error"
+// @Router			/substitutions/{id}	[delete]
+func (server *Server) deleteSubstitutionByID(ctx *gin.Context) {
+	var uriParam getByIDRequest
+	if err := ctx.ShouldBindUri(&uriParam); err != nil {
+		NewErrorBadRequest(err).Send(ctx)
+		return
+	}
+
+	deleteCount, err := server.store.DeleteSubstitutionByID(ctx, uriParam.ID)
+	if err != nil {
+		newErrorFromDB(err).Send(ctx)
+		return
+	}
+
+	if deleteCount < 1 {
+		NewErrorNotFound(fmt.Errorf("could not find substitution with ID: %s", uriParam.ID)).Send(ctx)
+		return
+	}
+
+	ctx.Status(http.StatusOK)
+}
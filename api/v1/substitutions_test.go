@@ -0,0 +1,526 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/PfMartin/wegonice-api/db"
+	mock_db "github.com/PfMartin/wegonice-api/db/mock"
+	"github.com/PfMartin/wegonice-api/util"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func randomSubstitution(t *testing.T) (db.Substitution, primitive.ObjectID) {
+	t.Helper()
+
+	substitutionID := primitive.NewObjectID()
+
+	return db.Substitution{
+		ID:             substitutionID.Hex(),
+		IngredientID:   primitive.NewObjectID().Hex(),
+		IngredientName: util.RandomString(8),
+		SubstituteID:   primitive.NewObjectID().Hex(),
+		SubstituteName: util.RandomString(8),
+		Ratio:          2,
+		SourceUnit:     db.Piece,
+		Unit:           db.Tablespoon,
+		Notes:          util.RandomString(20),
+		CreatedAt:      time.Now().Unix(),
+		ModifiedAt:     time.Now().Unix(),
+	}, substitutionID
+}
+
+func TestUnitListSubstitutions(t *testing.T) {
+	user, _ := randomUser(t)
+	var substitutions []db.Substitution
+	for i := 0; i < 10; i++ {
+		substitution, _ := randomSubstitution(t)
+
+		substitutions = append(substitutions, substitution)
+	}
+
+	testCases := []struct {
+		name          string
+		query         string
+		buildStubs    func(store *mock_db.MockDBStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "Success with pagination from 1 to 10",
+			query: "?page_id=1&page_size=10",
+			buildStubs: func(store *mock_db.MockDBStore) {
+				pagination := db.Pagination{
+					PageID:   1,
+					PageSize: 10,
+				}
+
+				store.EXPECT().GetAllSubstitutions(gomock.Any(), pagination, db.SubstitutionFilter{}, db.Sorting{}).Times(1).Return(substitutions, db.PageInfo{TotalCount: 10}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var gotPage SubstitutionListResponse
+				err := json.NewDecoder(recorder.Body).Decode(&gotPage)
+				require.NoError(t, err)
+
+				require.Equal(t, substitutions, gotPage.Items)
+				require.Equal(t, int64(10), gotPage.TotalCount)
+				require.False(t, gotPage.HasNext)
+			},
+		},
+		{
+			name:  "Success filtering by the replaced ingredient",
+			query: "?page_id=1&page_size=10&ingredient_id=" + substitutions[0].IngredientID,
+			buildStubs: func(store *mock_db.MockDBStore) {
+				pagination := db.Pagination{
+					PageID:   1,
+					PageSize: 10,
+				}
+				filter := db.SubstitutionFilter{IngredientID: substitutions[0].IngredientID}
+
+				store.EXPECT().GetAllSubstitutions(gomock.Any(), pagination, filter, db.Sorting{}).Times(1).Return(substitutions[:1], db.PageInfo{TotalCount: 1}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:  "Fail with invalid ingredient_id",
+			query: "?page_id=1&page_size=10&ingredient_id=test",
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().GetAllSubstitutions(gomock.Any(), gomock.Any(), db.SubstitutionFilter{IngredientID: "test"}, gomock.Any()).Times(1).Return(nil, db.PageInfo{}, db.ErrInvalidID)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "Fail with missing page_size",
+			query: "?page_id=1",
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().GetAllSubstitutions(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mock_db.NewMockDBStore(ctrl)
+			expectActiveSession(store)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodGet, "/api/v1/substitutions"+tc.query, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.ID, db.UserRole, time.Minute)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestUnitCreateSubstitution(t *testing.T) {
+	user, _ := randomUser(t)
+	substitution, primitiveID := randomSubstitution(t)
+	catalogueIngredientIDs := []string{substitution.IngredientID, substitution.SubstituteID}
+	catalogueIngredients := []db.CatalogueIngredient{
+		{ID: substitution.IngredientID, Name: substitution.IngredientName},
+		{ID: substitution.SubstituteID, Name: substitution.SubstituteName},
+	}
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mock_db.MockDBStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "Success creating a new substitution",
+			body: gin.H{
+				"ingredientId": substitution.IngredientID,
+				"substituteId": substitution.SubstituteID,
+				"ratio":        substitution.Ratio,
+				"sourceUnit":   substitution.SourceUnit,
+				"unit":         substitution.Unit,
+				"notes":        substitution.Notes,
+			},
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().GetCatalogueIngredientsByIDs(gomock.Any(), catalogueIngredientIDs).Times(1).Return(catalogueIngredients, nil)
+				store.EXPECT().CreateSubstitution(gomock.Any(), db.SubstitutionToCreate{
+					IngredientID: substitution.IngredientID,
+					SubstituteID: substitution.SubstituteID,
+					Ratio:        substitution.Ratio,
+					SourceUnit:   substitution.SourceUnit,
+					Unit:         substitution.Unit,
+					Notes:        substitution.Notes,
+				}).Times(1).Return(primitiveID, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "Fail due to substituting an ingredient with itself",
+			body: gin.H{
+				"ingredientId": substitution.IngredientID,
+				"substituteId": substitution.IngredientID,
+				"ratio":        1,
+			},
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().GetCatalogueIngredientsByIDs(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().CreateSubstitution(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "Fail due to missing ratio",
+			body: gin.H{
+				"ingredientId": substitution.IngredientID,
+				"substituteId": substitution.SubstituteID,
+			},
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().GetCatalogueIngredientsByIDs(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().CreateSubstitution(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "Fail due to unit without source unit",
+			body: gin.H{
+				"ingredientId": substitution.IngredientID,
+				"substituteId": substitution.SubstituteID,
+				"ratio":        substitution.Ratio,
+				"unit":         substitution.Unit,
+			},
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().GetCatalogueIngredientsByIDs(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().CreateSubstitution(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "Fail due to not existing catalogue ingredient",
+			body: gin.H{
+				"ingredientId": substitution.IngredientID,
+				"substituteId": substitution.SubstituteID,
+				"ratio":        substitution.Ratio,
+			},
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().GetCatalogueIngredientsByIDs(gomock.Any(), catalogueIngredientIDs).Times(1).Return(catalogueIngredients[:1], nil)
+				store.EXPECT().CreateSubstitution(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+			},
+		},
+		{
+			name: "Fail due to already existing substitution",
+			body: gin.H{
+				"ingredientId": substitution.IngredientID,
+				"substituteId": substitution.SubstituteID,
+				"ratio":        substitution.Ratio,
+			},
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().GetCatalogueIngredientsByIDs(gomock.Any(), catalogueIngredientIDs).Times(1).Return(catalogueIngredients, nil)
+				store.EXPECT().CreateSubstitution(gomock.Any(), gomock.Any()).Times(1).Return(primitive.NilObjectID, db.ErrDuplicateKey)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mock_db.NewMockDBStore(ctrl)
+			expectActiveSession(store)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, "/api/v1/substitutions/", bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.ID, db.UserRole, time.Minute)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestUnitGetSubstitutionByID(t *testing.T) {
+	user, _ := randomUser(t)
+	substitution, _ := randomSubstitution(t)
+	nonMatchingID := primitive.NewObjectID().Hex()
+
+	testCases := []struct {
+		name          string
+		id            string
+		buildStubs    func(store *mock_db.MockDBStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "Success getting the substitution",
+			id:   substitution.ID,
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().GetSubstitutionByID(gomock.Any(), substitution.ID).Times(1).Return(substitution, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var gotSubstitution db.Substitution
+				err := json.NewDecoder(recorder.Body).Decode(&gotSubstitution)
+				require.NoError(t, err)
+
+				require.Equal(t, substitution, gotSubstitution)
+			},
+		},
+		{
+			name: "Fail with substitution not found",
+			id:   nonMatchingID,
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().GetSubstitutionByID(gomock.Any(), nonMatchingID).Times(1).Return(db.Substitution{}, db.ErrNotFound)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mock_db.NewMockDBStore(ctrl)
+			expectActiveSession(store)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodGet, fmt.Sprintf("/api/v1/substitutions/%s", tc.id), nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.ID, db.UserRole, time.Minute)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestUnitPatchSubstitutionByID(t *testing.T) {
+	user, _ := randomUser(t)
+	admin := randomAdmin(t)
+	substitution, _ := randomSubstitution(t)
+	nonMatchingID := primitive.NewObjectID().Hex()
+
+	testCases := []struct {
+		name          string
+		id            string
+		userID        string
+		role          db.Role
+		body          gin.H
+		buildStubs    func(store *mock_db.MockDBStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "Success patching the substitution as an admin",
+			id:     substitution.ID,
+			userID: admin.ID,
+			role:   db.AdminRole,
+			body:   gin.H{"ratio": 1.5, "notes": "Reduce the liquid"},
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().UpdateSubstitutionByID(gomock.Any(), substitution.ID, db.SubstitutionUpdate{Ratio: 1.5, Notes: "Reduce the liquid"}).Times(1).Return(int64(1), nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:   "Fail due to missing permission",
+			id:     substitution.ID,
+			userID: user.ID,
+			role:   db.UserRole,
+			body:   gin.H{"ratio": 1.5},
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().UpdateSubstitutionByID(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:   "Fail due to empty patch",
+			id:     substitution.ID,
+			userID: admin.ID,
+			role:   db.AdminRole,
+			body:   gin.H{},
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().UpdateSubstitutionByID(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:   "Fail due to negative ratio",
+			id:     substitution.ID,
+			userID: admin.ID,
+			role:   db.AdminRole,
+			body:   gin.H{"ratio": -1},
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().UpdateSubstitutionByID(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:   "Fail with substitution not found",
+			id:     nonMatchingID,
+			userID: admin.ID,
+			role:   db.AdminRole,
+			body:   gin.H{"ratio": 1.5},
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().UpdateSubstitutionByID(gomock.Any(), nonMatchingID, gomock.Any()).Times(1).Return(int64(0), nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mock_db.NewMockDBStore(ctrl)
+			expectActiveSession(store)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPatch, fmt.Sprintf("/api/v1/substitutions/%s", tc.id), bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, tc.userID, tc.role, time.Minute)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestUnitDeleteSubstitutionByID(t *testing.T) {
+	user, _ := randomUser(t)
+	admin := randomAdmin(t)
+	substitution, _ := randomSubstitution(t)
+	nonMatchingID := primitive.NewObjectID().Hex()
+
+	testCases := []struct {
+		name          string
+		id            string
+		userID        string
+		role          db.Role
+		buildStubs    func(store *mock_db.MockDBStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "Success deleting the substitution as an admin",
+			id:     substitution.ID,
+			userID: admin.ID,
+			role:   db.AdminRole,
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().DeleteSubstitutionByID(gomock.Any(), substitution.ID).Times(1).Return(int64(1), nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:   "Fail due to missing permission",
+			id:     substitution.ID,
+			userID: user.ID,
+			role:   db.UserRole,
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().DeleteSubstitutionByID(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:   "Fail with substitution not found",
+			id:     nonMatchingID,
+			userID: admin.ID,
+			role:   db.AdminRole,
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().DeleteSubstitutionByID(gomock.Any(), nonMatchingID).Times(1).Return(int64(0), nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mock_db.NewMockDBStore(ctrl)
+			expectActiveSession(store)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodDelete, fmt.Sprintf("/api/v1/substitutions/%s", tc.id), nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, tc.userID, tc.role, time.Minute)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
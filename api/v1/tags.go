@@ -0,0 +1,30 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// listTags
+//
+// @Summary			List all tags
+// @Description	All tags of the recipes are listed with the number of recipes, which are tagged with them. The most used tags come first.
+// @ID					tags-list-tags
+// @Tags				tags
+// @Accept			json
+// @Produce			json
+// @Param				authorization		header			string							false	"Authorization header for bearer token"
+// @Success			200							{array}			TagCount									"Tags with their usage counts"
+// @Failure			401							{object}		ProblemDetails						"Unauthorized"
+// @Failure 		500							{object}		ProblemDetails						"Internal Server Error"
+// @Router			/tags						[get]
+func (server *Server) listTags(ctx *gin.Context) {
+	tagCounts, err := server.store.GetTagCounts(ctx)
+	if err != nil {
+		newErrorFromDB(err).Send(ctx)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, tagCounts)
+}
@@ -0,0 +1,77 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/PfMartin/wegonice-api/db"
+	mock_db "github.com/PfMartin/wegonice-api/db/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnitListTags(t *testing.T) {
+	user, _ := randomUser(t)
+
+	tagCounts := []db.TagCount{
+		{Name: "quick", RecipeCount: 12},
+		{Name: "sweet", RecipeCount: 4},
+	}
+
+	testCases := []struct {
+		name          string
+		buildStubs    func(store *mock_db.MockDBStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "Success listing the tags",
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().GetTagCounts(gomock.Any()).Times(1).Return(tagCounts, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var gotTagCounts []db.TagCount
+				err := json.NewDecoder(recorder.Body).Decode(&gotTagCounts)
+				require.NoError(t, err)
+
+				require.Equal(t, tagCounts, gotTagCounts)
+			},
+		},
+		{
+			name: "Fail with internal server error",
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().GetTagCounts(gomock.Any()).Times(1).Return(nil, fmt.Errorf("connection lost"))
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mock_db.NewMockDBStore(ctrl)
+			expectActiveSession(store)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodGet, "/api/v1/tags", nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.ID, db.UserRole, time.Minute)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
@@ -2,14 +2,29 @@ package api
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"time"
 
+	"github.com/PfMartin/wegonice-api/conversion"
 	"github.com/PfMartin/wegonice-api/db"
+	"github.com/PfMartin/wegonice-api/token"
 	"github.com/PfMartin/wegonice-api/util"
 	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+func newUserResponse(user db.User) UserResponse {
+	return UserResponse{
+		ID:         user.ID,
+		Email:      user.Email,
+		Role:       user.Role,
+		IsActive:   user.IsActive,
+		CreatedAt:  user.CreatedAt,
+		ModifiedAt: user.ModifiedAt,
+	}
+}
+
 // registerUser
 //
 // @Summary 		Registers a user
@@ -20,8 +35,8 @@ import (
 // @Produce			json
 // @Param				userCredentials 	body				authUserBody				true		"Credentials for the registration"
 // @Success			201
-// @Failure			400								{object}		ErrorBadRequest							"Bad Request"
-// @Failure 		406								{object}		ErrorNotAcceptable					"Not Acceptable"
+// @Failure			400								{object}		ProblemDetails							"Bad Request"
+// @Failure 		409								{object}		ProblemDetails							"Conflict, a user with this email already exists"
 // @Router			/auth/register		[post]
 func (server *Server) registerUser(ctx *gin.Context) {
 	var credentials authUserBody
@@ -41,7 +56,7 @@ func (server *Server) registerUser(ctx *gin.Context) {
 
 	_, err := server.store.CreateUser(c, userToCreate)
 	if err != nil {
-		NewErrorNotAcceptable(err).Send(ctx)
+		newErrorFromDB(err).Send(ctx)
 		return
 	}
 
@@ -58,11 +73,11 @@ func (server *Server) registerUser(ctx *gin.Context) {
 // @Produce			json
 // @Param				userCredentials 	body				authUserBody							true		"Credentials for the login"
 // @Success			200								{object}		loginResponse											"Login response with required tokens"
-// @Failure			400								{object}		ErrorBadRequest										"Bad Request"
-// @Failure			401								{object}		ErrorUnauthorized									"Unauthorized"
-// @Failure			404								{object}		ErrorNotFound											"Not Found"
-// @Failure 		406								{object}		ErrorNotAcceptable								"Not Acceptable"
-// @Failure 		500								{object}		ErrorInternalServerError					"Internal Server Error"
+// @Failure			400								{object}		ProblemDetails										"Bad Request"
+// @Failure			401								{object}		ProblemDetails										"Unauthorized"
+// @Failure			403								{object}		ProblemDetails										"Forbidden, the user has not been activated yet"
+// @Failure			404								{object}		ProblemDetails										"Not Found"
+// @Failure 		500								{object}		ProblemDetails										"Internal Server Error"
 // @Router			/auth/login				[post]
 func (server *Server) loginUser(ctx *gin.Context) {
 	var credentials authUserBody
@@ -75,7 +90,7 @@ func (server *Server) loginUser(ctx *gin.Context) {
 	defer cancel()
 	user, err := server.store.GetUserByEmail(c, credentials.Email)
 	if err != nil {
-		NewErrorNotFound(err).Send(ctx)
+		newErrorFromDB(err).Send(ctx)
 		return
 	}
 
@@ -85,19 +100,28 @@ func (server *Server) loginUser(ctx *gin.Context) {
 		return
 	}
 
-	accessToken, accessPayload, err := server.tokenMaker.CreateToken(user.Email, server.config.accessTokenDuration)
+	if !user.IsActive {
+		NewErrorForbidden(fmt.Errorf("user %s has not been activated by an admin yet", user.Email)).Send(ctx)
+		return
+	}
+
+	// The session ID is created in advance, so both tokens can be bound to the session.
+	sessionID := primitive.NewObjectID().Hex()
+
+	accessToken, accessPayload, err := server.tokenMaker.CreateToken(user.ID, string(user.Role), sessionID, token.AccessToken, server.config.accessTokenDuration)
 	if err != nil {
 		NewErrorInternalServerError(err).Send(ctx)
 		return
 	}
 
-	refreshToken, refreshPayload, err := server.tokenMaker.CreateToken(user.Email, server.config.refreshTokenDuration)
+	refreshToken, refreshPayload, err := server.tokenMaker.CreateToken(user.ID, string(user.Role), sessionID, token.RefreshToken, server.config.refreshTokenDuration)
 	if err != nil {
 		NewErrorInternalServerError(err).Send(ctx)
 		return
 	}
 
-	sessionID, err := server.store.CreateSession(c, db.Session{
+	createdSessionID, err := server.store.CreateSession(c, db.Session{
+		ID:           sessionID,
 		UserID:       user.ID,
 		RefreshToken: refreshToken,
 		UserAgent:    ctx.Request.UserAgent(),
@@ -112,13 +136,564 @@ func (server *Server) loginUser(ctx *gin.Context) {
 	}
 
 	res := loginResponse{
-		SessionID:             sessionID.Hex(),
+		SessionID:             createdSessionID.Hex(),
 		AccessToken:           accessToken,
 		AccessTokenExpiresAt:  accessPayload.ExpiresAt,
 		RefreshToken:          refreshToken,
 		RefreshTokenExpiresAt: refreshPayload.ExpiresAt,
-		UserEmail:             user.ID,
+		UserEmail:             user.Email,
 	}
 
 	ctx.JSON(http.StatusOK, res)
 }
+
+// refreshAccessToken
+//
+// @Summary 		Refreshes the access token
+// @Description A new access token is issued for a valid refresh token of an active session.
+// @ID					auth-refresh-access-token
+// @Tags				auth
+// @Accept			json
+// @Produce			json
+// @Param				refreshData 			body				refreshTokenBody					true		"Session and refresh token returned by the login"
+// @Success			200								{object}		refreshTokenResponse							"New access token"
+// @Failure			400								{object}		ProblemDetails										"Bad Request"
+// @Failure			401								{object}		ProblemDetails										"Unauthorized"
+// @Failure			404								{object}		ProblemDetails										"Not Found"
+// @Failure 		500								{object}		ProblemDetails										"Internal Server Error"
+// @Router			/auth/refresh			[post]
+func (server *Server) refreshAccessToken(ctx *gin.Context) {
+	var body refreshTokenBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		NewErrorBadRequest(err).Send(ctx)
+		return
+	}
+
+	refreshPayload, err := server.tokenMaker.VerifyToken(body.RefreshToken, token.RefreshToken)
+	if err != nil {
+		NewErrorUnauthorized(err).Send(ctx)
+		return
+	}
+
+	c, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	session, err := server.store.GetSessionByID(c, body.SessionID)
+	if err != nil {
+		newErrorFromDB(err).Send(ctx)
+		return
+	}
+
+	if refreshPayload.SessionID != session.ID {
+		NewErrorUnauthorized(fmt.Errorf("refresh token does not belong to session %s", session.ID)).Send(ctx)
+		return
+	}
+
+	if err := checkSession(session); err != nil {
+		NewErrorUnauthorized(err).Send(ctx)
+		return
+	}
+
+	if time.Now().Unix() > session.ExpiresAt {
+		NewErrorUnauthorized(fmt.Errorf("session %s has expired", session.ID)).Send(ctx)
+		return
+	}
+
+	if session.UserID != refreshPayload.UserID {
+		NewErrorUnauthorized(fmt.Errorf("session %s does not belong to the user of the refresh token", session.ID)).Send(ctx)
+		return
+	}
+
+	if session.RefreshToken != body.RefreshToken {
+		NewErrorUnauthorized(fmt.Errorf("refresh token does not match session %s", session.ID)).Send(ctx)
+		return
+	}
+
+	accessToken, accessPayload, err := server.tokenMaker.CreateToken(session.UserID, string(session.User.Role), session.ID, token.AccessToken, server.config.accessTokenDuration)
+	if err != nil {
+		NewErrorInternalServerError(err).Send(ctx)
+		return
+	}
+
+	res := refreshTokenResponse{
+		AccessToken:          accessToken,
+		AccessTokenExpiresAt: accessPayload.ExpiresAt,
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+// logoutUser
+//
+// @Summary 		Logs a user out
+// @Description The given session of the logged in user is revoked, so its access and refresh tokens can no longer be used.
+// @ID					auth-logout-user
+// @Tags				auth
+// @Accept			json
+// @Produce			json
+// @Param				authorization			header			string										false		"Authorization header for bearer token"
+// @Param				logoutData 				body				logoutBody								true		"Session to revoke"
+// @Success			200
+// @Failure			400								{object}		ProblemDetails										"Bad Request"
+// @Failure			401								{object}		ProblemDetails										"Unauthorized"
+// @Failure			403								{object}		ProblemDetails										"Forbidden"
+// @Failure			404								{object}		ProblemDetails										"Not Found"
+// @Failure 		500								{object}		ProblemDetails										"Internal Server Error"
+// @Router			/auth/logout			[post]
+func (server *Server) logoutUser(ctx *gin.Context) {
+	var body logoutBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		NewErrorBadRequest(err).Send(ctx)
+		return
+	}
+
+	payload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	session, err := server.store.GetSessionByID(ctx, body.SessionID)
+	if err != nil {
+		newErrorFromDB(err).Send(ctx)
+		return
+	}
+
+	if session.UserID != payload.UserID {
+		NewErrorForbidden(fmt.Errorf("session %s does not belong to the logged in user", session.ID)).Send(ctx)
+		return
+	}
+
+	if _, err = server.store.BlockSessionByID(ctx, session.ID); err != nil {
+		newErrorFromDB(err).Send(ctx)
+		return
+	}
+
+	ctx.Status(http.StatusOK)
+}
+
+// logoutUserEverywhere
+//
+// @Summary 		Logs a user out everywhere
+// @Description All sessions of the logged in user are revoked.
+// @ID					auth-logout-user-everywhere
+// @Tags				auth
+// @Accept			json
+// @Produce			json
+// @Param				authorization			header			string										false		"Authorization header for bearer token"
+// @Success			200
+// @Failure			401								{object}		ProblemDetails										"Unauthorized"
+// @Failure 		500								{object}		ProblemDetails										"Internal Server Error"
+// @Router			/auth/logout-all	[post]
+func (server *Server) logoutUserEverywhere(ctx *gin.Context) {
+	payload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	if _, err := server.store.BlockSessionsByUserID(ctx, payload.UserID); err != nil {
+		newErrorFromDB(err).Send(ctx)
+		return
+	}
+
+	ctx.Status(http.StatusOK)
+}
+
+// listPendingUsers
+//
+// @Summary			List all pending registrations
+// @Description	All users, which have registered but have not been activated yet, are listed in a paginated manner. They are sorted by their registration.
+// @ID					admin-list-pending-users
+// @Tags				admin
+// @Accept			json
+// @Produce			json
+// @Param				authorization					header			string							false	"Authorization header for bearer token"
+// @Param				page_id								query 			int									false	"Offset for the pagination, required without cursor"
+// @Param				page_size							query 			int									true	"Number of elements in one page"
+// @Param				cursor								query 			string							false	"Cursor of the next page, which was returned with the previous page"
+// @Success			200										{object}		UserListResponse					"Page of pending users matching the given pagination parameters"
+// @Failure			400										{object}		ProblemDetails						"Bad Request"
+// @Failure			401										{object}		ProblemDetails						"Unauthorized"
+// @Failure			403										{object}		ProblemDetails						"Forbidden"
+// @Failure 		500										{object}		ProblemDetails						"Internal Server Error"
+// @Router			/admin/users/pending	[get]
+func (server *Server) listPendingUsers(ctx *gin.Context) {
+	var pagination db.Pagination
+	if err := ctx.ShouldBindQuery(&pagination); err != nil {
+		NewErrorBadRequest(err).Send(ctx)
+		return
+	}
+
+	users, pageInfo, err := server.store.GetPendingUsers(ctx, pagination)
+	if err != nil {
+		newErrorFromDB(err).Send(ctx)
+		return
+	}
+
+	res := []UserResponse{}
+	for _, user := range users {
+		res = append(res, newUserResponse(user))
+	}
+
+	ctx.JSON(http.StatusOK, newListResponse(ctx, res, pagination, pageInfo))
+}
+
+// approveUser
+//
+// @Summary			Approve a pending registration
+// @Description	The pending user, which matches the ID, is activated and can log in afterwards
+// @ID					admin-approve-user
+// @Tags				admin
+// @Accept			json
+// @Produce			json
+// @Param				authorization							header			string							false	"Authorization header for bearer token"
+// @Param				id												path 				string							true	"ID of the user to approve"
+// @Success			200
+// @Failure			400												{object}		ProblemDetails						"Bad Request"
+// @Failure			401												{object}		ProblemDetails						"Unauthorized"
+// @Failure			403												{object}		ProblemDetails						"Forbidden"
+// @Failure			404												{object}		ProblemDetails						"Not Found"
+// @Failure 		500												{object}		ProblemDetails						"Internal Server Error"
+// @Router			/admin/users/{id}/approve	[post]
+func (server *Server) approveUser(ctx *gin.Context) {
+	var uriParam getByIDRequest
+	if err := ctx.ShouldBindUri(&uriParam); err != nil {
+		NewErrorBadRequest(err).Send(ctx)
+		return
+	}
+
+	user, err := server.store.GetUserByID(ctx, uriParam.ID)
+	if err != nil {
+		newErrorFromDB(err).Send(ctx)
+		return
+	}
+
+	if user.IsActive {
+		NewErrorBadRequest(fmt.Errorf("user %s is already active", user.ID)).Send(ctx)
+		return
+	}
+
+	if _, err = server.store.ActivateUserByID(ctx, user.ID); err != nil {
+		newErrorFromDB(err).Send(ctx)
+		return
+	}
+
+	ctx.Status(http.StatusOK)
+}
+
+// rejectUser
+//
+// @Summary			Reject a pending registration
+// @Description	The pending user, which matches the ID, is deleted. Users, which are already active, cannot be rejected.
+// @ID					admin-reject-user
+// @Tags				admin
+// @Accept			json
+// @Produce			json
+// @Param				authorization							header			string							false	"Authorization header for bearer token"
+// @Param				id												path 				string							true	"ID of the user to reject"
+// @Success			200
+// @Failure			400												{object}		ProblemDetails						"Bad Request"
+// @Failure			401												{object}		ProblemDetails						"Unauthorized"
+// @Failure			403												{object}		ProblemDetails						"Forbidden"
+// @Failure			404												{object}		ProblemDetails						"Not Found"
+// @Failure 		500												{object}		ProblemDetails						"Internal Server Error"
+// @Router			/admin/users/{id}/reject	[post]
+func (server *Server) rejectUser(ctx *gin.Context) {
+	var uriParam getByIDRequest
+	if err := ctx.ShouldBindUri(&uriParam); err != nil {
+		NewErrorBadRequest(err).Send(ctx)
+		return
+	}
+
+	user, err := server.store.GetUserByID(ctx, uriParam.ID)
+	if err != nil {
+		newErrorFromDB(err).Send(ctx)
+		return
+	}
+
+	if user.IsActive {
+		NewErrorBadRequest(fmt.Errorf("user %s is already active and cannot be rejected", user.ID)).Send(ctx)
+		return
+	}
+
+	if _, err = server.store.DeleteUserByID(ctx, user.ID); err != nil {
+		newErrorFromDB(err).Send(ctx)
+		return
+	}
+
+	ctx.Status(http.StatusOK)
+}
+
+// listUsers
+//
+// @Summary			List all users
+// @Description	All users are listed in a paginated manner. They are sorted by email, if no sorting is provided. Only admins are allowed to list users.
+// @ID					users-list-users
+// @Tags				users
+// @Accept			json
+// @Produce			json
+// @Param				authorization	header			string							false	"Authorization header for bearer token"
+// @Param				page_id				query 			int									false	"Offset for the pagination, required without cursor"
+// @Param				page_size			query 			int									true	"Number of elements in one page"
+// @Param				cursor				query 			string							false	"Cursor of the next page, which was returned with the previous page"
+// @Param				sort					query 			[]string						false	"Keys to sort by"	collectionFormat(multi)	Enums(email, role, createdAt, modifiedAt)
+// @Param				order					query 			[]string						false	"Sort order per key"	collectionFormat(multi)	Enums(asc, desc)
+// @Success			200						{object}		UserListResponse					"Page of users matching the given pagination parameters"
+// @Failure			400						{object}		ProblemDetails						"Bad Request"
+// @Failure			401						{object}		ProblemDetails						"Unauthorized"
+// @Failure			403						{object}		ProblemDetails						"Forbidden"
+// @Failure 		500						{object}		ProblemDetails						"Internal Server Error"
+// @Router			/users				[get]
+func (server *Server) listUsers(ctx *gin.Context) {
+	var pagination db.Pagination
+	if err := ctx.ShouldBindQuery(&pagination); err != nil {
+		NewErrorBadRequest(err).Send(ctx)
+		return
+	}
+
+	var sorting db.Sorting
+	if err := ctx.ShouldBindQuery(&sorting); err != nil {
+		NewErrorBadRequest(err).Send(ctx)
+		return
+	}
+
+	users, pageInfo, err := server.store.GetAllUsers(ctx, pagination, sorting)
+	if err != nil {
+		newErrorFromDB(err).Send(ctx)
+		return
+	}
+
+	res := []UserResponse{}
+	for _, user := range users {
+		res = append(res, newUserResponse(user))
+	}
+
+	ctx.JSON(http.StatusOK, newListResponse(ctx, res, pagination, pageInfo))
+}
+
+// getCurrentUser
+//
+// @Summary			Get the logged in user
+// @Description	The user, which belongs to the access token, is returned
+// @ID					users-get-current-user
+// @Tags				users
+// @Accept			json
+// @Produce			json
+// @Param				authorization		header			string							false	"Authorization header for bearer token"
+// @Success			200							{object}		UserResponse							"Logged in user"
+// @Failure			401							{object}		ProblemDetails						"Unauthorized"
+// @Failure			404							{object}		ProblemDetails						"Not Found"
+// @Router			/users/me				[get]
+func (server *Server) getCurrentUser(ctx *gin.Context) {
+	payload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	user, err := server.store.GetUserByID(ctx, payload.UserID)
+	if err != nil {
+		newErrorFromDB(err).Send(ctx)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newUserResponse(user))
+}
+
+// getUserByID
+//
+// @Summary			Get one user by ID
+// @Description	One user, which matches the ID, is returned. Users can only get themselves unless they are admins.
+// @ID					users-get-user-by-id
+// @Tags				users
+// @Accept			json
+// @Produce			json
+// @Param				authorization		header			string							false	"Authorization header for bearer token"
+// @Param				id							path 				string							true	"ID of the desired user"
+// @Success			200							{object}		UserResponse							"User that matches the ID"
+// @Failure			400							{object}		ProblemDetails						"Bad Request"
+// @Failure			401							{object}		ProblemDetails						"Unauthorized"
+// @Failure			403							{object}		ProblemDetails						"Forbidden"
+// @Failure			404							{object}		ProblemDetails						"Not Found"
+// @Router			/users/{id}			[get]
+func (server *Server) getUserByID(ctx *gin.Context) {
+	var uriParam getByIDRequest
+	if err := ctx.ShouldBindUri(&uriParam); err != nil {
+		NewErrorBadRequest(err).Send(ctx)
+		return
+	}
+
+	payload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if !isOwnerOrPermitted(payload, uriParam.ID, permissionManageUsers) {
+		NewErrorForbidden(fmt.Errorf("not allowed to access user with ID: %s", uriParam.ID)).Send(ctx)
+		return
+	}
+
+	user, err := server.store.GetUserByID(ctx, uriParam.ID)
+	if err != nil {
+		newErrorFromDB(err).Send(ctx)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newUserResponse(user))
+}
+
+// listUserRecipes
+//
+// @Summary			List all recipes of one user
+// @Description	All recipes, which were created by the user matching the ID, are listed in a paginated manner. They are sorted by name, if no sorting is provided.
+// @ID					users-list-user-recipes
+// @Tags				users
+// @Accept			json
+// @Produce			json
+// @Param				authorization				header			string							false	"Authorization header for bearer token"
+// @Param				id									path 				string							true	"ID of the desired user"
+// @Param				page_id							query 			int									false	"Offset for the pagination, required without cursor"
+// @Param				page_size						query 			int									true	"Number of elements in one page"
+// @Param				cursor							query 			string							false	"Cursor of the next page, which was returned with the previous page"
+// @Param				sort								query 			[]string						false	"Keys to sort by"	collectionFormat(multi)	Enums(name, timeM, category, averageRating, ratingCount, createdAt, modifiedAt)
+// @Param				order								query 			[]string						false	"Sort order per key"	collectionFormat(multi)	Enums(asc, desc)
+// @Param				units								query 			string							false	"Unit system to convert the ingredient amounts to"	Enums(metric, imperial)
+// @Success			200									{object}		RecipeListResponse				"Page of recipes of the user"
+// @Failure			400									{object}		ProblemDetails						"Bad Request"
+// @Failure			401									{object}		ProblemDetails						"Unauthorized"
+// @Failure			404									{object}		ProblemDetails						"Not Found"
+// @Failure 		500									{object}		ProblemDetails						"Internal Server Error"
+// @Router			/users/{id}/recipes	[get]
+func (server *Server) listUserRecipes(ctx *gin.Context) {
+	var uriParam getByIDRequest
+	if err := ctx.ShouldBindUri(&uriParam); err != nil {
+		NewErrorBadRequest(err).Send(ctx)
+		return
+	}
+
+	var pagination db.Pagination
+	if err := ctx.ShouldBindQuery(&pagination); err != nil {
+		NewErrorBadRequest(err).Send(ctx)
+		return
+	}
+
+	var sorting db.Sorting
+	if err := ctx.ShouldBindQuery(&sorting); err != nil {
+		NewErrorBadRequest(err).Send(ctx)
+		return
+	}
+
+	var units unitsQuery
+	if err := ctx.ShouldBindQuery(&units); err != nil {
+		NewErrorBadRequest(err).Send(ctx)
+		return
+	}
+
+	if _, err := server.store.GetUserByID(ctx, uriParam.ID); err != nil {
+		newErrorFromDB(err).Send(ctx)
+		return
+	}
+
+	recipes, pageInfo, err := server.store.GetRecipesByUserID(ctx, uriParam.ID, pagination, sorting)
+	if err != nil {
+		newErrorFromDB(err).Send(ctx)
+		return
+	}
+
+	recipes = conversion.ConvertRecipes(recipes, units.Units)
+
+	ctx.JSON(http.StatusOK, newListResponse(ctx, recipes, pagination, pageInfo))
+}
+
+// patchUserByID
+//
+// @Summary			Patch one user by ID
+// @Description	The email address or the password of the user, which matches the ID, is modified. Users can only patch themselves unless they are admins.
+// @ID					users-patch-user-by-id
+// @Tags				users
+// @Accept			json
+// @Produce			json
+// @Param				authorization		header			string							false	"Authorization header for bearer token"
+// @Param				id							path 				string							true	"ID of the desired user to patch"
+// @Param				data						body 				userUpdateBody			true	"Patch for modifying the user"
+// @Success			200
+// @Failure			400							{object}		ProblemDetails						"Bad Request"
+// @Failure			401							{object}		ProblemDetails						"Unauthorized"
+// @Failure			403							{object}		ProblemDetails						"Forbidden"
+// @Failure			404							{object}		ProblemDetails						"Not Found"
+// @Failure			409							{object}		ProblemDetails						"Conflict, a user with this email already exists"
+// @Failure 		500							{object}		ProblemDetails						"Internal Server Error"
+// @Router			/users/{id}			[patch]
+func (server *Server) patchUserByID(ctx *gin.Context) {
+	var uriParam getByIDRequest
+	if err := ctx.ShouldBindUri(&uriParam); err != nil {
+		NewErrorBadRequest(err).Send(ctx)
+		return
+	}
+
+	var userPatch userUpdateBody
+	if err := ctx.ShouldBindJSON(&userPatch); err != nil {
+		NewErrorBadRequest(err).Send(ctx)
+		return
+	}
+
+	if userPatch.Email == "" && userPatch.Password == "" {
+		NewErrorBadRequest(fmt.Errorf("missing user patch")).Send(ctx)
+		return
+	}
+
+	payload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if !isOwnerOrPermitted(payload, uriParam.ID, permissionManageUsers) {
+		NewErrorForbidden(fmt.Errorf("not allowed to modify user with ID: %s", uriParam.ID)).Send(ctx)
+		return
+	}
+
+	if _, err := server.store.GetUserByID(ctx, uriParam.ID); err != nil {
+		newErrorFromDB(err).Send(ctx)
+		return
+	}
+
+	modifiedCount, err := server.store.UpdateUserByID(ctx, uriParam.ID, db.User{
+		Email:    userPatch.Email,
+		Password: userPatch.Password,
+	})
+	if err != nil {
+		newErrorFromDB(err).Send(ctx)
+		return
+	}
+
+	if modifiedCount < 1 {
+		NewErrorNotFound(fmt.Errorf("could not find user with ID: %s", uriParam.ID)).Send(ctx)
+		return
+	}
+
+	ctx.Status(http.StatusOK)
+}
+
+// deleteUserByID
+//
+// @Summary			Delete one user by ID
+// @Description	One user, which matches the ID, is deleted together with all of its sessions. Users can only delete themselves unless they are admins.
+// @ID					users-delete-user-by-id
+// @Tags				users
+// @Accept			json
+// @Produce			json
+// @Param				authorization		header			string							false	"Authorization header for bearer token"
+// @Param				id							path 				string							true	"ID of the desired user to delete"
+// @Success			200
+// @Failure			400							{object}		ProblemDetails						"Bad Request"
+// @Failure			401							{object}		ProblemDetails						"Unauthorized"
+// @Failure			403							{object}		ProblemDetails						"Forbidden"
+// @Failure			404							{object}		ProblemDetails						"Not Found"
+// @Failure			409							{object}		ProblemDetails						"Conflict, the user is still referenced by recipes, authors or reviews"
+// @Failure 		500							{object}		ProblemDetails						"Internal Server Error"
+// @Router			/users/{id}			[delete]
+func (server *Server) deleteUserByID(ctx *gin.Context) {
+	var uriParam getByIDRequest
+	if err := ctx.ShouldBindUri(&uriParam); err != nil {
+		NewErrorBadRequest(err).Send(ctx)
+		return
+	}
+
+	payload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if !isOwnerOrPermitted(payload, uriParam.ID, permissionManageUsers) {
+		NewErrorForbidden(fmt.Errorf("not allowed to delete user with ID: %s", uriParam.ID)).Send(ctx)
+		return
+	}
+
+	if _, err := server.store.GetUserByID(ctx, uriParam.ID); err != nil {
+		newErrorFromDB(err).Send(ctx)
+		return
+	}
+
+	if _, err := server.store.DeleteUserByID(ctx, uriParam.ID); err != nil {
+		newErrorFromDB(err).Send(ctx)
+		return
+	}
+
+	ctx.Status(http.StatusOK)
+}
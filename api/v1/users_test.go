@@ -6,9 +6,11 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/PfMartin/wegonice-api/db"
 	mock_db "github.com/PfMartin/wegonice-api/db/mock"
+	"github.com/PfMartin/wegonice-api/token"
 	"github.com/PfMartin/wegonice-api/util"
 	"github.com/gin-gonic/gin"
 	"github.com/golang/mock/gomock"
@@ -30,6 +32,8 @@ func randomUser(t *testing.T) (db.User, string) {
 		ID:           primitive.NewObjectID().Hex(),
 		Email:        util.RandomEmail(),
 		PasswordHash: hashedPassword,
+		Role:         db.UserRole,
+		IsActive:     true,
 	}
 
 	return user, password
@@ -72,10 +76,10 @@ func TestUnitRegisterUser(t *testing.T) {
 				"password": password,
 			},
 			buildStubs: func(store *mock_db.MockDBStore) {
-				store.EXPECT().CreateUser(gomock.Any(), gomock.Any()).Times(1).Return(primitive.NewObjectID(), fmt.Errorf("Duplicate user error"))
+				store.EXPECT().CreateUser(gomock.Any(), gomock.Any()).Times(1).Return(primitive.NewObjectID(), db.ErrDuplicateKey)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusNotAcceptable, recorder.Code)
+				require.Equal(t, http.StatusConflict, recorder.Code)
 			},
 		},
 		{
@@ -206,6 +210,23 @@ func TestUnitLoginUser(t *testing.T) {
 				require.Equal(t, http.StatusUnauthorized, recorder.Code)
 			},
 		},
+		{
+			name: "Fails due to inactive user",
+			body: gin.H{
+				"email":    user.Email,
+				"password": password,
+			},
+			buildStubs: func(store *mock_db.MockDBStore) {
+				inactiveUser := user
+				inactiveUser.IsActive = false
+
+				store.EXPECT().GetUserByEmail(gomock.Any(), user.Email).Times(1).Return(inactiveUser, nil)
+				store.EXPECT().CreateSession(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -232,3 +253,1245 @@ func TestUnitLoginUser(t *testing.T) {
 		})
 	}
 }
+
+func TestUnitRefreshAccessToken(t *testing.T) {
+	user, _ := randomUser(t)
+	sessionID := primitive.NewObjectID().Hex()
+
+	randomSession := func(refreshToken string) db.Session {
+		return db.Session{
+			ID:           sessionID,
+			UserID:       user.ID,
+			User:         db.User{ID: user.ID},
+			RefreshToken: refreshToken,
+			IsBlocked:    false,
+			ExpiresAt:    time.Now().Add(time.Minute).Unix(),
+		}
+	}
+
+	testCases := []struct {
+		name                 string
+		refreshTokenDuration time.Duration
+		tokenType            token.TokenType
+		buildBody            func(refreshToken string) gin.H
+		buildStubs           func(store *mock_db.MockDBStore, refreshToken string)
+		checkResponse        func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:                 "Success",
+			refreshTokenDuration: time.Minute,
+			buildBody: func(refreshToken string) gin.H {
+				return gin.H{"sessionId": sessionID, "refreshToken": refreshToken}
+			},
+			buildStubs: func(store *mock_db.MockDBStore, refreshToken string) {
+				store.EXPECT().GetSessionByID(gomock.Any(), sessionID).Times(1).Return(randomSession(refreshToken), nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var res refreshTokenResponse
+				err := json.NewDecoder(recorder.Body).Decode(&res)
+				require.NoError(t, err)
+				require.NotEmpty(t, res.AccessToken)
+				require.NotZero(t, res.AccessTokenExpiresAt)
+			},
+		},
+		{
+			name:                 "Fails due to missing session ID",
+			refreshTokenDuration: time.Minute,
+			buildBody: func(refreshToken string) gin.H {
+				return gin.H{"refreshToken": refreshToken}
+			},
+			buildStubs: func(store *mock_db.MockDBStore, refreshToken string) {
+				store.EXPECT().GetSessionByID(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:                 "Fails due to expired refresh token",
+			refreshTokenDuration: -time.Minute,
+			buildBody: func(refreshToken string) gin.H {
+				return gin.H{"sessionId": sessionID, "refreshToken": refreshToken}
+			},
+			buildStubs: func(store *mock_db.MockDBStore, refreshToken string) {
+				store.EXPECT().GetSessionByID(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:                 "Fails due to access token instead of refresh token",
+			refreshTokenDuration: time.Minute,
+			tokenType:            token.AccessToken,
+			buildBody: func(refreshToken string) gin.H {
+				return gin.H{"sessionId": sessionID, "refreshToken": refreshToken}
+			},
+			buildStubs: func(store *mock_db.MockDBStore, refreshToken string) {
+				store.EXPECT().GetSessionByID(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:                 "Fails due to session not found",
+			refreshTokenDuration: time.Minute,
+			buildBody: func(refreshToken string) gin.H {
+				return gin.H{"sessionId": sessionID, "refreshToken": refreshToken}
+			},
+			buildStubs: func(store *mock_db.MockDBStore, refreshToken string) {
+				store.EXPECT().GetSessionByID(gomock.Any(), sessionID).Times(1).Return(db.Session{}, db.ErrNotFound)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:                 "Fails due to blocked session",
+			refreshTokenDuration: time.Minute,
+			buildBody: func(refreshToken string) gin.H {
+				return gin.H{"sessionId": sessionID, "refreshToken": refreshToken}
+			},
+			buildStubs: func(store *mock_db.MockDBStore, refreshToken string) {
+				session := randomSession(refreshToken)
+				session.IsBlocked = true
+				store.EXPECT().GetSessionByID(gomock.Any(), sessionID).Times(1).Return(session, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:                 "Fails due to expired session",
+			refreshTokenDuration: time.Minute,
+			buildBody: func(refreshToken string) gin.H {
+				return gin.H{"sessionId": sessionID, "refreshToken": refreshToken}
+			},
+			buildStubs: func(store *mock_db.MockDBStore, refreshToken string) {
+				session := randomSession(refreshToken)
+				session.ExpiresAt = time.Now().Add(-time.Minute).Unix()
+				store.EXPECT().GetSessionByID(gomock.Any(), sessionID).Times(1).Return(session, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:                 "Fails due to refresh token of a different session",
+			refreshTokenDuration: time.Minute,
+			buildBody: func(refreshToken string) gin.H {
+				return gin.H{"sessionId": sessionID, "refreshToken": refreshToken}
+			},
+			buildStubs: func(store *mock_db.MockDBStore, refreshToken string) {
+				session := randomSession(refreshToken)
+				session.ID = primitive.NewObjectID().Hex()
+				store.EXPECT().GetSessionByID(gomock.Any(), sessionID).Times(1).Return(session, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:                 "Fails due to deleted user of the session",
+			refreshTokenDuration: time.Minute,
+			buildBody: func(refreshToken string) gin.H {
+				return gin.H{"sessionId": sessionID, "refreshToken": refreshToken}
+			},
+			buildStubs: func(store *mock_db.MockDBStore, refreshToken string) {
+				session := randomSession(refreshToken)
+				session.User = db.User{}
+				store.EXPECT().GetSessionByID(gomock.Any(), sessionID).Times(1).Return(session, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:                 "Fails due to session of a different user",
+			refreshTokenDuration: time.Minute,
+			buildBody: func(refreshToken string) gin.H {
+				return gin.H{"sessionId": sessionID, "refreshToken": refreshToken}
+			},
+			buildStubs: func(store *mock_db.MockDBStore, refreshToken string) {
+				session := randomSession(refreshToken)
+				session.UserID = primitive.NewObjectID().Hex()
+				store.EXPECT().GetSessionByID(gomock.Any(), sessionID).Times(1).Return(session, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:                 "Fails due to refresh token not matching the session",
+			refreshTokenDuration: time.Minute,
+			buildBody: func(refreshToken string) gin.H {
+				return gin.H{"sessionId": sessionID, "refreshToken": refreshToken}
+			},
+			buildStubs: func(store *mock_db.MockDBStore, refreshToken string) {
+				store.EXPECT().GetSessionByID(gomock.Any(), sessionID).Times(1).Return(randomSession("other-refresh-token"), nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mock_db.NewMockDBStore(ctrl)
+			server := newTestServer(t, store)
+
+			tokenType := tc.tokenType
+			if tokenType == "" {
+				tokenType = token.RefreshToken
+			}
+
+			refreshToken, _, err := server.tokenMaker.CreateToken(user.ID, string(db.UserRole), sessionID, tokenType, tc.refreshTokenDuration)
+			require.NoError(t, err)
+
+			tc.buildStubs(store, refreshToken)
+
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.buildBody(refreshToken))
+			require.NoError(t, err)
+
+			url := "/api/v1/auth/refresh"
+			request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestUnitLogoutUser(t *testing.T) {
+	user, _ := randomUser(t)
+	sessionID := primitive.NewObjectID().Hex()
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mock_db.MockDBStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "Success",
+			body: gin.H{"sessionId": sessionID},
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().GetSessionByID(gomock.Any(), sessionID).Times(1).Return(db.Session{ID: sessionID, UserID: user.ID}, nil)
+				store.EXPECT().BlockSessionByID(gomock.Any(), sessionID).Times(1).Return(int64(1), nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "Fails due to missing session ID",
+			body: gin.H{},
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().GetSessionByID(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().BlockSessionByID(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "Fails due to session not found",
+			body: gin.H{"sessionId": sessionID},
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().GetSessionByID(gomock.Any(), sessionID).Times(1).Return(db.Session{}, db.ErrNotFound)
+				store.EXPECT().BlockSessionByID(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "Fails due to session of a different user",
+			body: gin.H{"sessionId": sessionID},
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().GetSessionByID(gomock.Any(), sessionID).Times(1).Return(db.Session{ID: sessionID, UserID: primitive.NewObjectID().Hex()}, nil)
+				store.EXPECT().BlockSessionByID(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "Fails due to error while blocking the session",
+			body: gin.H{"sessionId": sessionID},
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().GetSessionByID(gomock.Any(), sessionID).Times(1).Return(db.Session{ID: sessionID, UserID: user.ID}, nil)
+				store.EXPECT().BlockSessionByID(gomock.Any(), sessionID).Times(1).Return(int64(0), fmt.Errorf("failed to block session"))
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mock_db.NewMockDBStore(ctrl)
+			expectActiveSession(store)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := "/api/v1/auth/logout"
+			request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.ID, db.UserRole, time.Minute)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestUnitLogoutUserEverywhere(t *testing.T) {
+	user, _ := randomUser(t)
+
+	testCases := []struct {
+		name          string
+		buildStubs    func(store *mock_db.MockDBStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "Success",
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().BlockSessionsByUserID(gomock.Any(), user.ID).Times(1).Return(int64(3), nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "Fails due to error while blocking the sessions",
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().BlockSessionsByUserID(gomock.Any(), user.ID).Times(1).Return(int64(0), fmt.Errorf("failed to block sessions"))
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mock_db.NewMockDBStore(ctrl)
+			expectActiveSession(store)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := "/api/v1/auth/logout-all"
+			request, err := http.NewRequest(http.MethodPost, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.ID, db.UserRole, time.Minute)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestUnitListPendingUsers(t *testing.T) {
+	admin := randomAdmin(t)
+	user, _ := randomUser(t)
+
+	var pendingUsers []db.User
+	for i := 0; i < 5; i++ {
+		pendingUser, _ := randomUser(t)
+		pendingUser.IsActive = false
+		pendingUsers = append(pendingUsers, pendingUser)
+	}
+
+	testCases := []struct {
+		name          string
+		caller        db.User
+		query         string
+		buildStubs    func(store *mock_db.MockDBStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "Success",
+			caller: admin,
+			query:  "?page_id=1&page_size=5",
+			buildStubs: func(store *mock_db.MockDBStore) {
+				pagination := db.Pagination{
+					PageID:   1,
+					PageSize: 5,
+				}
+
+				store.EXPECT().GetPendingUsers(gomock.Any(), pagination).Times(1).Return(pendingUsers, db.PageInfo{TotalCount: 7, NextCursor: "next-cursor"}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var gotPage UserListResponse
+				err := json.NewDecoder(recorder.Body).Decode(&gotPage)
+				require.NoError(t, err)
+
+				gotUsers := gotPage.Items
+				require.Equal(t, int64(7), gotPage.TotalCount)
+				require.True(t, gotPage.HasNext)
+				require.Equal(t, "/api/v1/admin/users/pending?page_id=2&page_size=5", gotPage.Links.Next)
+				require.Equal(t, len(pendingUsers), len(gotUsers))
+				for i, pendingUser := range pendingUsers {
+					requireUserComparison(t, pendingUser, gotUsers[i])
+					require.False(t, gotUsers[i].IsActive)
+				}
+
+				require.NotContains(t, recorder.Body.String(), "passwordHash")
+			},
+		},
+		{
+			name:   "Fails due to missing pagination",
+			caller: admin,
+			query:  "",
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().GetPendingUsers(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:   "Success with cursor",
+			caller: admin,
+			query:  "?cursor=next-cursor&page_size=5",
+			buildStubs: func(store *mock_db.MockDBStore) {
+				pagination := db.Pagination{
+					PageSize: 5,
+					Cursor:   "next-cursor",
+				}
+
+				store.EXPECT().GetPendingUsers(gomock.Any(), pagination).Times(1).Return(pendingUsers[:2], db.PageInfo{TotalCount: 7}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var gotPage UserListResponse
+				err := json.NewDecoder(recorder.Body).Decode(&gotPage)
+				require.NoError(t, err)
+
+				require.Len(t, gotPage.Items, 2)
+				require.False(t, gotPage.HasNext)
+				require.Empty(t, gotPage.Links.Next)
+			},
+		},
+		{
+			name:   "Fails due to invalid cursor",
+			caller: admin,
+			query:  "?cursor=invalid&page_size=5",
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().GetPendingUsers(gomock.Any(), gomock.Any()).Times(1).Return(nil, db.PageInfo{}, db.ErrInvalidCursor)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:   "Fails due to caller not being an admin",
+			caller: user,
+			query:  "?page_id=1&page_size=5",
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().GetPendingUsers(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mock_db.NewMockDBStore(ctrl)
+			expectActiveSession(store)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/api/v1/admin/users/pending%s", tc.query)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, tc.caller.ID, tc.caller.Role, time.Minute)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestUnitApproveUser(t *testing.T) {
+	admin := randomAdmin(t)
+	activeUser, _ := randomUser(t)
+	pendingUser, _ := randomUser(t)
+	pendingUser.IsActive = false
+
+	testCases := []struct {
+		name          string
+		caller        db.User
+		id            string
+		buildStubs    func(store *mock_db.MockDBStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "Success",
+			caller: admin,
+			id:     pendingUser.ID,
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().GetUserByID(gomock.Any(), pendingUser.ID).Times(1).Return(pendingUser, nil)
+				store.EXPECT().ActivateUserByID(gomock.Any(), pendingUser.ID).Times(1).Return(int64(1), nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:   "Fails due to user already being active",
+			caller: admin,
+			id:     activeUser.ID,
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().GetUserByID(gomock.Any(), activeUser.ID).Times(1).Return(activeUser, nil)
+				store.EXPECT().ActivateUserByID(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:   "Fails due to user not found",
+			caller: admin,
+			id:     pendingUser.ID,
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().GetUserByID(gomock.Any(), pendingUser.ID).Times(1).Return(db.User{}, db.ErrNotFound)
+				store.EXPECT().ActivateUserByID(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:   "Fails due to caller not being an admin",
+			caller: activeUser,
+			id:     pendingUser.ID,
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().GetUserByID(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().ActivateUserByID(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mock_db.NewMockDBStore(ctrl)
+			expectActiveSession(store)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/api/v1/admin/users/%s/approve", tc.id)
+			request, err := http.NewRequest(http.MethodPost, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, tc.caller.ID, tc.caller.Role, time.Minute)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestUnitRejectUser(t *testing.T) {
+	admin := randomAdmin(t)
+	activeUser, _ := randomUser(t)
+	pendingUser, _ := randomUser(t)
+	pendingUser.IsActive = false
+
+	testCases := []struct {
+		name          string
+		caller        db.User
+		id            string
+		buildStubs    func(store *mock_db.MockDBStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "Success",
+			caller: admin,
+			id:     pendingUser.ID,
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().GetUserByID(gomock.Any(), pendingUser.ID).Times(1).Return(pendingUser, nil)
+				store.EXPECT().DeleteUserByID(gomock.Any(), pendingUser.ID).Times(1).Return(int64(1), nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:   "Fails due to user already being active",
+			caller: admin,
+			id:     activeUser.ID,
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().GetUserByID(gomock.Any(), activeUser.ID).Times(1).Return(activeUser, nil)
+				store.EXPECT().DeleteUserByID(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:   "Fails due to caller not being an admin",
+			caller: activeUser,
+			id:     pendingUser.ID,
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().GetUserByID(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().DeleteUserByID(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mock_db.NewMockDBStore(ctrl)
+			expectActiveSession(store)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/api/v1/admin/users/%s/reject", tc.id)
+			request, err := http.NewRequest(http.MethodPost, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, tc.caller.ID, tc.caller.Role, time.Minute)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestUnitListUsers(t *testing.T) {
+	admin := randomAdmin(t)
+	user, _ := randomUser(t)
+
+	var users []db.User
+	for i := 0; i < 10; i++ {
+		u, _ := randomUser(t)
+		users = append(users, u)
+	}
+
+	testCases := []struct {
+		name          string
+		caller        db.User
+		query         string
+		buildStubs    func(store *mock_db.MockDBStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "Success with pagination from 1 to 10",
+			caller: admin,
+			query:  "?page_id=1&page_size=10",
+			buildStubs: func(store *mock_db.MockDBStore) {
+				pagination := db.Pagination{
+					PageID:   1,
+					PageSize: 10,
+				}
+
+				store.EXPECT().GetAllUsers(gomock.Any(), pagination, db.Sorting{}).Times(1).Return(users, db.PageInfo{TotalCount: 10}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var gotPage UserListResponse
+				err := json.NewDecoder(recorder.Body).Decode(&gotPage)
+				require.NoError(t, err)
+
+				gotUsers := gotPage.Items
+				require.Equal(t, 10, len(gotUsers))
+				require.Equal(t, int64(10), gotPage.TotalCount)
+				require.False(t, gotPage.HasNext)
+				for i, expectedUser := range users {
+					requireUserComparison(t, expectedUser, gotUsers[i])
+				}
+
+				require.NotContains(t, recorder.Body.String(), "passwordHash")
+			},
+		},
+		{
+			name:   "Success with sorting",
+			caller: admin,
+			query:  "?page_id=1&page_size=10&sort=createdAt&order=desc",
+			buildStubs: func(store *mock_db.MockDBStore) {
+				pagination := db.Pagination{
+					PageID:   1,
+					PageSize: 10,
+				}
+
+				sorting := db.Sorting{
+					Sort:  []string{"createdAt"},
+					Order: []string{"desc"},
+				}
+
+				store.EXPECT().GetAllUsers(gomock.Any(), pagination, sorting).Times(1).Return(users, db.PageInfo{TotalCount: 10}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:   "Fail with missing page_id",
+			caller: admin,
+			query:  "?page_size=10",
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().GetAllUsers(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:   "Fail due to caller not being an admin",
+			caller: user,
+			query:  "?page_id=1&page_size=10",
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().GetAllUsers(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mock_db.NewMockDBStore(ctrl)
+			expectActiveSession(store)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/api/v1/users%s", tc.query)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, tc.caller.ID, tc.caller.Role, time.Minute)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestUnitGetCurrentUser(t *testing.T) {
+	user, _ := randomUser(t)
+
+	testCases := []struct {
+		name          string
+		buildStubs    func(store *mock_db.MockDBStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "Success",
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().GetUserByID(gomock.Any(), user.ID).Times(1).Return(user, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var gotUser UserResponse
+				err := json.NewDecoder(recorder.Body).Decode(&gotUser)
+				require.NoError(t, err)
+
+				requireUserComparison(t, user, gotUser)
+				require.NotContains(t, recorder.Body.String(), "passwordHash")
+			},
+		},
+		{
+			name: "Fail due to user not found",
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().GetUserByID(gomock.Any(), user.ID).Times(1).Return(db.User{}, db.ErrNotFound)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mock_db.NewMockDBStore(ctrl)
+			expectActiveSession(store)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := "/api/v1/users/me"
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.ID, user.Role, time.Minute)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestUnitGetUserByID(t *testing.T) {
+	admin := randomAdmin(t)
+	user, _ := randomUser(t)
+	otherUser, _ := randomUser(t)
+
+	testCases := []struct {
+		name          string
+		caller        db.User
+		id            string
+		buildStubs    func(store *mock_db.MockDBStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "Success getting oneself",
+			caller: user,
+			id:     user.ID,
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().GetUserByID(gomock.Any(), user.ID).Times(1).Return(user, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var gotUser UserResponse
+				err := json.NewDecoder(recorder.Body).Decode(&gotUser)
+				require.NoError(t, err)
+
+				requireUserComparison(t, user, gotUser)
+			},
+		},
+		{
+			name:   "Success getting another user as admin",
+			caller: admin,
+			id:     user.ID,
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().GetUserByID(gomock.Any(), user.ID).Times(1).Return(user, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:   "Fail getting another user without being an admin",
+			caller: otherUser,
+			id:     user.ID,
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().GetUserByID(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:   "Fail due to user not found",
+			caller: admin,
+			id:     user.ID,
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().GetUserByID(gomock.Any(), user.ID).Times(1).Return(db.User{}, db.ErrNotFound)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mock_db.NewMockDBStore(ctrl)
+			expectActiveSession(store)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/api/v1/users/%s", tc.id)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, tc.caller.ID, tc.caller.Role, time.Minute)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestUnitListUserRecipes(t *testing.T) {
+	caller, _ := randomUser(t)
+	user, _ := randomUser(t)
+
+	var recipes []db.Recipe
+	for i := 0; i < 3; i++ {
+		recipe, _ := randomRecipe(t)
+		recipe.UserID = user.ID
+		recipe.UserCreated = db.User{ID: user.ID, Email: user.Email}
+		recipes = append(recipes, recipe)
+	}
+
+	testCases := []struct {
+		name          string
+		id            string
+		query         string
+		buildStubs    func(store *mock_db.MockDBStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "Success with recipes of another user",
+			id:    user.ID,
+			query: "?page_id=1&page_size=10",
+			buildStubs: func(store *mock_db.MockDBStore) {
+				pagination := db.Pagination{
+					PageID:   1,
+					PageSize: 10,
+				}
+
+				store.EXPECT().GetUserByID(gomock.Any(), user.ID).Times(1).Return(user, nil)
+				store.EXPECT().GetRecipesByUserID(gomock.Any(), user.ID, pagination, db.Sorting{}).Times(1).Return(recipes, db.PageInfo{TotalCount: 3}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var gotPage RecipeListResponse
+				err := json.NewDecoder(recorder.Body).Decode(&gotPage)
+				require.NoError(t, err)
+
+				require.Equal(t, 3, len(gotPage.Items))
+				for i, expectedRecipe := range recipes {
+					requireRecipeComparison(t, expectedRecipe, gotPage.Items[i])
+				}
+
+				require.NotContains(t, recorder.Body.String(), "passwordHash")
+			},
+		},
+		{
+			name:  "Fail with non-existent user",
+			id:    user.ID,
+			query: "?page_id=1&page_size=10",
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().GetUserByID(gomock.Any(), user.ID).Times(1).Return(db.User{}, db.ErrNotFound)
+				store.EXPECT().GetRecipesByUserID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:  "Fail with non-parsable ID",
+			id:    "notvalid",
+			query: "?page_id=1&page_size=10",
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().GetUserByID(gomock.Any(), "notvalid").Times(1).Return(db.User{}, db.ErrInvalidID)
+				store.EXPECT().GetRecipesByUserID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "Fail with missing page_size",
+			id:    user.ID,
+			query: "?page_id=1",
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().GetUserByID(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().GetRecipesByUserID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mock_db.NewMockDBStore(ctrl)
+			expectActiveSession(store)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/api/v1/users/%s/recipes%s", tc.id, tc.query)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, caller.ID, caller.Role, time.Minute)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestUnitPatchUserByID(t *testing.T) {
+	admin := randomAdmin(t)
+	user, _ := randomUser(t)
+	otherUser, _ := randomUser(t)
+
+	newEmail := util.RandomEmail()
+
+	testCases := []struct {
+		name          string
+		caller        db.User
+		id            string
+		body          gin.H
+		buildStubs    func(store *mock_db.MockDBStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "Success patching oneself",
+			caller: user,
+			id:     user.ID,
+			body:   gin.H{"email": newEmail},
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().GetUserByID(gomock.Any(), user.ID).Times(1).Return(user, nil)
+				store.EXPECT().UpdateUserByID(gomock.Any(), user.ID, db.User{Email: newEmail}).Times(1).Return(int64(1), nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:   "Success patching another user as admin",
+			caller: admin,
+			id:     user.ID,
+			body:   gin.H{"password": "n3wP@ssword"},
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().GetUserByID(gomock.Any(), user.ID).Times(1).Return(user, nil)
+				store.EXPECT().UpdateUserByID(gomock.Any(), user.ID, db.User{Password: "n3wP@ssword"}).Times(1).Return(int64(1), nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:   "Fail due to missing body",
+			caller: user,
+			id:     user.ID,
+			body:   gin.H{},
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().GetUserByID(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().UpdateUserByID(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:   "Fail due to password too short",
+			caller: user,
+			id:     user.ID,
+			body:   gin.H{"password": "short"},
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().GetUserByID(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().UpdateUserByID(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:   "Fail patching another user without being an admin",
+			caller: otherUser,
+			id:     user.ID,
+			body:   gin.H{"email": newEmail},
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().GetUserByID(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().UpdateUserByID(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:   "Fail due to user not found",
+			caller: admin,
+			id:     user.ID,
+			body:   gin.H{"email": newEmail},
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().GetUserByID(gomock.Any(), user.ID).Times(1).Return(db.User{}, db.ErrNotFound)
+				store.EXPECT().UpdateUserByID(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mock_db.NewMockDBStore(ctrl)
+			expectActiveSession(store)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/api/v1/users/%s", tc.id)
+			request, err := http.NewRequest(http.MethodPatch, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, tc.caller.ID, tc.caller.Role, time.Minute)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestUnitDeleteUserByID(t *testing.T) {
+	admin := randomAdmin(t)
+	user, _ := randomUser(t)
+	otherUser, _ := randomUser(t)
+
+	testCases := []struct {
+		name          string
+		caller        db.User
+		id            string
+		buildStubs    func(store *mock_db.MockDBStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "Success deleting oneself",
+			caller: user,
+			id:     user.ID,
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().GetUserByID(gomock.Any(), user.ID).Times(1).Return(user, nil)
+				store.EXPECT().DeleteUserByID(gomock.Any(), user.ID).Times(1).Return(int64(1), nil)
+				store.EXPECT().BlockSessionsByUserID(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:   "Success deleting another user as admin",
+			caller: admin,
+			id:     user.ID,
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().GetUserByID(gomock.Any(), user.ID).Times(1).Return(user, nil)
+				store.EXPECT().DeleteUserByID(gomock.Any(), user.ID).Times(1).Return(int64(1), nil)
+				store.EXPECT().BlockSessionsByUserID(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:   "Fail deleting another user without being an admin",
+			caller: otherUser,
+			id:     user.ID,
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().GetUserByID(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().DeleteUserByID(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:   "Fail due to referencing documents",
+			caller: user,
+			id:     user.ID,
+			buildStubs: func(store *mock_db.MockDBStore) {
+				store.EXPECT().GetUserByID(gomock.Any(), user.ID).Times(1).Return(user, nil)
+				store.EXPECT().DeleteUserByID(gomock.Any(), user.ID).Times(1).Return(int64(0), db.ErrReferenced)
+				store.EXPECT().BlockSessionsByUserID(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mock_db.NewMockDBStore(ctrl)
+			expectActiveSession(store)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/api/v1/users/%s", tc.id)
+			request, err := http.NewRequest(http.MethodDelete, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, tc.caller.ID, tc.caller.Role, time.Minute)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package api
+
+import (
+	"net/url"
+	"reflect"
+	"strings"
+
+	"github.com/PfMartin/wegonice-api/db"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"github.com/rs/zerolog/log"
+)
+
+// registerValidators configures the validator used by gin for binding requests.
+func registerValidators() {
+	validate, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		log.Error().Msg("failed to get validator engine for registering validators")
+		return
+	}
+
+	// Report fields with their JSON names, so validation errors match the request body
+	validate.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			return ""
+		}
+		if name == "" {
+			return field.Name
+		}
+
+		return name
+	})
+
+	for tag, validatorFunc := range customValidators {
+		if err := validate.RegisterValidation(tag, validatorFunc); err != nil {
+			log.Err(err).Msgf("failed to register %s validator", tag)
+		}
+	}
+}
+
+// customValidators are the validators, which are used in the binding tags in addition to the
+// built-in validators.
+var customValidators = map[string]validator.Func{
+	"amount_unit":     validateAmountUnit,
+	"recipe_category": validateRecipeCategory,
+	"dietary_label":   validateDietaryLabel,
+	"allergen":        validateAllergen,
+	"web_url":         validateWebURL,
+	"prep_steps":      validatePrepSteps,
+}
+
+// validateAmountUnit checks, that the unit of an ingredient is one of the supported units.
+func validateAmountUnit(fieldLevel validator.FieldLevel) bool {
+	unit, ok := fieldLevel.Field().Interface().(db.AmountUnit)
+
+	return ok && unit.IsValid()
+}
+
+// validateRecipeCategory checks, that the category of a recipe is one of the supported categories.
+func validateRecipeCategory(fieldLevel validator.FieldLevel) bool {
+	category, ok := fieldLevel.Field().Interface().(db.Category)
+
+	return ok && category.IsValid()
+}
+
+// validateDietaryLabel checks, that the label is part of the vocabulary of dietary labels.
+func validateDietaryLabel(fieldLevel validator.FieldLevel) bool {
+	label, ok := fieldLevel.Field().Interface().(db.DietaryLabel)
+
+	return ok && label.IsValid()
+}
+
+// validateAllergen checks, that the allergen is part of the allergen catalogue.
+func validateAllergen(fieldLevel validator.FieldLevel) bool {
+	allergen, ok := fieldLevel.Field().Interface().(db.Allergen)
+
+	return ok && allergen.IsValid()
+}
+
+// validateWebURL checks, that the field is an absolute http or https URL.
+func validateWebURL(fieldLevel validator.FieldLevel) bool {
+	parsedURL, err := url.ParseRequestURI(fieldLevel.Field().String())
+	if err != nil {
+		return false
+	}
+
+	return (parsedURL.Scheme == "http" || parsedURL.Scheme == "https") && parsedURL.Host != ""
+}
+
+// validatePrepSteps checks, that the ranks of the preparation steps are unique and contiguous,
+// starting at 1. The order of the steps in the list doesn't matter.
+func validatePrepSteps(fieldLevel validator.FieldLevel) bool {
+	prepSteps, ok := fieldLevel.Field().Interface().([]db.PrepStep)
+	if !ok {
+		return false
+	}
+
+	ranks := make(map[int]bool, len(prepSteps))
+	for _, prepStep := range prepSteps {
+		if prepStep.Rank < 1 || prepStep.Rank > len(prepSteps) || ranks[prepStep.Rank] {
+			return false
+		}
+
+		ranks[prepStep.Rank] = true
+	}
+
+	return true
+}
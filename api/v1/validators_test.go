@@ -0,0 +1,128 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/PfMartin/wegonice-api/db"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnitRecipeValidators(t *testing.T) {
+	registerValidators()
+
+	router := gin.Default()
+	router.POST("/validate", func(ctx *gin.Context) {
+		var data db.RecipeToCreate
+		if err := ctx.ShouldBindJSON(&data); err != nil {
+			NewErrorBadRequest(err).Send(ctx)
+			return
+		}
+
+		ctx.Status(http.StatusOK)
+	})
+
+	validBody := func() gin.H {
+		return gin.H{
+			"name":      "Pancakes",
+			"recipeUrl": "https://www.allthepancakes.com/pancakes",
+			"timeM":     30,
+			"category":  db.Breakfast,
+			"ingredients": []gin.H{
+				{"name": "flour", "amount": 200, "unit": db.Grams},
+			},
+			"prepSteps": []gin.H{
+				{"rank": 2, "description": "Fry the pancakes"},
+				{"rank": 1, "description": "Mix the batter"},
+			},
+			"authorId": "660c4b99bc1bc4aabe126cd1",
+		}
+	}
+
+	testCases := []struct {
+		name               string
+		modify             func(body gin.H)
+		expectedFieldError *FieldError
+	}{
+		{
+			name:   "Success with valid recipe",
+			modify: func(body gin.H) {},
+		},
+		{
+			name:   "Success without prep steps",
+			modify: func(body gin.H) { delete(body, "prepSteps") },
+		},
+		{
+			name:               "Fail with unsupported category",
+			modify:             func(body gin.H) { body["category"] = "lunch" },
+			expectedFieldError: &FieldError{Field: "category", Message: "must be one of: breakfast main desert smoothie baby drink"},
+		},
+		{
+			name:               "Fail with negative time",
+			modify:             func(body gin.H) { body["timeM"] = -5 },
+			expectedFieldError: &FieldError{Field: "timeM", Message: "must be greater than or equal to 0"},
+		},
+		{
+			name:               "Fail with URL without scheme",
+			modify:             func(body gin.H) { body["recipeUrl"] = "www.allthepancakes.com" },
+			expectedFieldError: &FieldError{Field: "recipeUrl", Message: "must be a valid http or https URL"},
+		},
+		{
+			name:               "Fail with URL with unsupported scheme",
+			modify:             func(body gin.H) { body["recipeUrl"] = "ftp://allthepancakes.com/pancakes" },
+			expectedFieldError: &FieldError{Field: "recipeUrl", Message: "must be a valid http or https URL"},
+		},
+		{
+			name: "Fail with duplicate prep step ranks",
+			modify: func(body gin.H) {
+				body["prepSteps"] = []gin.H{{"rank": 1, "description": "Mix"}, {"rank": 1, "description": "Fry"}}
+			},
+			expectedFieldError: &FieldError{Field: "prepSteps", Message: "must have unique ranks from 1 to the number of steps"},
+		},
+		{
+			name: "Fail with gap in prep step ranks",
+			modify: func(body gin.H) {
+				body["prepSteps"] = []gin.H{{"rank": 1, "description": "Mix"}, {"rank": 3, "description": "Fry"}}
+			},
+			expectedFieldError: &FieldError{Field: "prepSteps", Message: "must have unique ranks from 1 to the number of steps"},
+		},
+		{
+			name: "Fail with unsupported ingredient unit",
+			modify: func(body gin.H) {
+				body["ingredients"] = []gin.H{{"name": "flour", "amount": 1, "unit": "handful"}}
+			},
+			expectedFieldError: &FieldError{Field: "ingredients[0].unit", Message: "must be one of: ml l mg g kg tbs tsp cup floz oz lb pinch pc can clove bunch slice"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			body := validBody()
+			tc.modify(body)
+
+			data, err := json.Marshal(body)
+			require.NoError(t, err)
+
+			recorder := httptest.NewRecorder()
+			request, err := http.NewRequest(http.MethodPost, "/validate", bytes.NewReader(data))
+			require.NoError(t, err)
+
+			router.ServeHTTP(recorder, request)
+
+			if tc.expectedFieldError == nil {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				return
+			}
+
+			require.Equal(t, http.StatusBadRequest, recorder.Code)
+
+			problem := decodeProblemDetails(t, recorder)
+			require.Equal(t, CodeValidationFailed, problem.Code)
+			require.Equal(t, []FieldError{*tc.expectedFieldError}, problem.Errors)
+		})
+	}
+}
@@ -0,0 +1,74 @@
+// Package conversion converts the amounts of ingredients between units and unit systems.
+package conversion
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/PfMartin/wegonice-api/db"
+)
+
+var (
+	ErrUnsupportedUnit   = errors.New("unsupported unit")
+	ErrIncompatibleUnits = errors.New("incompatible units")
+	ErrUnknownDensity    = errors.New("unknown density")
+)
+
+type dimension string
+
+const (
+	mass   dimension = "mass"
+	volume dimension = "volume"
+)
+
+type unitDefinition struct {
+	dimension dimension
+	// factor converts an amount to the base unit of the dimension, which is grams for mass and
+	// milliliters for volume.
+	factor float64
+	// system is empty for units, which are used in both unit systems.
+	system UnitSystem
+}
+
+// units are the units, which can be converted. Counted units like pieces are missing, since
+// they can't be converted.
+var units = map[db.AmountUnit]unitDefinition{
+	db.Milligrams:  {dimension: mass, factor: 0.001, system: Metric},
+	db.Grams:       {dimension: mass, factor: 1, system: Metric},
+	db.Kilograms:   {dimension: mass, factor: 1000, system: Metric},
+	db.Ounce:       {dimension: mass, factor: 28.349523125, system: Imperial},
+	db.Pound:       {dimension: mass, factor: 453.59237, system: Imperial},
+	db.Milliliters: {dimension: volume, factor: 1, system: Metric},
+	db.Liters:      {dimension: volume, factor: 1000, system: Metric},
+	db.FluidOunce:  {dimension: volume, factor: 29.5735295625, system: Imperial},
+	db.Cup:         {dimension: volume, factor: 236.5882365, system: Imperial},
+	db.Tablespoon:  {dimension: volume, factor: 14.78676478125},
+	db.Teaspoon:    {dimension: volume, factor: 4.92892159375},
+}
+
+// Convert converts the amount of an ingredient from one unit to another. Mass and volume are
+// converted into each other with the density of the ingredient.
+func Convert(amount float64, from db.AmountUnit, to db.AmountUnit, ingredientName string) (float64, error) {
+	fromUnit, ok := units[from]
+	if !ok {
+		return 0, fmt.Errorf("%w: %s", ErrUnsupportedUnit, from)
+	}
+
+	toUnit, ok := units[to]
+	if !ok {
+		return 0, fmt.Errorf("%w: %s", ErrUnsupportedUnit, to)
+	}
+
+	base := amount * fromUnit.factor
+
+	if fromUnit.dimension != toUnit.dimension {
+		ingredientDensity, ok := getDensity(ingredientName)
+		if !ok {
+			return 0, fmt.Errorf("%w: failed to convert %s to %s for ingredient %s", ErrUnknownDensity, from, to, ingredientName)
+		}
+
+		base = ingredientDensity.convertBase(base, fromUnit.dimension)
+	}
+
+	return base / toUnit.factor, nil
+}
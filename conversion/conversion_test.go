@@ -0,0 +1,144 @@
+package conversion
+
+import (
+	"testing"
+
+	"github.com/PfMartin/wegonice-api/db"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnitConvert(t *testing.T) {
+	testCases := []struct {
+		name           string
+		amount         float64
+		from           db.AmountUnit
+		to             db.AmountUnit
+		ingredientName string
+		expectedErr    error
+		expectedAmount float64
+	}{
+		{name: "Mass to mass", amount: 1, from: db.Kilograms, to: db.Grams, expectedAmount: 1000},
+		{name: "Imperial mass to imperial mass", amount: 16, from: db.Ounce, to: db.Pound, expectedAmount: 1},
+		{name: "Volume to volume", amount: 1, from: db.Cup, to: db.Milliliters, expectedAmount: 236.588},
+		{name: "Volume to mass", amount: 1, from: db.Cup, to: db.Grams, ingredientName: "Whole wheat flour", expectedAmount: 125.392},
+		{name: "Mass to volume", amount: 100, from: db.Grams, to: db.Cup, ingredientName: "sugar", expectedAmount: 0.497},
+		{name: "Volume to mass with the later of two density names", amount: 1, from: db.Cup, to: db.Grams, ingredientName: "rice milk", expectedAmount: 243.686},
+		{name: "Fail with unsupported unit", amount: 1, from: db.Piece, to: db.Grams, expectedErr: ErrUnsupportedUnit},
+		{name: "Fail with unknown density", amount: 1, from: db.Grams, to: db.Milliliters, ingredientName: "stones", expectedErr: ErrUnknownDensity},
+		{name: "Fail with density name inside of a word", amount: 1, from: db.Cup, to: db.Grams, ingredientName: "boiled potatoes", expectedErr: ErrUnknownDensity},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			amount, err := Convert(tc.amount, tc.from, tc.to, tc.ingredientName)
+
+			if tc.expectedErr != nil {
+				require.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.InDelta(t, tc.expectedAmount, amount, 0.001)
+		})
+	}
+}
+
+func TestUnitConvertIngredient(t *testing.T) {
+	testCases := []struct {
+		name               string
+		ingredient         db.Ingredient
+		system             UnitSystem
+		expectedIngredient db.Ingredient
+	}{
+		{
+			name:               "Weighs cups of flour in metric",
+			ingredient:         db.Ingredient{Name: "flour", Amount: 2, Unit: db.Cup},
+			system:             Metric,
+			expectedIngredient: db.Ingredient{Name: "flour", Amount: 251, Unit: db.Grams},
+		},
+		{
+			name:               "Measures rice milk by volume like milk in metric",
+			ingredient:         db.Ingredient{Name: "rice milk", Amount: 1, Unit: db.Cup},
+			system:             Metric,
+			expectedIngredient: db.Ingredient{Name: "rice milk", Amount: 237, Unit: db.Milliliters},
+		},
+		{
+			name:               "Measures liquids by volume in metric",
+			ingredient:         db.Ingredient{Name: "milk", Amount: 5, Unit: db.Cup},
+			system:             Metric,
+			expectedIngredient: db.Ingredient{Name: "milk", Amount: 1.2, Unit: db.Liters},
+		},
+		{
+			name:               "Converts ounces without density to grams",
+			ingredient:         db.Ingredient{Name: "cheese", Amount: 8, Unit: db.Ounce},
+			system:             Metric,
+			expectedIngredient: db.Ingredient{Name: "cheese", Amount: 227, Unit: db.Grams},
+		},
+		{
+			name:               "Measures weighed ingredients in cups in imperial",
+			ingredient:         db.Ingredient{Name: "flour", Amount: 200, Unit: db.Grams},
+			system:             Imperial,
+			expectedIngredient: db.Ingredient{Name: "flour", Amount: 1.5, Unit: db.Cup},
+		},
+		{
+			name:               "Uses spoons for small amounts in imperial",
+			ingredient:         db.Ingredient{Name: "salt", Amount: 10, Unit: db.Grams},
+			system:             Imperial,
+			expectedIngredient: db.Ingredient{Name: "salt", Amount: 1.75, Unit: db.Teaspoon},
+		},
+		{
+			name:               "Converts grams without density to pounds",
+			ingredient:         db.Ingredient{Name: "potatoes", Amount: 1, Unit: db.Kilograms},
+			system:             Imperial,
+			expectedIngredient: db.Ingredient{Name: "potatoes", Amount: 2.25, Unit: db.Pound},
+		},
+		{
+			name:               "Keeps units of the same unit system",
+			ingredient:         db.Ingredient{Name: "milk", Amount: 100, Unit: db.Milliliters},
+			system:             Metric,
+			expectedIngredient: db.Ingredient{Name: "milk", Amount: 100, Unit: db.Milliliters},
+		},
+		{
+			name:               "Keeps spoons",
+			ingredient:         db.Ingredient{Name: "sugar", Amount: 2, Unit: db.Tablespoon},
+			system:             Metric,
+			expectedIngredient: db.Ingredient{Name: "sugar", Amount: 2, Unit: db.Tablespoon},
+		},
+		{
+			name:               "Keeps units, which can't be converted",
+			ingredient:         db.Ingredient{Name: "eggs", Amount: 3, Unit: db.Piece},
+			system:             Imperial,
+			expectedIngredient: db.Ingredient{Name: "eggs", Amount: 3, Unit: db.Piece},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expectedIngredient, ConvertIngredient(tc.ingredient, tc.system))
+		})
+	}
+}
+
+func TestUnitConvertRecipe(t *testing.T) {
+	recipe := db.Recipe{
+		Name: "Pancakes",
+		Ingredients: []db.Ingredient{
+			{Name: "flour", Amount: 1, Unit: db.Cup},
+			{Name: "eggs", Amount: 2, Unit: db.Piece},
+		},
+	}
+
+	t.Run("Converts all ingredients without modifying the original recipe", func(t *testing.T) {
+		convertedRecipe := ConvertRecipe(recipe, Metric)
+
+		require.Equal(t, []db.Ingredient{
+			{Name: "flour", Amount: 125, Unit: db.Grams},
+			{Name: "eggs", Amount: 2, Unit: db.Piece},
+		}, convertedRecipe.Ingredients)
+		require.Equal(t, db.Cup, recipe.Ingredients[0].Unit)
+	})
+
+	t.Run("Returns the recipe unchanged without unit system", func(t *testing.T) {
+		require.Equal(t, recipe, ConvertRecipe(recipe, ""))
+	})
+}
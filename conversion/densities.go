@@ -0,0 +1,106 @@
+package conversion
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+)
+
+type density struct {
+	gramsPerMilliliter float64
+	// isLiquid marks ingredients, which are measured by volume in both unit systems.
+	isLiquid bool
+}
+
+// densities of common ingredients. Ingredients are matched by the longest name, which is part
+// of the name of the ingredient as whole words, e.g. "whole wheat flour" uses the density of
+// "flour", but "boiled potatoes" doesn't use the density of "oil". Of two names with the same
+// length, the one ending later wins, because the last word names the ingredient, e.g. "rice milk"
+// uses the density of "milk".
+var densities = map[string]density{
+	"almond flour":    {gramsPerMilliliter: 0.4},
+	"almond milk":     {gramsPerMilliliter: 1.01, isLiquid: true},
+	"breadcrumbs":     {gramsPerMilliliter: 0.45},
+	"brown sugar":     {gramsPerMilliliter: 0.93},
+	"butter":          {gramsPerMilliliter: 0.96},
+	"buttermilk":      {gramsPerMilliliter: 1.03, isLiquid: true},
+	"chocolate chips": {gramsPerMilliliter: 0.72},
+	"cocoa":           {gramsPerMilliliter: 0.42},
+	"cornstarch":      {gramsPerMilliliter: 0.54},
+	"cream":           {gramsPerMilliliter: 1.01, isLiquid: true},
+	"flour":           {gramsPerMilliliter: 0.53},
+	"honey":           {gramsPerMilliliter: 1.42, isLiquid: true},
+	"lentils":         {gramsPerMilliliter: 0.85},
+	"maple syrup":     {gramsPerMilliliter: 1.32, isLiquid: true},
+	"milk":            {gramsPerMilliliter: 1.03, isLiquid: true},
+	"oats":            {gramsPerMilliliter: 0.38},
+	"oil":             {gramsPerMilliliter: 0.92, isLiquid: true},
+	"peanut butter":   {gramsPerMilliliter: 1.08},
+	"powdered sugar":  {gramsPerMilliliter: 0.5},
+	"quinoa":          {gramsPerMilliliter: 0.72},
+	"rice":            {gramsPerMilliliter: 0.85},
+	"salt":            {gramsPerMilliliter: 1.2},
+	"sugar":           {gramsPerMilliliter: 0.85},
+	"water":           {gramsPerMilliliter: 1, isLiquid: true},
+	"yogurt":          {gramsPerMilliliter: 1.03},
+}
+
+type densityPattern struct {
+	name    string
+	pattern *regexp.Regexp
+}
+
+// densityPatterns match the names of the densities as whole words, optionally in plural. They
+// are sorted by name, so the matching doesn't depend on the order of the densities map.
+var densityPatterns = newDensityPatterns()
+
+func newDensityPatterns() []densityPattern {
+	patterns := make([]densityPattern, 0, len(densities))
+	for densityName := range densities {
+		patterns = append(patterns, densityPattern{
+			name:    densityName,
+			pattern: regexp.MustCompile(`\b` + regexp.QuoteMeta(densityName) + `(s|es)?\b`),
+		})
+	}
+
+	slices.SortFunc(patterns, func(a, b densityPattern) int {
+		return strings.Compare(a.name, b.name)
+	})
+
+	return patterns
+}
+
+func getDensity(ingredientName string) (density, bool) {
+	name := strings.ToLower(strings.TrimSpace(ingredientName))
+
+	var matchedName string
+	matchedEnd := -1
+	for _, densityPattern := range densityPatterns {
+		matches := densityPattern.pattern.FindAllStringIndex(name, -1)
+		if len(matches) == 0 {
+			continue
+		}
+
+		end := matches[len(matches)-1][1]
+		if len(densityPattern.name) > len(matchedName) || (len(densityPattern.name) == len(matchedName) && end > matchedEnd) {
+			matchedName = densityPattern.name
+			matchedEnd = end
+		}
+	}
+
+	if matchedName == "" {
+		return density{}, false
+	}
+
+	return densities[matchedName], true
+}
+
+// convertBase converts an amount in the base unit of one dimension to the base unit of the other
+// dimension.
+func (ingredientDensity density) convertBase(base float64, from dimension) float64 {
+	if from == volume {
+		return base * ingredientDensity.gramsPerMilliliter
+	}
+
+	return base / ingredientDensity.gramsPerMilliliter
+}
@@ -0,0 +1,113 @@
+package conversion
+
+import "github.com/PfMartin/wegonice-api/db"
+
+type UnitSystem string
+
+const (
+	Metric   UnitSystem = "metric"
+	Imperial UnitSystem = "imperial"
+)
+
+type targetUnit struct {
+	unit db.AmountUnit
+	// minimum is the smallest amount in the unit, for which it is used.
+	minimum float64
+}
+
+// targetUnits are the units, which amounts are converted to for each unit system and dimension,
+// ordered from the largest to the smallest unit.
+var targetUnits = map[UnitSystem]map[dimension][]targetUnit{
+	Metric: {
+		mass:   {{unit: db.Kilograms, minimum: 1}, {unit: db.Grams, minimum: 1}, {unit: db.Milligrams}},
+		volume: {{unit: db.Liters, minimum: 1}, {unit: db.Milliliters}},
+	},
+	Imperial: {
+		mass:   {{unit: db.Pound, minimum: 1}, {unit: db.Ounce}},
+		volume: {{unit: db.Cup, minimum: 0.25}, {unit: db.Tablespoon, minimum: 1}, {unit: db.Teaspoon}},
+	},
+}
+
+// ConvertRecipes returns copies of the recipes with their ingredients converted to the unit
+// system. The recipes are returned unchanged without unit system.
+func ConvertRecipes(recipes []db.Recipe, system UnitSystem) []db.Recipe {
+	if _, ok := targetUnits[system]; !ok {
+		return recipes
+	}
+
+	convertedRecipes := make([]db.Recipe, 0, len(recipes))
+	for _, recipe := range recipes {
+		convertedRecipes = append(convertedRecipes, ConvertRecipe(recipe, system))
+	}
+
+	return convertedRecipes
+}
+
+// ConvertSearchResults works like ConvertRecipes for the results of a recipe search.
+func ConvertSearchResults(results []db.RecipeSearchResult, system UnitSystem) []db.RecipeSearchResult {
+	if _, ok := targetUnits[system]; !ok {
+		return results
+	}
+
+	convertedResults := make([]db.RecipeSearchResult, 0, len(results))
+	for _, result := range results {
+		result.Recipe = ConvertRecipe(result.Recipe, system)
+		convertedResults = append(convertedResults, result)
+	}
+
+	return convertedResults
+}
+
+// ConvertRecipe returns a copy of the recipe with its ingredients converted to the unit system.
+// The recipe is returned unchanged without unit system.
+func ConvertRecipe(recipe db.Recipe, system UnitSystem) db.Recipe {
+	if _, ok := targetUnits[system]; !ok {
+		return recipe
+	}
+
+	convertedIngredients := make([]db.Ingredient, 0, len(recipe.Ingredients))
+	for _, ingredient := range recipe.Ingredients {
+		convertedIngredients = append(convertedIngredients, ConvertIngredient(ingredient, system))
+	}
+
+	recipe.Ingredients = convertedIngredients
+
+	return recipe
+}
+
+// ConvertIngredient converts the amount of the ingredient to the unit of the unit system, which
+// fits its size best. Ingredients, which are weighed, are converted between cups and grams, if
+// their density is known. Spoons are used in both unit systems and are kept, as well as units,
+// which can't be converted.
+func ConvertIngredient(ingredient db.Ingredient, system UnitSystem) db.Ingredient {
+	unit, ok := units[ingredient.Unit]
+	if !ok || ingredient.Amount == 0 || unit.system == "" || unit.system == system {
+		return ingredient
+	}
+
+	base := float64(ingredient.Amount) * unit.factor
+	baseDimension := unit.dimension
+
+	ingredientDensity, ok := getDensity(ingredient.Name)
+	if ok && !ingredientDensity.isLiquid {
+		if system == Metric && baseDimension == volume {
+			base = ingredientDensity.convertBase(base, volume)
+			baseDimension = mass
+		} else if system == Imperial && baseDimension == mass {
+			base = ingredientDensity.convertBase(base, mass)
+			baseDimension = volume
+		}
+	}
+
+	candidates := targetUnits[system][baseDimension]
+	for _, candidate := range candidates {
+		amount := base / units[candidate.unit].factor
+		if amount >= candidate.minimum {
+			ingredient.Unit = candidate.unit
+			ingredient.Amount = db.RoundAmount(amount, candidate.unit)
+			break
+		}
+	}
+
+	return ingredient
+}
@@ -0,0 +1,154 @@
+package db
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// fractionDenominators are the denominators, which are commonly used for amounts in recipes.
+var fractionDenominators = []int{2, 3, 4, 8}
+
+// Amount is the decimal amount of an ingredient. In JSON it is either provided as number or as
+// string, which may contain a fraction, e.g. "1/3" or "1 1/2".
+type Amount float64
+
+// ParseAmount parses a decimal number, a fraction or a whole number followed by a fraction.
+func ParseAmount(value string) (Amount, error) {
+	value = strings.TrimSpace(value)
+
+	whole, fraction, hasWhole := strings.Cut(value, " ")
+	if !hasWhole {
+		whole, fraction = "", value
+	}
+
+	amount, err := parseFraction(strings.TrimSpace(fraction))
+	if err != nil {
+		return 0, fmt.Errorf("invalid amount %q: %w", value, err)
+	}
+
+	if hasWhole {
+		wholeAmount, err := strconv.ParseUint(whole, 10, 32)
+		if err != nil || !strings.Contains(fraction, "/") {
+			return 0, fmt.Errorf("invalid amount %q: expected a whole number followed by a fraction", value)
+		}
+		amount += float64(wholeAmount)
+	}
+
+	if amount < 0 || math.IsInf(amount, 0) || math.IsNaN(amount) {
+		return 0, fmt.Errorf("invalid amount %q: must be a positive number", value)
+	}
+
+	return Amount(amount), nil
+}
+
+func parseFraction(value string) (float64, error) {
+	numerator, denominator, isFraction := strings.Cut(value, "/")
+	if !isFraction {
+		return strconv.ParseFloat(value, 64)
+	}
+
+	num, err := strconv.ParseUint(strings.TrimSpace(numerator), 10, 32)
+	if err != nil {
+		return 0, err
+	}
+
+	den, err := strconv.ParseUint(strings.TrimSpace(denominator), 10, 32)
+	if err != nil {
+		return 0, err
+	}
+	if den == 0 {
+		return 0, fmt.Errorf("denominator must not be zero")
+	}
+
+	return float64(num) / float64(den), nil
+}
+
+func (amount *Amount) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+
+	if len(data) > 0 && data[0] == '"' {
+		var value string
+		if err := json.Unmarshal(data, &value); err != nil {
+			return err
+		}
+
+		parsedAmount, err := ParseAmount(value)
+		if err != nil {
+			return err
+		}
+
+		*amount = parsedAmount
+		return nil
+	}
+
+	var value float64
+	if err := json.Unmarshal(data, &value); err != nil {
+		return fmt.Errorf("invalid amount %s: %w", data, err)
+	}
+
+	*amount = Amount(value)
+	return nil
+}
+
+// MarshalJSON writes the amount as number with at most three decimals, so repeating decimals
+// like 1/3 don't leak their floating point representation.
+func (amount Amount) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.FormatFloat(roundDecimals(float64(amount), 3), 'f', -1, 64)), nil
+}
+
+// Fraction formats the amount with one of the fractionDenominators, e.g. "1 1/2". Amounts,
+// which can't be written as such a fraction, are formatted as decimal number.
+func (amount Amount) Fraction() string {
+	if amount == 0 {
+		return ""
+	}
+
+	whole, rest := math.Modf(float64(amount))
+	if rest < 0.01 {
+		return strconv.FormatFloat(whole, 'f', -1, 64)
+	}
+	if rest > 0.99 {
+		return strconv.FormatFloat(whole+1, 'f', -1, 64)
+	}
+
+	for _, denominator := range fractionDenominators {
+		numerator := rest * float64(denominator)
+		if math.Abs(numerator-math.Round(numerator)) > 0.01 {
+			continue
+		}
+
+		fraction := fmt.Sprintf("%d/%d", int(math.Round(numerator)), denominator)
+		if whole == 0 {
+			return fraction
+		}
+
+		return fmt.Sprintf("%d %s", int(whole), fraction)
+	}
+
+	return strconv.FormatFloat(roundDecimals(float64(amount), 2), 'f', -1, 64)
+}
+
+// roundToFraction rounds the value to the closest whole number or fraction with one of the
+// fractionDenominators. Of equally close fractions, the one with the smaller denominator wins.
+func roundToFraction(value float64) float64 {
+	roundedValue := math.Round(value)
+	for _, denominator := range fractionDenominators {
+		fraction := math.Round(value*float64(denominator)) / float64(denominator)
+		if math.Abs(fraction-value) < math.Abs(roundedValue-value) {
+			roundedValue = fraction
+		}
+	}
+
+	return roundedValue
+}
+
+func roundDecimals(value float64, decimals int) float64 {
+	factor := math.Pow10(decimals)
+
+	return math.Round(value*factor) / factor
+}
@@ -0,0 +1,88 @@
+package db
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnitParseAmount(t *testing.T) {
+	testCases := []struct {
+		name           string
+		value          string
+		hasError       bool
+		expectedAmount Amount
+	}{
+		{name: "Whole number", value: "2", expectedAmount: 2},
+		{name: "Decimal number", value: "0.5", expectedAmount: 0.5},
+		{name: "Fraction", value: "3/4", expectedAmount: 0.75},
+		{name: "Whole number with fraction", value: " 1 1/2 ", expectedAmount: 1.5},
+		{name: "Fail with empty value", value: "", hasError: true},
+		{name: "Fail with zero denominator", value: "1/0", hasError: true},
+		{name: "Fail with negative number", value: "-1", hasError: true},
+		{name: "Fail with whole number without fraction", value: "1 2", hasError: true},
+		{name: "Fail with text", value: "a pinch", hasError: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			amount, err := ParseAmount(tc.value)
+
+			if tc.hasError {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedAmount, amount)
+		})
+	}
+}
+
+func TestUnitAmountFraction(t *testing.T) {
+	testCases := []struct {
+		amount           Amount
+		expectedFraction string
+	}{
+		{amount: 0, expectedFraction: ""},
+		{amount: 2, expectedFraction: "2"},
+		{amount: 0.5, expectedFraction: "1/2"},
+		{amount: 1.0 / 3, expectedFraction: "1/3"},
+		{amount: 1.75, expectedFraction: "1 3/4"},
+		{amount: 2.125, expectedFraction: "2 1/8"},
+		{amount: 0.15, expectedFraction: "0.15"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.expectedFraction, func(t *testing.T) {
+			require.Equal(t, tc.expectedFraction, tc.amount.Fraction())
+		})
+	}
+}
+
+func TestUnitIngredientJSON(t *testing.T) {
+	t.Run("Reads amounts as number or fraction", func(t *testing.T) {
+		var ingredients []Ingredient
+		err := json.Unmarshal([]byte(`[{"name":"flour","amount":"1 1/3","unit":"cup"},{"name":"salt","amount":0.5,"unit":"tsp"}]`), &ingredients)
+		require.NoError(t, err)
+
+		require.Equal(t, []Ingredient{
+			{Name: "flour", Amount: Amount(4.0 / 3), Unit: Cup},
+			{Name: "salt", Amount: 0.5, Unit: Teaspoon},
+		}, ingredients)
+	})
+
+	t.Run("Fails with invalid fraction", func(t *testing.T) {
+		var ingredient Ingredient
+		err := json.Unmarshal([]byte(`{"name":"flour","amount":"1/x","unit":"cup"}`), &ingredient)
+		require.Error(t, err)
+	})
+
+	t.Run("Writes amounts as rounded number and fraction", func(t *testing.T) {
+		data, err := json.Marshal(Ingredient{Name: "flour", Amount: Amount(4.0 / 3), Unit: Cup})
+		require.NoError(t, err)
+
+		require.JSONEq(t, `{"name":"flour","amount":1.333,"amountFraction":"1 1/3","unit":"cup"}`, string(data))
+	})
+}
@@ -19,6 +19,16 @@ var authorLookupStage = bson.M{"$lookup": bson.M{
 	"as":           "recipeAuthor",
 }}
 
+// recipeCountLookupStage looks up the recipes of an author to count them in the
+// authorProjectStage. A recipe only needs its ID to be counted.
+var recipeCountLookupStage = bson.M{"$lookup": bson.M{
+	"from":         "recipes",
+	"localField":   "_id",
+	"foreignField": "authorId",
+	"pipeline":     bson.A{bson.M{"$project": bson.M{"_id": 1}}},
+	"as":           "recipes",
+}}
+
 var authorProjectStage = bson.M{"$project": bson.M{
 	"_id":          1,
 	"name":         1,
@@ -28,6 +38,7 @@ var authorProjectStage = bson.M{"$project": bson.M{
 	"instagramUrl": 1,
 	"youtubeUrl":   1,
 	"imageName":    1,
+	"recipeCount":  bson.M{"$size": "$recipes"},
 	"createdAt":    1,
 	"modifiedAt":   1,
 	"userCreated": bson.M{
@@ -42,22 +53,18 @@ var authorProjectStage = bson.M{"$project": bson.M{
 	},
 }}
 
-func (store *MongoDBStore) CreateAuthor(ctx context.Context, author AuthorToCreate) (primitive.ObjectID, error) {
-	indexModel := mongo.IndexModel{
+var authorIndexModels = []mongo.IndexModel{
+	{
 		Keys:    bson.M{"name": 1},
 		Options: options.Index().SetUnique(true),
-	}
-
-	_, err := store.authorCollection.Indexes().CreateOne(ctx, indexModel)
-	if err != nil {
-		log.Err(err).Msgf("author with name %s already exists", author.Name)
-		return primitive.NilObjectID, err
-	}
+	},
+}
 
+func (store *MongoDBStore) CreateAuthor(ctx context.Context, author AuthorToCreate) (primitive.ObjectID, error) {
 	primitiveUserID, err := primitive.ObjectIDFromHex(author.UserID)
 	if err != nil {
 		log.Err(err).Msgf("failed to parse userID %s to primitive ObjectID", author.UserID)
-		return primitive.NilObjectID, err
+		return primitive.NilObjectID, newInvalidIDError("userID", author.UserID, err)
 	}
 
 	insertData := bson.M{
@@ -76,7 +83,7 @@ func (store *MongoDBStore) CreateAuthor(ctx context.Context, author AuthorToCrea
 	insertResult, err := store.authorCollection.InsertOne(ctx, insertData)
 	if err != nil {
 		log.Err(err).Msgf("failed to insert author with name %s", author.Name)
-		return primitive.NilObjectID, err
+		return primitive.NilObjectID, wrapMongoError(err)
 	}
 
 	authorID := insertResult.InsertedID.(primitive.ObjectID)
@@ -84,30 +91,22 @@ func (store *MongoDBStore) CreateAuthor(ctx context.Context, author AuthorToCrea
 	return authorID, nil
 }
 
-func (store *MongoDBStore) GetAllAuthors(ctx context.Context, pagination Pagination) ([]Author, error) {
+func (store *MongoDBStore) GetAllAuthors(ctx context.Context, pagination Pagination, sorting Sorting) ([]Author, PageInfo, error) {
 	var authors []Author
 
-	pipeline := []bson.M{
-		userLookupStage,
-		authorProjectStage,
-		getSortStage("name"),
-		pagination.getSkipStage(),
-		pagination.getLimitStage(),
+	sortFields, err := sorting.getSortFields(AuthorSortKeys, "name")
+	if err != nil {
+		log.Err(err).Msg("failed to get sort fields for authors")
+		return authors, PageInfo{}, err
 	}
 
-	cursor, err := store.authorCollection.Aggregate(ctx, pipeline)
+	authors, pageInfo, err := aggregatePage[Author](ctx, store.authorCollection, nil, pagination, sortFields, authorComputedSortKeys, userLookupStage, recipeCountLookupStage, authorProjectStage)
 	if err != nil {
 		log.Err(err).Msg("failed to aggregate author documents")
-		return authors, err
-	}
-	defer cursor.Close(ctx)
-
-	if err = cursor.All(ctx, &authors); err != nil {
-		log.Err(err).Msg("failed to parse author documents")
-		return authors, err
+		return authors, PageInfo{}, err
 	}
 
-	return authors, nil
+	return authors, pageInfo, nil
 }
 
 func (store *MongoDBStore) GetAuthorByID(ctx context.Context, authorID string) (Author, error) {
@@ -116,12 +115,13 @@ func (store *MongoDBStore) GetAuthorByID(ctx context.Context, authorID string) (
 	primitiveAuthorID, err := primitive.ObjectIDFromHex(authorID)
 	if err != nil {
 		log.Err(err).Msgf("failed to parse authorID %s to primitive ObjectID", authorID)
-		return author, err
+		return author, newInvalidIDError("authorID", authorID, err)
 	}
 
 	pipeline := []bson.M{
 		{"$match": bson.M{"_id": primitiveAuthorID}},
 		userLookupStage,
+		recipeCountLookupStage,
 		authorProjectStage,
 		{"$limit": 1},
 	}
@@ -135,12 +135,12 @@ func (store *MongoDBStore) GetAuthorByID(ctx context.Context, authorID string) (
 
 	if !cursor.Next(ctx) {
 		log.Error().Msgf("failed to find author with authorID %s", authorID)
-		return author, fmt.Errorf("failed to find author with authorID %s", authorID)
+		return author, fmt.Errorf("%w: failed to find author with authorID %s", ErrNotFound, authorID)
 	}
 
 	if err := cursor.Decode(&author); err != nil {
 		log.Err(err).Msg("failed to decode author")
-		return author, nil
+		return author, err
 	}
 
 	return author, nil
@@ -150,7 +150,7 @@ func (store *MongoDBStore) UpdateAuthorByID(ctx context.Context, authorID string
 	primitiveAuthorID, err := primitive.ObjectIDFromHex(authorID)
 	if err != nil {
 		log.Err(err).Msgf("failed to parse authorID %s to primitive ObjectID", authorID)
-		return 0, err
+		return 0, newInvalidIDError("authorID", authorID, err)
 	}
 
 	filter := bson.M{
@@ -185,7 +185,7 @@ func (store *MongoDBStore) UpdateAuthorByID(ctx context.Context, authorID string
 	updateResult, err := store.authorCollection.UpdateOne(ctx, filter, update)
 	if err != nil {
 		log.Err(err).Msgf("failed to update author with author authorID %s", authorID)
-		return 0, err
+		return 0, wrapMongoError(err)
 	}
 
 	if updateResult.MatchedCount < 1 {
@@ -204,7 +204,7 @@ func (store *MongoDBStore) DeleteAuthorByID(ctx context.Context, authorID string
 	primitiveAuthorID, err := primitive.ObjectIDFromHex(authorID)
 	if err != nil {
 		log.Err(err).Msgf("failed to parse authorID %s to primitive ObjectID", authorID)
-		return 0, err
+		return 0, newInvalidIDError("authorID", authorID, err)
 	}
 
 	if err = checkReferencesOfDocument(ctx, store.recipeCollection, "authorId", primitiveAuthorID); err != nil {
@@ -93,9 +93,10 @@ func TestUnitGetAllAuthors(t *testing.T) {
 
 	t.Run("Gets all authors with pagination", func(t *testing.T) {
 		ctx := context.Background()
-		authors, err := store.GetAllAuthors(ctx, pagination)
+		authors, pageInfo, err := store.GetAllAuthors(ctx, pagination, Sorting{})
 		require.NoError(t, err)
 		require.NotEmpty(t, authors)
+		require.GreaterOrEqual(t, pageInfo.TotalCount, int64(10))
 
 		require.Equal(t, int(pagination.PageSize), len(authors))
 
@@ -105,6 +106,23 @@ func TestUnitGetAllAuthors(t *testing.T) {
 			require.Empty(t, author.UserID)
 		}
 	})
+
+	t.Run("Gets all authors sorted by recipe count", func(t *testing.T) {
+		authorWithRecipes := createRandomAuthor(t, store, user.ID)
+		for i := 0; i < 3; i++ {
+			_ = createRandomRecipe(t, store, user.ID, authorWithRecipes.ID)
+		}
+
+		sorting := Sorting{Sort: []string{"recipeCount"}, Order: []string{"desc"}}
+		authors, _, err := store.GetAllAuthors(context.Background(), pagination, sorting)
+		require.NoError(t, err)
+		require.NotEmpty(t, authors)
+
+		require.GreaterOrEqual(t, authors[0].RecipeCount, 3)
+		for i := 1; i < len(authors); i++ {
+			require.GreaterOrEqual(t, authors[i-1].RecipeCount, authors[i].RecipeCount)
+		}
+	})
 }
 
 func TestUnitGetAuthorByID(t *testing.T) {
@@ -113,10 +131,17 @@ func TestUnitGetAuthorByID(t *testing.T) {
 
 	createdAuthor := createRandomAuthor(t, store, user.ID)
 
+	authorWithRecipes := createRandomAuthor(t, store, user.ID)
+	for i := 0; i < 2; i++ {
+		_ = createRandomRecipe(t, store, user.ID, authorWithRecipes.ID)
+	}
+	authorWithRecipes.RecipeCount = 2
+
 	testCases := []struct {
 		name           string
 		authorID       string
 		hasError       bool
+		expectedErr    error
 		expectedAuthor Author
 	}{
 		{
@@ -126,14 +151,22 @@ func TestUnitGetAuthorByID(t *testing.T) {
 			expectedAuthor: createdAuthor,
 		},
 		{
-			name:     "Fail with invalid authorID",
-			authorID: "test",
-			hasError: true,
+			name:           "Success with recipe count",
+			authorID:       authorWithRecipes.ID,
+			hasError:       false,
+			expectedAuthor: authorWithRecipes,
 		},
 		{
-			name:     "Fail with authorID not found",
-			authorID: "659c00751f7178dff690270d",
-			hasError: true,
+			name:        "Fail with invalid authorID",
+			authorID:    "test",
+			hasError:    true,
+			expectedErr: ErrInvalidID,
+		},
+		{
+			name:        "Fail with authorID not found",
+			authorID:    "659c00751f7178dff690270d",
+			hasError:    true,
+			expectedErr: ErrNotFound,
 		},
 	}
 
@@ -143,6 +176,7 @@ func TestUnitGetAuthorByID(t *testing.T) {
 
 			if tc.hasError {
 				require.Error(t, err)
+				require.ErrorIs(t, err, tc.expectedErr)
 				return
 			}
 
@@ -0,0 +1,116 @@
+package db
+
+import (
+	"encoding/base64"
+	"fmt"
+	"slices"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// pageCursor marks the position after the last document of a page. It contains the sorting,
+// which was used to create it, so a cursor can't be continued with a different sorting.
+type pageCursor struct {
+	Keys   []string           `bson:"k"`
+	Orders []int              `bson:"o"`
+	Values bson.A             `bson:"v"`
+	ID     primitive.ObjectID `bson:"id"`
+}
+
+// encodePageCursor creates the opaque cursor, which points behind the given document.
+func encodePageCursor(sortFields []sortField, document bson.Raw) (string, error) {
+	id, ok := document.Lookup("_id").ObjectIDOK()
+	if !ok {
+		return "", fmt.Errorf("failed to create cursor: document has no valid _id")
+	}
+
+	cursor := pageCursor{
+		Keys:   make([]string, 0, len(sortFields)),
+		Orders: make([]int, 0, len(sortFields)),
+		Values: make(bson.A, 0, len(sortFields)),
+		ID:     id,
+	}
+
+	for _, field := range sortFields {
+		cursor.Keys = append(cursor.Keys, field.key)
+		cursor.Orders = append(cursor.Orders, field.order)
+
+		// Missing values are stored as null, which matches missing fields and null values alike.
+		value, err := document.LookupErr(field.key)
+		if err != nil || value.Type == bson.TypeNull || value.Type == bson.TypeUndefined {
+			cursor.Values = append(cursor.Values, nil)
+			continue
+		}
+
+		cursor.Values = append(cursor.Values, value)
+	}
+
+	data, err := bson.Marshal(cursor)
+	if err != nil {
+		return "", fmt.Errorf("failed to create cursor: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// decodePageCursor parses the opaque cursor and checks that it was created with the same
+// sorting.
+func decodePageCursor(encodedCursor string, sortFields []sortField) (pageCursor, error) {
+	var cursor pageCursor
+
+	data, err := base64.RawURLEncoding.DecodeString(encodedCursor)
+	if err != nil {
+		return cursor, fmt.Errorf("%w: failed to decode cursor: %w", ErrInvalidCursor, err)
+	}
+
+	if err = bson.Unmarshal(data, &cursor); err != nil {
+		return cursor, fmt.Errorf("%w: failed to parse cursor: %w", ErrInvalidCursor, err)
+	}
+
+	keys := make([]string, 0, len(sortFields))
+	orders := make([]int, 0, len(sortFields))
+	for _, field := range sortFields {
+		keys = append(keys, field.key)
+		orders = append(orders, field.order)
+	}
+
+	if !slices.Equal(keys, cursor.Keys) || !slices.Equal(orders, cursor.Orders) || len(cursor.Values) != len(keys) {
+		return cursor, fmt.Errorf("%w: cursor was created with a different sorting", ErrInvalidCursor)
+	}
+
+	return cursor, nil
+}
+
+// getMatchStage returns the documents, which come after the cursor in the order of its
+// sorting. The _id is used as the last key to break ties. Null and missing values are sorted
+// before all other values, so they can't be compared with $gt and $lt.
+func (cursor *pageCursor) getMatchStage() bson.M {
+	keys := append(slices.Clone(cursor.Keys), "_id")
+	orders := append(slices.Clone(cursor.Orders), 1)
+	values := append(slices.Clone(cursor.Values), cursor.ID)
+
+	conditions := make(bson.A, 0, len(keys))
+	for i, key := range keys {
+		condition := bson.M{}
+		for j := 0; j < i; j++ {
+			condition[keys[j]] = values[j]
+		}
+
+		switch {
+		case values[i] == nil && orders[i] > 0:
+			condition[key] = bson.M{"$ne": nil}
+		case values[i] == nil:
+			// Nothing comes after null in descending order, so only the next keys can match.
+			continue
+		case orders[i] > 0:
+			condition[key] = bson.M{"$gt": values[i]}
+		default:
+			condition["$or"] = bson.A{bson.M{key: bson.M{"$lt": values[i]}}, bson.M{key: nil}}
+		}
+
+		conditions = append(conditions, condition)
+	}
+
+	return bson.M{"$match": bson.M{"$or": conditions}}
+}
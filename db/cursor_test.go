@@ -0,0 +1,98 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestUnitPageCursor(t *testing.T) {
+	id := primitive.NewObjectID()
+	sortFields := []sortField{{key: "timeM", order: -1}, {key: "name", order: 1}}
+
+	document, err := bson.Marshal(bson.M{"_id": id, "name": "Pancakes", "timeM": int32(30)})
+	require.NoError(t, err)
+
+	encodedCursor, err := encodePageCursor(sortFields, document)
+	require.NoError(t, err)
+	require.NotEmpty(t, encodedCursor)
+
+	t.Run("Decodes cursor with the same sorting", func(t *testing.T) {
+		cursor, err := decodePageCursor(encodedCursor, sortFields)
+		require.NoError(t, err)
+
+		require.Equal(t, []string{"timeM", "name"}, cursor.Keys)
+		require.Equal(t, []int{-1, 1}, cursor.Orders)
+		require.Equal(t, bson.A{int32(30), "Pancakes"}, cursor.Values)
+		require.Equal(t, id, cursor.ID)
+	})
+
+	t.Run("Fails with a different sorting", func(t *testing.T) {
+		_, err := decodePageCursor(encodedCursor, []sortField{{key: "timeM", order: 1}, {key: "name", order: 1}})
+		require.ErrorIs(t, err, ErrInvalidCursor)
+	})
+
+	t.Run("Fails with malformed cursor", func(t *testing.T) {
+		_, err := decodePageCursor("not-a-cursor!", sortFields)
+		require.ErrorIs(t, err, ErrInvalidCursor)
+
+		_, err = decodePageCursor("bm90LWJzb24", sortFields)
+		require.ErrorIs(t, err, ErrInvalidCursor)
+	})
+
+	t.Run("Encodes missing and null values as nil", func(t *testing.T) {
+		document, err := bson.Marshal(bson.M{"_id": id, "name": nil})
+		require.NoError(t, err)
+
+		encodedCursor, err := encodePageCursor(sortFields, document)
+		require.NoError(t, err)
+
+		cursor, err := decodePageCursor(encodedCursor, sortFields)
+		require.NoError(t, err)
+		require.Equal(t, bson.A{nil, nil}, cursor.Values)
+	})
+
+	t.Run("Fails to encode document without _id", func(t *testing.T) {
+		document, err := bson.Marshal(bson.M{"name": "Pancakes"})
+		require.NoError(t, err)
+
+		_, err = encodePageCursor(sortFields, document)
+		require.Error(t, err)
+	})
+}
+
+func TestUnitPageCursorGetMatchStage(t *testing.T) {
+	id := primitive.NewObjectID()
+	cursor := pageCursor{
+		Keys:   []string{"timeM", "name"},
+		Orders: []int{-1, 1},
+		Values: bson.A{30, "Pancakes"},
+		ID:     id,
+	}
+
+	expectedStage := bson.M{"$match": bson.M{"$or": bson.A{
+		bson.M{"$or": bson.A{bson.M{"timeM": bson.M{"$lt": 30}}, bson.M{"timeM": nil}}},
+		bson.M{"timeM": 30, "name": bson.M{"$gt": "Pancakes"}},
+		bson.M{"timeM": 30, "name": "Pancakes", "_id": bson.M{"$gt": id}},
+	}}}
+
+	require.Equal(t, expectedStage, cursor.getMatchStage())
+
+	t.Run("Matches documents after null values", func(t *testing.T) {
+		cursor := pageCursor{
+			Keys:   []string{"category", "timeM"},
+			Orders: []int{1, -1},
+			Values: bson.A{nil, nil},
+			ID:     id,
+		}
+
+		expectedStage := bson.M{"$match": bson.M{"$or": bson.A{
+			bson.M{"category": bson.M{"$ne": nil}},
+			bson.M{"category": nil, "timeM": nil, "_id": bson.M{"$gt": id}},
+		}}}
+
+		require.Equal(t, expectedStage, cursor.getMatchStage())
+	})
+}
@@ -40,8 +40,8 @@ func NewDatabaseClient(authSource string, username string, password string, uri
 	return dbClient, cancel
 }
 
-func getSortStage(key string) bson.M {
-	return bson.M{"$sort": bson.M{key: 1}}
+func getSortStage(sortFields []sortField) bson.M {
+	return bson.M{"$sort": getSortDocument(sortFields)}
 }
 
 func checkReferencesOfDocument(ctx context.Context, coll *mongo.Collection, foreignKey string, id primitive.ObjectID) error {
@@ -51,7 +51,7 @@ func checkReferencesOfDocument(ctx context.Context, coll *mongo.Collection, fore
 	}
 
 	if count > 0 {
-		return fmt.Errorf("document with id %s is referenced in at least one other document", id)
+		return fmt.Errorf("%w: document with id %s is referenced in at least one other document", ErrReferenced, id.Hex())
 	}
 
 	return nil
@@ -7,6 +7,7 @@ import (
 	"github.com/PfMartin/wegonice-api/config"
 	"github.com/stretchr/testify/require"
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 func getDatabaseConfiguration(t *testing.T) config.Config {
@@ -39,25 +40,166 @@ func TestUnitConnectToDatabase(t *testing.T) {
 
 func TestUnitGetSortStage(t *testing.T) {
 	testCases := []struct {
-		name string
-		key  string
+		name          string
+		sortFields    []sortField
+		expectedStage bson.M
 	}{
 		{
-			name: "Success with name sorting",
-			key:  "name",
+			name:          "Success with name sorting",
+			sortFields:    []sortField{{key: "name", order: 1}},
+			expectedStage: bson.M{"$sort": bson.D{{Key: "name", Value: 1}, {Key: "_id", Value: 1}}},
 		},
 		{
-			name: "Success with email sorting",
-			key:  "email",
+			name:       "Success with multiple keys",
+			sortFields: []sortField{{key: "timeM", order: -1}, {key: "email", order: 1}},
+			expectedStage: bson.M{"$sort": bson.D{
+				{Key: "timeM", Value: -1},
+				{Key: "email", Value: 1},
+				{Key: "_id", Value: 1},
+			}},
 		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			expectedStage := bson.M{"$sort": bson.M{tc.key: 1}}
-
-			require.Equal(t, expectedStage, getSortStage(tc.key))
+			require.Equal(t, tc.expectedStage, getSortStage(tc.sortFields))
 		},
 		)
 	}
 }
+
+func TestUnitSortingGetSortFields(t *testing.T) {
+	testCases := []struct {
+		name               string
+		sorting            Sorting
+		expectedSortFields []sortField
+		expectedError      error
+	}{
+		{
+			name:               "Success with default key",
+			sorting:            Sorting{},
+			expectedSortFields: []sortField{{key: "name", order: 1}},
+		},
+		{
+			name:    "Success with repeated keys and orders",
+			sorting: Sorting{Sort: []string{"timeM", "createdAt"}, Order: []string{"desc"}},
+			expectedSortFields: []sortField{
+				{key: "timeM", order: -1},
+				{key: "createdAt", order: 1},
+			},
+		},
+		{
+			name:    "Success with comma-separated keys and orders",
+			sorting: Sorting{Sort: []string{"category,name"}, Order: []string{"asc,desc"}},
+			expectedSortFields: []sortField{
+				{key: "category", order: 1},
+				{key: "name", order: -1},
+			},
+		},
+		{
+			name:          "Fail with unknown key",
+			sorting:       Sorting{Sort: []string{"password"}},
+			expectedError: ErrInvalidSort,
+		},
+		{
+			name:          "Fail with invalid order",
+			sorting:       Sorting{Sort: []string{"name"}, Order: []string{"up"}},
+			expectedError: ErrInvalidSort,
+		},
+		{
+			name:          "Fail with duplicate key",
+			sorting:       Sorting{Sort: []string{"name", "name"}},
+			expectedError: ErrInvalidSort,
+		},
+		{
+			name:          "Fail with more orders than keys",
+			sorting:       Sorting{Sort: []string{"name"}, Order: []string{"asc", "desc"}},
+			expectedError: ErrInvalidSort,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sortFields, err := tc.sorting.getSortFields(RecipeSortKeys, "name")
+			if tc.expectedError != nil {
+				require.ErrorIs(t, err, tc.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedSortFields, sortFields)
+		})
+	}
+}
+
+func TestUnitPaginationGetPageStages(t *testing.T) {
+	sortFields := []sortField{{key: "name", order: 1}}
+	lookupStage := bson.M{"$lookup": bson.M{"from": "users"}}
+	sortStage := getSortStage(sortFields)
+
+	t.Run("Skips documents with page_id before the lookups", func(t *testing.T) {
+		pagination := Pagination{PageID: 3, PageSize: 10}
+
+		expectedStages := []bson.M{sortStage, {"$skip": int64(20)}, {"$limit": int64(11)}, lookupStage}
+
+		stages, err := pagination.getPageStages(sortFields, nil, lookupStage)
+		require.NoError(t, err)
+		require.Equal(t, expectedStages, stages)
+	})
+
+	t.Run("Matches documents after the cursor before the lookups", func(t *testing.T) {
+		id := primitive.NewObjectID()
+		document, err := bson.Marshal(bson.M{"_id": id, "name": "Pancakes"})
+		require.NoError(t, err)
+
+		encodedCursor, err := encodePageCursor(sortFields, document)
+		require.NoError(t, err)
+
+		pagination := Pagination{Cursor: encodedCursor, PageSize: 10}
+
+		cursor, err := decodePageCursor(encodedCursor, sortFields)
+		require.NoError(t, err)
+
+		expectedStages := []bson.M{cursor.getMatchStage(), sortStage, {"$limit": int64(11)}, lookupStage}
+
+		stages, err := pagination.getPageStages(sortFields, nil, lookupStage)
+		require.NoError(t, err)
+		require.Equal(t, expectedStages, stages)
+	})
+
+	t.Run("Runs the lookups first with a computed sort key", func(t *testing.T) {
+		pagination := Pagination{PageID: 1, PageSize: 10}
+
+		expectedStages := []bson.M{lookupStage, sortStage, {"$skip": int64(0)}, {"$limit": int64(11)}}
+
+		stages, err := pagination.getPageStages(sortFields, []string{"name"}, lookupStage)
+		require.NoError(t, err)
+		require.Equal(t, expectedStages, stages)
+	})
+
+	t.Run("Matches documents after the cursor after the lookups with a computed sort key", func(t *testing.T) {
+		document, err := bson.Marshal(bson.M{"_id": primitive.NewObjectID(), "name": "Pancakes"})
+		require.NoError(t, err)
+
+		encodedCursor, err := encodePageCursor(sortFields, document)
+		require.NoError(t, err)
+
+		cursor, err := decodePageCursor(encodedCursor, sortFields)
+		require.NoError(t, err)
+
+		pagination := Pagination{Cursor: encodedCursor, PageSize: 10}
+
+		expectedStages := []bson.M{lookupStage, cursor.getMatchStage(), sortStage, {"$limit": int64(11)}}
+
+		stages, err := pagination.getPageStages(sortFields, []string{"name"}, lookupStage)
+		require.NoError(t, err)
+		require.Equal(t, expectedStages, stages)
+	})
+
+	t.Run("Fails with invalid cursor", func(t *testing.T) {
+		pagination := Pagination{Cursor: "invalid", PageSize: 10}
+
+		_, err := pagination.getPageStages(sortFields, nil, lookupStage)
+		require.ErrorIs(t, err, ErrInvalidCursor)
+	})
+}
@@ -0,0 +1,36 @@
+package db
+
+import (
+	"errors"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var (
+	ErrNotFound      = errors.New("document not found")
+	ErrDuplicateKey  = errors.New("document with the same unique key already exists")
+	ErrInvalidID     = errors.New("invalid document id")
+	ErrReferenced    = errors.New("document is still referenced by other documents")
+	ErrInvalidSort   = errors.New("invalid sorting")
+	ErrInvalidCursor = errors.New("invalid cursor")
+)
+
+// wrapMongoError translates errors of the mongo driver into the errors of this package,
+// so callers can check them with errors.Is. Unknown errors are returned unchanged.
+func wrapMongoError(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, mongo.ErrNoDocuments):
+		return fmt.Errorf("%w: %w", ErrNotFound, err)
+	case mongo.IsDuplicateKeyError(err):
+		return fmt.Errorf("%w: %w", ErrDuplicateKey, err)
+	default:
+		return err
+	}
+}
+
+func newInvalidIDError(idName string, id string, err error) error {
+	return fmt.Errorf("%w: failed to parse %s %s: %w", ErrInvalidID, idName, id, err)
+}
@@ -0,0 +1,64 @@
+package db
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestUnitWrapMongoError(t *testing.T) {
+	duplicateKeyError := mongo.WriteException{
+		WriteErrors: []mongo.WriteError{{Code: 11000, Message: "E11000 duplicate key error"}},
+	}
+	otherError := fmt.Errorf("connection refused")
+
+	testCases := []struct {
+		name        string
+		err         error
+		expectedErr error
+	}{
+		{
+			name:        "No error",
+			err:         nil,
+			expectedErr: nil,
+		},
+		{
+			name:        "No documents",
+			err:         mongo.ErrNoDocuments,
+			expectedErr: ErrNotFound,
+		},
+		{
+			name:        "Duplicate key",
+			err:         duplicateKeyError,
+			expectedErr: ErrDuplicateKey,
+		},
+		{
+			name:        "Unknown error",
+			err:         otherError,
+			expectedErr: otherError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := wrapMongoError(tc.err)
+
+			if tc.expectedErr == nil {
+				require.NoError(t, err)
+				return
+			}
+
+			require.ErrorIs(t, err, tc.expectedErr)
+			require.Contains(t, err.Error(), tc.err.Error())
+		})
+	}
+}
+
+func TestUnitNewInvalidIDError(t *testing.T) {
+	err := newInvalidIDError("recipeID", "test", fmt.Errorf("the provided hex string is not a valid ObjectID"))
+
+	require.ErrorIs(t, err, ErrInvalidID)
+	require.Contains(t, err.Error(), "recipeID test")
+}
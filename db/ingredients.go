@@ -0,0 +1,450 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const defaultAutocompleteLimit = 10
+
+var catalogueIngredientProjectStage = bson.M{"$project": bson.M{
+	"_id":          1,
+	"name":         1,
+	"aliases":      1,
+	"defaultUnit":  1,
+	"pieceWeightG": 1,
+	"nutrients":    1,
+	"createdAt":    1,
+	"modifiedAt":   1,
+}}
+
+var catalogueIngredientIndexModels = []mongo.IndexModel{
+	{
+		Keys:    bson.M{"name": 1},
+		Options: options.Index().SetUnique(true),
+	},
+	// Index supporting the autocomplete and the linking of recipe ingredients by alias
+	{Keys: bson.M{"aliases": 1}},
+}
+
+func (store *MongoDBStore) CreateCatalogueIngredient(ctx context.Context, ingredient CatalogueIngredientToCreate) (primitive.ObjectID, error) {
+	name := normalizeName(ingredient.Name)
+
+	insertData := bson.M{
+		"name":         name,
+		"aliases":      getAliases(name, ingredient.Aliases),
+		"defaultUnit":  ingredient.DefaultUnit,
+		"pieceWeightG": ingredient.PieceWeightG,
+		"nutrients":    ingredient.Nutrients,
+		"createdAt":    time.Now().Unix(),
+		"modifiedAt":   time.Now().Unix(),
+	}
+
+	insertResult, err := store.ingredientCollection.InsertOne(ctx, insertData)
+	if err != nil {
+		log.Err(err).Msgf("failed to insert catalogue ingredient with name %s", name)
+		return primitive.NilObjectID, wrapMongoError(err)
+	}
+
+	ingredientID := insertResult.InsertedID.(primitive.ObjectID)
+
+	return ingredientID, nil
+}
+
+func (store *MongoDBStore) GetAllCatalogueIngredients(ctx context.Context, pagination Pagination, sorting Sorting) ([]CatalogueIngredient, PageInfo, error) {
+	var ingredients []CatalogueIngredient
+
+	sortFields, err := sorting.getSortFields(CatalogueIngredientSortKeys, "name")
+	if err != nil {
+		log.Err(err).Msg("failed to get sort fields for catalogue ingredients")
+		return ingredients, PageInfo{}, err
+	}
+
+	ingredients, pageInfo, err := aggregatePage[CatalogueIngredient](ctx, store.ingredientCollection, nil, pagination, sortFields, nil, catalogueIngredientProjectStage)
+	if err != nil {
+		log.Err(err).Msg("failed to aggregate catalogue ingredient documents")
+		return ingredients, PageInfo{}, err
+	}
+
+	return ingredients, pageInfo, nil
+}
+
+// AutocompleteCatalogueIngredients returns the catalogue ingredients, whose name or one of whose
+// aliases starts with the query. They are sorted by name.
+func (store *MongoDBStore) AutocompleteCatalogueIngredients(ctx context.Context, autocomplete IngredientAutocomplete) ([]CatalogueIngredient, error) {
+	ingredients := []CatalogueIngredient{}
+
+	prefixRegex := getPrefixRegex(normalizeName(autocomplete.Query))
+	filter := bson.M{"$or": bson.A{
+		bson.M{"name": prefixRegex},
+		bson.M{"aliases": prefixRegex},
+	}}
+
+	limit := autocomplete.Limit
+	if limit < 1 {
+		limit = defaultAutocompleteLimit
+	}
+
+	findOptions := options.Find()
+	findOptions.SetSort(bson.D{{Key: "name", Value: 1}})
+	findOptions.SetLimit(limit)
+
+	cursor, err := store.ingredientCollection.Find(ctx, filter, findOptions)
+	if err != nil {
+		log.Err(err).Msgf("failed to find catalogue ingredients starting with %s", autocomplete.Query)
+		return ingredients, err
+	}
+	defer cursor.Close(ctx)
+
+	if err = cursor.All(ctx, &ingredients); err != nil {
+		log.Err(err).Msg("failed to parse catalogue ingredient documents")
+		return ingredients, err
+	}
+
+	return ingredients, nil
+}
+
+func (store *MongoDBStore) GetCatalogueIngredientByID(ctx context.Context, ingredientID string) (CatalogueIngredient, error) {
+	var ingredient CatalogueIngredient
+
+	primitiveIngredientID, err := primitive.ObjectIDFromHex(ingredientID)
+	if err != nil {
+		log.Err(err).Msgf("failed to parse ingredientID %s to primitive ObjectID", ingredientID)
+		return ingredient, newInvalidIDError("ingredientID", ingredientID, err)
+	}
+
+	err = store.ingredientCollection.FindOne(ctx, bson.M{"_id": primitiveIngredientID}).Decode(&ingredient)
+	if err != nil {
+		log.Err(err).Msgf("failed to find catalogue ingredient with ingredientID %s", ingredientID)
+		return ingredient, wrapMongoError(err)
+	}
+
+	return ingredient, nil
+}
+
+// GetCatalogueIngredientsByIDs returns the catalogue ingredients, which match the IDs. IDs without
+// a catalogue ingredient are skipped.
+func (store *MongoDBStore) GetCatalogueIngredientsByIDs(ctx context.Context, ingredientIDs []string) ([]CatalogueIngredient, error) {
+	ingredients := []CatalogueIngredient{}
+
+	primitiveIngredientIDs := bson.A{}
+	for _, ingredientID := range ingredientIDs {
+		primitiveIngredientID, err := primitive.ObjectIDFromHex(ingredientID)
+		if err != nil {
+			log.Err(err).Msgf("failed to parse ingredientID %s to primitive ObjectID", ingredientID)
+			return ingredients, newInvalidIDError("ingredientID", ingredientID, err)
+		}
+		primitiveIngredientIDs = append(primitiveIngredientIDs, primitiveIngredientID)
+	}
+
+	if len(primitiveIngredientIDs) == 0 {
+		return ingredients, nil
+	}
+
+	cursor, err := store.ingredientCollection.Find(ctx, bson.M{"_id": bson.M{"$in": primitiveIngredientIDs}})
+	if err != nil {
+		log.Err(err).Msg("failed to find catalogue ingredients by their IDs")
+		return ingredients, err
+	}
+	defer cursor.Close(ctx)
+
+	if err = cursor.All(ctx, &ingredients); err != nil {
+		log.Err(err).Msg("failed to parse catalogue ingredient documents")
+		return ingredients, err
+	}
+
+	return ingredients, nil
+}
+
+func (store *MongoDBStore) UpdateCatalogueIngredientByID(ctx context.Context, ingredientID string, ingredientUpdate CatalogueIngredientUpdate) (int64, error) {
+	primitiveIngredientID, err := primitive.ObjectIDFromHex(ingredientID)
+	if err != nil {
+		log.Err(err).Msgf("failed to parse ingredientID %s to primitive ObjectID", ingredientID)
+		return 0, newInvalidIDError("ingredientID", ingredientID, err)
+	}
+
+	filter := bson.M{
+		"_id": primitiveIngredientID,
+	}
+
+	update := bson.M{
+		"$set": bson.M{"modifiedAt": time.Now().Unix()},
+	}
+	name := normalizeName(ingredientUpdate.Name)
+	if name != "" {
+		update["$set"].(bson.M)["name"] = name
+	}
+	// Aliases are only recomputed, if a name is patched, which is then removed from the patched
+	// aliases or pulled from the existing ones.
+	if name != "" && len(ingredientUpdate.Aliases) != 0 {
+		update["$set"].(bson.M)["aliases"] = getAliases(name, ingredientUpdate.Aliases)
+	} else if name != "" {
+		update["$pull"] = bson.M{"aliases": name}
+	}
+	if ingredientUpdate.DefaultUnit != "" {
+		update["$set"].(bson.M)["defaultUnit"] = ingredientUpdate.DefaultUnit
+	}
+	if ingredientUpdate.PieceWeightG != 0 {
+		update["$set"].(bson.M)["pieceWeightG"] = ingredientUpdate.PieceWeightG
+	}
+	if ingredientUpdate.Nutrients != nil {
+		update["$set"].(bson.M)["nutrients"] = ingredientUpdate.Nutrients
+	}
+
+	updateResult, err := store.ingredientCollection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		log.Err(err).Msgf("failed to update catalogue ingredient with ingredientID %s", ingredientID)
+		return 0, wrapMongoError(err)
+	}
+
+	if updateResult.MatchedCount < 1 {
+		log.Info().Msgf("failed to find catalogue ingredient with ingredientID %s", ingredientID)
+	}
+
+	modifiedCount := updateResult.ModifiedCount
+	if modifiedCount < 1 {
+		log.Info().Msgf("did not update catalogue ingredient with ingredientID %s", ingredientID)
+	}
+
+	return modifiedCount, err
+}
+
+func (store *MongoDBStore) DeleteCatalogueIngredientByID(ctx context.Context, ingredientID string) (int64, error) {
+	primitiveIngredientID, err := primitive.ObjectIDFromHex(ingredientID)
+	if err != nil {
+		log.Err(err).Msgf("failed to parse ingredientID %s to primitive ObjectID", ingredientID)
+		return 0, newInvalidIDError("ingredientID", ingredientID, err)
+	}
+
+	if err = checkReferencesOfDocument(ctx, store.recipeCollection, "ingredients.ingredientId", primitiveIngredientID); err != nil {
+		return 0, err
+	}
+	for _, foreignKey := range []string{"ingredientId", "substituteId"} {
+		if err = checkReferencesOfDocument(ctx, store.substitutionCollection, foreignKey, primitiveIngredientID); err != nil {
+			return 0, err
+		}
+	}
+
+	filter := bson.M{
+		"_id": primitiveIngredientID,
+	}
+
+	deleteResult, err := store.ingredientCollection.DeleteOne(ctx, filter)
+	if err != nil {
+		log.Err(err).Msgf("failed to delete catalogue ingredient with ingredientID %s", ingredientID)
+		return 0, err
+	}
+
+	deleteCount := deleteResult.DeletedCount
+	if deleteCount < 1 {
+		log.Info().Msgf("catalogue ingredient with ingredientID %s was not deleted", ingredientID)
+	}
+
+	return deleteCount, nil
+}
+
+// ImportCatalogueIngredients inserts the catalogue ingredients, whose names don't exist yet, and
+// updates the nutrients and piece weights of the existing ones. Aliases are added to the existing
+// aliases and the default unit is only set for new catalogue ingredients, so curated entries
+// are kept.
+func (store *MongoDBStore) ImportCatalogueIngredients(ctx context.Context, ingredients []CatalogueIngredientToCreate) (CatalogueImportResult, error) {
+	var importResult CatalogueImportResult
+
+	if len(ingredients) == 0 {
+		return importResult, nil
+	}
+
+	writeModels := []mongo.WriteModel{}
+	for _, ingredient := range ingredients {
+		name := normalizeName(ingredient.Name)
+
+		update := bson.M{
+			"$set": bson.M{
+				"nutrients":  ingredient.Nutrients,
+				"modifiedAt": time.Now().Unix(),
+			},
+			"$setOnInsert": bson.M{
+				"defaultUnit": ingredient.DefaultUnit,
+				"createdAt":   time.Now().Unix(),
+			},
+			"$addToSet": bson.M{"aliases": bson.M{"$each": getAliases(name, ingredient.Aliases)}},
+		}
+		if ingredient.PieceWeightG != 0 {
+			update["$set"].(bson.M)["pieceWeightG"] = ingredient.PieceWeightG
+		}
+
+		writeModels = append(writeModels, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"name": name}).
+			SetUpdate(update).
+			SetUpsert(true))
+	}
+
+	bulkWriteResult, err := store.ingredientCollection.BulkWrite(ctx, writeModels, options.BulkWrite().SetOrdered(false))
+	if err != nil {
+		log.Err(err).Msg("failed to import catalogue ingredients")
+		return importResult, wrapMongoError(err)
+	}
+
+	importResult.InsertedCount = bulkWriteResult.UpsertedCount
+	importResult.UpdatedCount = bulkWriteResult.ModifiedCount
+
+	return importResult, nil
+}
+
+// linkIngredients returns the documents of the ingredients of a recipe, which are linked to the
+// ingredient catalogue. Ingredients with an ingredientId keep it, if the catalogue ingredient
+// exists. All other ingredients are linked to the catalogue ingredient, whose name or alias
+// matches their name. Ingredients without a match are stored unlinked, so the catalogue does not
+// need to be complete.
+func (store *MongoDBStore) linkIngredients(ctx context.Context, ingredients []Ingredient) (bson.A, error) {
+	if err := store.checkCatalogueIngredientIDs(ctx, ingredients); err != nil {
+		return nil, err
+	}
+
+	names := []string{}
+	for _, ingredient := range ingredients {
+		name := normalizeName(ingredient.Name)
+		if ingredient.IngredientID == "" && !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+
+	catalogueIDs := map[string]primitive.ObjectID{}
+	if len(names) > 0 {
+		filter := bson.M{"$or": bson.A{
+			bson.M{"name": bson.M{"$in": names}},
+			bson.M{"aliases": bson.M{"$in": names}},
+		}}
+
+		cursor, err := store.ingredientCollection.Find(ctx, filter)
+		if err != nil {
+			log.Err(err).Msg("failed to find catalogue ingredients to link")
+			return nil, err
+		}
+		defer cursor.Close(ctx)
+
+		var catalogueIngredients []CatalogueIngredient
+		if err = cursor.All(ctx, &catalogueIngredients); err != nil {
+			log.Err(err).Msg("failed to parse catalogue ingredient documents")
+			return nil, err
+		}
+
+		catalogueIDs, err = getCatalogueIDs(catalogueIngredients)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return getIngredientDocuments(ingredients, catalogueIDs)
+}
+
+// checkCatalogueIngredientIDs returns ErrNotFound, if an ingredientId of the ingredients does not
+// belong to a catalogue ingredient.
+func (store *MongoDBStore) checkCatalogueIngredientIDs(ctx context.Context, ingredients []Ingredient) error {
+	primitiveIngredientIDs := []primitive.ObjectID{}
+	for _, ingredient := range ingredients {
+		if ingredient.IngredientID == "" {
+			continue
+		}
+
+		primitiveIngredientID, err := primitive.ObjectIDFromHex(ingredient.IngredientID)
+		if err != nil {
+			log.Err(err).Msgf("failed to parse ingredientID %s to primitive ObjectID", ingredient.IngredientID)
+			return newInvalidIDError("ingredientID", ingredient.IngredientID, err)
+		}
+		if !slices.Contains(primitiveIngredientIDs, primitiveIngredientID) {
+			primitiveIngredientIDs = append(primitiveIngredientIDs, primitiveIngredientID)
+		}
+	}
+
+	if len(primitiveIngredientIDs) == 0 {
+		return nil
+	}
+
+	count, err := store.ingredientCollection.CountDocuments(ctx, bson.M{"_id": bson.M{"$in": primitiveIngredientIDs}})
+	if err != nil {
+		log.Err(err).Msg("failed to count catalogue ingredients of the ingredients")
+		return err
+	}
+
+	if count < int64(len(primitiveIngredientIDs)) {
+		log.Error().Msg("failed to find the catalogue ingredients of all ingredientIDs")
+		return fmt.Errorf("%w: failed to find the catalogue ingredients of all ingredientIDs", ErrNotFound)
+	}
+
+	return nil
+}
+
+// getCatalogueIDs maps the names and aliases of the catalogue ingredients to their IDs. Names
+// take precedence over aliases of other catalogue ingredients.
+func getCatalogueIDs(catalogueIngredients []CatalogueIngredient) (map[string]primitive.ObjectID, error) {
+	primitiveIngredientIDs := make([]primitive.ObjectID, len(catalogueIngredients))
+	catalogueIDs := map[string]primitive.ObjectID{}
+	for i, catalogueIngredient := range catalogueIngredients {
+		primitiveIngredientID, err := primitive.ObjectIDFromHex(catalogueIngredient.ID)
+		if err != nil {
+			return nil, newInvalidIDError("ingredientID", catalogueIngredient.ID, err)
+		}
+		primitiveIngredientIDs[i] = primitiveIngredientID
+
+		for _, alias := range catalogueIngredient.Aliases {
+			if _, ok := catalogueIDs[alias]; !ok {
+				catalogueIDs[alias] = primitiveIngredientID
+			}
+		}
+	}
+
+	for i, catalogueIngredient := range catalogueIngredients {
+		catalogueIDs[catalogueIngredient.Name] = primitiveIngredientIDs[i]
+	}
+
+	return catalogueIDs, nil
+}
+
+// getIngredientDocuments converts the ingredients into documents with the ingredientId stored as
+// ObjectID, so recipes can be matched and counted by their catalogue ingredients.
+func getIngredientDocuments(ingredients []Ingredient, catalogueIDs map[string]primitive.ObjectID) (bson.A, error) {
+	documents := bson.A{}
+	for _, ingredient := range ingredients {
+		document := bson.M{
+			"name":   ingredient.Name,
+			"amount": ingredient.Amount,
+			"unit":   ingredient.Unit,
+		}
+
+		if ingredient.IngredientID != "" {
+			primitiveIngredientID, err := primitive.ObjectIDFromHex(ingredient.IngredientID)
+			if err != nil {
+				log.Err(err).Msgf("failed to parse ingredientID %s to primitive ObjectID", ingredient.IngredientID)
+				return nil, newInvalidIDError("ingredientID", ingredient.IngredientID, err)
+			}
+			document["ingredientId"] = primitiveIngredientID
+		} else if primitiveIngredientID, ok := catalogueIDs[normalizeName(ingredient.Name)]; ok {
+			document["ingredientId"] = primitiveIngredientID
+		}
+
+		documents = append(documents, document)
+	}
+
+	return documents, nil
+}
+
+// getAliases normalizes the aliases and removes the name of the catalogue ingredient from them.
+func getAliases(name string, aliases []string) []string {
+	return slices.DeleteFunc(normalizeTags(aliases), func(alias string) bool {
+		return alias == name
+	})
+}
+
+func getPrefixRegex(value string) primitive.Regex {
+	return primitive.Regex{Pattern: fmt.Sprintf("^%s", regexp.QuoteMeta(value)), Options: "i"}
+}
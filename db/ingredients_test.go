@@ -0,0 +1,377 @@
+package db
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/PfMartin/wegonice-api/util"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func createRandomCatalogueIngredient(t *testing.T, store *MongoDBStore) CatalogueIngredient {
+	t.Helper()
+
+	ingredient := CatalogueIngredientToCreate{
+		Name:        util.RandomString(10),
+		Aliases:     []string{util.RandomString(10), util.RandomString(10)},
+		DefaultUnit: Grams,
+	}
+
+	insertedIngredientID, err := store.CreateCatalogueIngredient(context.Background(), ingredient)
+	require.NoError(t, err)
+	require.False(t, insertedIngredientID.IsZero())
+
+	return CatalogueIngredient{
+		ID:          insertedIngredientID.Hex(),
+		Name:        ingredient.Name,
+		Aliases:     ingredient.Aliases,
+		DefaultUnit: ingredient.DefaultUnit,
+	}
+}
+
+func TestUnitCreateCatalogueIngredient(t *testing.T) {
+	store := getMongoDBStore(t)
+
+	t.Run("Creates a normalized ingredient and throws an error when the same name is created again", func(t *testing.T) {
+		name := util.RandomString(10)
+		ingredientID, err := store.CreateCatalogueIngredient(context.Background(), CatalogueIngredientToCreate{
+			Name:    " " + strings.ToUpper(name) + " ",
+			Aliases: []string{"Wheat  Flour", name},
+		})
+		require.NoError(t, err)
+
+		gotIngredient, err := store.GetCatalogueIngredientByID(context.Background(), ingredientID.Hex())
+		require.NoError(t, err)
+		require.Equal(t, name, gotIngredient.Name)
+		require.Equal(t, []string{"wheat flour"}, gotIngredient.Aliases)
+
+		_, err = store.CreateCatalogueIngredient(context.Background(), CatalogueIngredientToCreate{Name: name})
+		require.ErrorIs(t, err, ErrDuplicateKey)
+	})
+}
+
+func TestUnitGetAllCatalogueIngredients(t *testing.T) {
+	store := getMongoDBStore(t)
+
+	for i := 0; i < 10; i++ {
+		_ = createRandomCatalogueIngredient(t, store)
+	}
+
+	pagination := Pagination{
+		PageID:   1,
+		PageSize: 5,
+	}
+
+	t.Run("Gets all catalogue ingredients sorted by name", func(t *testing.T) {
+		ingredients, pageInfo, err := store.GetAllCatalogueIngredients(context.Background(), pagination, Sorting{})
+		require.NoError(t, err)
+		require.Equal(t, int(pagination.PageSize), len(ingredients))
+		require.GreaterOrEqual(t, pageInfo.TotalCount, int64(10))
+
+		for i := 1; i < len(ingredients); i++ {
+			require.LessOrEqual(t, ingredients[i-1].Name, ingredients[i].Name)
+		}
+	})
+}
+
+func TestUnitAutocompleteCatalogueIngredients(t *testing.T) {
+	store := getMongoDBStore(t)
+
+	ingredient := createRandomCatalogueIngredient(t, store)
+
+	testCases := []struct {
+		name         string
+		autocomplete IngredientAutocomplete
+		hasMatch     bool
+	}{
+		{
+			name:         "Matches the beginning of the name case-insensitive",
+			autocomplete: IngredientAutocomplete{Query: strings.ToUpper(ingredient.Name[:6])},
+			hasMatch:     true,
+		},
+		{
+			name:         "Matches the beginning of an alias",
+			autocomplete: IngredientAutocomplete{Query: ingredient.Aliases[1][:6], Limit: 50},
+			hasMatch:     true,
+		},
+		{
+			name:         "Does not match the middle of the name",
+			autocomplete: IngredientAutocomplete{Query: ingredient.Name[4:]},
+			hasMatch:     false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ingredients, err := store.AutocompleteCatalogueIngredients(context.Background(), tc.autocomplete)
+			require.NoError(t, err)
+
+			var gotIDs []string
+			for _, gotIngredient := range ingredients {
+				gotIDs = append(gotIDs, gotIngredient.ID)
+			}
+
+			if tc.hasMatch {
+				require.Contains(t, gotIDs, ingredient.ID)
+			} else {
+				require.NotContains(t, gotIDs, ingredient.ID)
+			}
+		})
+	}
+}
+
+func TestUnitGetCatalogueIngredientByID(t *testing.T) {
+	store := getMongoDBStore(t)
+
+	createdIngredient := createRandomCatalogueIngredient(t, store)
+
+	testCases := []struct {
+		name         string
+		ingredientID string
+		expectedErr  error
+	}{
+		{
+			name:         "Success",
+			ingredientID: createdIngredient.ID,
+		},
+		{
+			name:         "Fail with invalid ingredientID",
+			ingredientID: "test",
+			expectedErr:  ErrInvalidID,
+		},
+		{
+			name:         "Fail with ingredientID not found",
+			ingredientID: "659c00751f7178dff690270d",
+			expectedErr:  ErrNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			gotIngredient, err := store.GetCatalogueIngredientByID(context.Background(), tc.ingredientID)
+
+			if tc.expectedErr != nil {
+				require.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, createdIngredient.ID, gotIngredient.ID)
+			require.Equal(t, createdIngredient.Name, gotIngredient.Name)
+			require.Equal(t, createdIngredient.Aliases, gotIngredient.Aliases)
+			require.Equal(t, createdIngredient.DefaultUnit, gotIngredient.DefaultUnit)
+		})
+	}
+}
+
+func TestUnitGetCatalogueIngredientsByIDs(t *testing.T) {
+	store := getMongoDBStore(t)
+
+	firstIngredient := createRandomCatalogueIngredient(t, store)
+	secondIngredient := createRandomCatalogueIngredient(t, store)
+
+	ingredients, err := store.GetCatalogueIngredientsByIDs(context.Background(), []string{firstIngredient.ID, secondIngredient.ID, "659c00751f7178dff690270d"})
+	require.NoError(t, err)
+	require.Len(t, ingredients, 2)
+
+	ingredients, err = store.GetCatalogueIngredientsByIDs(context.Background(), []string{})
+	require.NoError(t, err)
+	require.Empty(t, ingredients)
+
+	_, err = store.GetCatalogueIngredientsByIDs(context.Background(), []string{"test"})
+	require.ErrorIs(t, err, ErrInvalidID)
+}
+
+func TestUnitImportCatalogueIngredients(t *testing.T) {
+	store := getMongoDBStore(t)
+
+	existingIngredient := createRandomCatalogueIngredient(t, store)
+	newName := util.RandomString(10)
+	nutrients := &Nutrients{KCal: 89, Protein: 1.1, Fat: 0.3, Carbs: 22.8, Fibre: 2.6}
+
+	importResult, err := store.ImportCatalogueIngredients(context.Background(), []CatalogueIngredientToCreate{
+		{Name: strings.ToUpper(existingIngredient.Name), Aliases: []string{"imported alias"}, DefaultUnit: Piece, PieceWeightG: 118, Nutrients: nutrients},
+		{Name: newName, DefaultUnit: Piece, Nutrients: nutrients},
+	})
+	require.NoError(t, err)
+	require.Equal(t, CatalogueImportResult{InsertedCount: 1, UpdatedCount: 1}, importResult)
+
+	updatedIngredient, err := store.GetCatalogueIngredientByID(context.Background(), existingIngredient.ID)
+	require.NoError(t, err)
+	require.Equal(t, append(existingIngredient.Aliases, "imported alias"), updatedIngredient.Aliases)
+	require.Equal(t, existingIngredient.DefaultUnit, updatedIngredient.DefaultUnit)
+	require.Equal(t, 118.0, updatedIngredient.PieceWeightG)
+	require.Equal(t, nutrients, updatedIngredient.Nutrients)
+
+	insertedIngredients, err := store.AutocompleteCatalogueIngredients(context.Background(), IngredientAutocomplete{Query: newName})
+	require.NoError(t, err)
+	require.Len(t, insertedIngredients, 1)
+	require.Equal(t, Piece, insertedIngredients[0].DefaultUnit)
+	require.Equal(t, []string{}, insertedIngredients[0].Aliases)
+}
+
+func TestUnitUpdateCatalogueIngredientByID(t *testing.T) {
+	store := getMongoDBStore(t)
+
+	createdIngredient := createRandomCatalogueIngredient(t, store)
+
+	ingredientUpdate := CatalogueIngredientUpdate{
+		Name:        util.RandomString(10),
+		Aliases:     []string{util.RandomString(10)},
+		DefaultUnit: Milliliters,
+	}
+
+	modifiedCount, err := store.UpdateCatalogueIngredientByID(context.Background(), createdIngredient.ID, ingredientUpdate)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), modifiedCount)
+
+	gotIngredient, err := store.GetCatalogueIngredientByID(context.Background(), createdIngredient.ID)
+	require.NoError(t, err)
+	require.Equal(t, ingredientUpdate.Name, gotIngredient.Name)
+	require.Equal(t, ingredientUpdate.Aliases, gotIngredient.Aliases)
+	require.Equal(t, ingredientUpdate.DefaultUnit, gotIngredient.DefaultUnit)
+
+	t.Run("Removes the new name from the existing aliases", func(t *testing.T) {
+		_, err := store.UpdateCatalogueIngredientByID(context.Background(), createdIngredient.ID, CatalogueIngredientUpdate{Name: ingredientUpdate.Aliases[0]})
+		require.NoError(t, err)
+
+		gotIngredient, err := store.GetCatalogueIngredientByID(context.Background(), createdIngredient.ID)
+		require.NoError(t, err)
+		require.Equal(t, ingredientUpdate.Aliases[0], gotIngredient.Name)
+		require.Empty(t, gotIngredient.Aliases)
+	})
+
+	_, err = store.UpdateCatalogueIngredientByID(context.Background(), "test", ingredientUpdate)
+	require.ErrorIs(t, err, ErrInvalidID)
+}
+
+func TestUnitDeleteCatalogueIngredientByID(t *testing.T) {
+	store := getMongoDBStore(t)
+	user := createRandomUser(t, store)
+	author := createRandomAuthor(t, store, user.ID)
+
+	createdIngredient := createRandomCatalogueIngredient(t, store)
+	linkedIngredient := createRandomCatalogueIngredient(t, store)
+
+	recipe := createRandomRecipe(t, store, user.ID, author.ID)
+	modifiedCount, err := store.UpdateRecipeByID(context.Background(), recipe.ID, RecipeUpdate{
+		Ingredients: []Ingredient{{Name: strings.ToUpper(linkedIngredient.Aliases[0]), Amount: 100, Unit: Grams}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), modifiedCount)
+
+	linkedRecipe, err := store.GetRecipeByID(context.Background(), recipe.ID)
+	require.NoError(t, err)
+	require.Equal(t, linkedIngredient.ID, linkedRecipe.Ingredients[0].IngredientID)
+	require.Equal(t, strings.ToUpper(linkedIngredient.Aliases[0]), linkedRecipe.Ingredients[0].Name)
+
+	testCases := []struct {
+		name         string
+		ingredientID string
+		expectedErr  error
+		deleteCount  int64
+	}{
+		{
+			name:         "Success",
+			ingredientID: createdIngredient.ID,
+			deleteCount:  1,
+		},
+		{
+			name:         "Fail with invalid ingredientID",
+			ingredientID: "test",
+			expectedErr:  ErrInvalidID,
+		},
+		{
+			name:         "Fail with ingredient linked in a recipe",
+			ingredientID: linkedIngredient.ID,
+			expectedErr:  ErrReferenced,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			deleteCount, err := store.DeleteCatalogueIngredientByID(context.Background(), tc.ingredientID)
+			require.Equal(t, tc.deleteCount, deleteCount)
+
+			if tc.expectedErr != nil {
+				require.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestUnitGetCatalogueIDs(t *testing.T) {
+	flourID := primitive.NewObjectID()
+	wheatFlourID := primitive.NewObjectID()
+
+	catalogueIDs, err := getCatalogueIDs([]CatalogueIngredient{
+		{ID: flourID.Hex(), Name: "flour", Aliases: []string{"wheat flour", "plain flour"}},
+		{ID: wheatFlourID.Hex(), Name: "wheat flour"},
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, map[string]primitive.ObjectID{
+		"flour":       flourID,
+		"plain flour": flourID,
+		"wheat flour": wheatFlourID,
+	}, catalogueIDs)
+
+	_, err = getCatalogueIDs([]CatalogueIngredient{{ID: "test", Name: "flour"}})
+	require.ErrorIs(t, err, ErrInvalidID)
+}
+
+func TestUnitGetIngredientDocuments(t *testing.T) {
+	flourID := primitive.NewObjectID()
+	sugarID := primitive.NewObjectID()
+
+	testCases := []struct {
+		name              string
+		ingredients       []Ingredient
+		expectedDocuments bson.A
+		expectedErr       error
+	}{
+		{
+			name: "Links by name and keeps provided ingredientIds",
+			ingredients: []Ingredient{
+				{Name: " Flour", Amount: 200, Unit: Grams},
+				{Name: "Brown sugar", Amount: 2, Unit: Tablespoon, IngredientID: sugarID.Hex()},
+				{Name: "Salt", Amount: 1, Unit: Pinch},
+			},
+			expectedDocuments: bson.A{
+				bson.M{"name": " Flour", "amount": Amount(200), "unit": Grams, "ingredientId": flourID},
+				bson.M{"name": "Brown sugar", "amount": Amount(2), "unit": Tablespoon, "ingredientId": sugarID},
+				bson.M{"name": "Salt", "amount": Amount(1), "unit": Pinch},
+			},
+		},
+		{
+			name:        "Fail with invalid ingredientID",
+			ingredients: []Ingredient{{Name: "Flour", IngredientID: "test"}},
+			expectedErr: ErrInvalidID,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			documents, err := getIngredientDocuments(tc.ingredients, map[string]primitive.ObjectID{"flour": flourID})
+
+			if tc.expectedErr != nil {
+				require.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedDocuments, documents)
+		})
+	}
+}
+
+func TestUnitGetAliases(t *testing.T) {
+	require.Equal(t, []string{"wheat flour", "plain flour"}, getAliases("flour", []string{"Wheat  Flour", "flour", "plain flour", "wheat flour"}))
+	require.Equal(t, []string{}, getAliases("flour", nil))
+}
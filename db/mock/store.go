@@ -36,6 +36,66 @@ func (m *MockDBStore) EXPECT() *MockDBStoreMockRecorder {
 	return m.recorder
 }
 
+// ActivateUserByID mocks base method.
+func (m *MockDBStore) ActivateUserByID(arg0 context.Context, arg1 string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ActivateUserByID", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ActivateUserByID indicates an expected call of ActivateUserByID.
+func (mr *MockDBStoreMockRecorder) ActivateUserByID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActivateUserByID", reflect.TypeOf((*MockDBStore)(nil).ActivateUserByID), arg0, arg1)
+}
+
+// AutocompleteCatalogueIngredients mocks base method.
+func (m *MockDBStore) AutocompleteCatalogueIngredients(arg0 context.Context, arg1 db.IngredientAutocomplete) ([]db.CatalogueIngredient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AutocompleteCatalogueIngredients", arg0, arg1)
+	ret0, _ := ret[0].([]db.CatalogueIngredient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AutocompleteCatalogueIngredients indicates an expected call of AutocompleteCatalogueIngredients.
+func (mr *MockDBStoreMockRecorder) AutocompleteCatalogueIngredients(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AutocompleteCatalogueIngredients", reflect.TypeOf((*MockDBStore)(nil).AutocompleteCatalogueIngredients), arg0, arg1)
+}
+
+// BlockSessionByID mocks base method.
+func (m *MockDBStore) BlockSessionByID(arg0 context.Context, arg1 string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BlockSessionByID", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BlockSessionByID indicates an expected call of BlockSessionByID.
+func (mr *MockDBStoreMockRecorder) BlockSessionByID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlockSessionByID", reflect.TypeOf((*MockDBStore)(nil).BlockSessionByID), arg0, arg1)
+}
+
+// BlockSessionsByUserID mocks base method.
+func (m *MockDBStore) BlockSessionsByUserID(arg0 context.Context, arg1 string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BlockSessionsByUserID", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BlockSessionsByUserID indicates an expected call of BlockSessionsByUserID.
+func (mr *MockDBStoreMockRecorder) BlockSessionsByUserID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlockSessionsByUserID", reflect.TypeOf((*MockDBStore)(nil).BlockSessionsByUserID), arg0, arg1)
+}
+
 // CreateAuthor mocks base method.
 func (m *MockDBStore) CreateAuthor(arg0 context.Context, arg1 db.AuthorToCreate) (primitive.ObjectID, error) {
 	m.ctrl.T.Helper()
@@ -51,6 +111,21 @@ func (mr *MockDBStoreMockRecorder) CreateAuthor(arg0, arg1 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAuthor", reflect.TypeOf((*MockDBStore)(nil).CreateAuthor), arg0, arg1)
 }
 
+// CreateCatalogueIngredient mocks base method.
+func (m *MockDBStore) CreateCatalogueIngredient(arg0 context.Context, arg1 db.CatalogueIngredientToCreate) (primitive.ObjectID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCatalogueIngredient", arg0, arg1)
+	ret0, _ := ret[0].(primitive.ObjectID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCatalogueIngredient indicates an expected call of CreateCatalogueIngredient.
+func (mr *MockDBStoreMockRecorder) CreateCatalogueIngredient(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCatalogueIngredient", reflect.TypeOf((*MockDBStore)(nil).CreateCatalogueIngredient), arg0, arg1)
+}
+
 // CreateRecipe mocks base method.
 func (m *MockDBStore) CreateRecipe(arg0 context.Context, arg1 db.RecipeToCreate) (primitive.ObjectID, error) {
 	m.ctrl.T.Helper()
@@ -66,6 +141,21 @@ func (mr *MockDBStoreMockRecorder) CreateRecipe(arg0, arg1 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRecipe", reflect.TypeOf((*MockDBStore)(nil).CreateRecipe), arg0, arg1)
 }
 
+// CreateReview mocks base method.
+func (m *MockDBStore) CreateReview(arg0 context.Context, arg1 db.ReviewToCreate) (primitive.ObjectID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateReview", arg0, arg1)
+	ret0, _ := ret[0].(primitive.ObjectID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateReview indicates an expected call of CreateReview.
+func (mr *MockDBStoreMockRecorder) CreateReview(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateReview", reflect.TypeOf((*MockDBStore)(nil).CreateReview), arg0, arg1)
+}
+
 // CreateSession mocks base method.
 func (m *MockDBStore) CreateSession(arg0 context.Context, arg1 db.Session) (primitive.ObjectID, error) {
 	m.ctrl.T.Helper()
@@ -81,6 +171,21 @@ func (mr *MockDBStoreMockRecorder) CreateSession(arg0, arg1 interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSession", reflect.TypeOf((*MockDBStore)(nil).CreateSession), arg0, arg1)
 }
 
+// CreateSubstitution mocks base method.
+func (m *MockDBStore) CreateSubstitution(arg0 context.Context, arg1 db.SubstitutionToCreate) (primitive.ObjectID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSubstitution", arg0, arg1)
+	ret0, _ := ret[0].(primitive.ObjectID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSubstitution indicates an expected call of CreateSubstitution.
+func (mr *MockDBStoreMockRecorder) CreateSubstitution(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSubstitution", reflect.TypeOf((*MockDBStore)(nil).CreateSubstitution), arg0, arg1)
+}
+
 // CreateUser mocks base method.
 func (m *MockDBStore) CreateUser(arg0 context.Context, arg1 db.User) (primitive.ObjectID, error) {
 	m.ctrl.T.Helper()
@@ -111,6 +216,21 @@ func (mr *MockDBStoreMockRecorder) DeleteAuthorByID(arg0, arg1 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAuthorByID", reflect.TypeOf((*MockDBStore)(nil).DeleteAuthorByID), arg0, arg1)
 }
 
+// DeleteCatalogueIngredientByID mocks base method.
+func (m *MockDBStore) DeleteCatalogueIngredientByID(arg0 context.Context, arg1 string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCatalogueIngredientByID", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteCatalogueIngredientByID indicates an expected call of DeleteCatalogueIngredientByID.
+func (mr *MockDBStoreMockRecorder) DeleteCatalogueIngredientByID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCatalogueIngredientByID", reflect.TypeOf((*MockDBStore)(nil).DeleteCatalogueIngredientByID), arg0, arg1)
+}
+
 // DeleteRecipeByID mocks base method.
 func (m *MockDBStore) DeleteRecipeByID(arg0 context.Context, arg1 string) (int64, error) {
 	m.ctrl.T.Helper()
@@ -126,6 +246,36 @@ func (mr *MockDBStoreMockRecorder) DeleteRecipeByID(arg0, arg1 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRecipeByID", reflect.TypeOf((*MockDBStore)(nil).DeleteRecipeByID), arg0, arg1)
 }
 
+// DeleteReviewByID mocks base method.
+func (m *MockDBStore) DeleteReviewByID(arg0 context.Context, arg1 string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteReviewByID", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteReviewByID indicates an expected call of DeleteReviewByID.
+func (mr *MockDBStoreMockRecorder) DeleteReviewByID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteReviewByID", reflect.TypeOf((*MockDBStore)(nil).DeleteReviewByID), arg0, arg1)
+}
+
+// DeleteSubstitutionByID mocks base method.
+func (m *MockDBStore) DeleteSubstitutionByID(arg0 context.Context, arg1 string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSubstitutionByID", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteSubstitutionByID indicates an expected call of DeleteSubstitutionByID.
+func (mr *MockDBStoreMockRecorder) DeleteSubstitutionByID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSubstitutionByID", reflect.TypeOf((*MockDBStore)(nil).DeleteSubstitutionByID), arg0, arg1)
+}
+
 // DeleteUserByID mocks base method.
 func (m *MockDBStore) DeleteUserByID(arg0 context.Context, arg1 string) (int64, error) {
 	m.ctrl.T.Helper()
@@ -142,48 +292,83 @@ func (mr *MockDBStoreMockRecorder) DeleteUserByID(arg0, arg1 interface{}) *gomoc
 }
 
 // GetAllAuthors mocks base method.
-func (m *MockDBStore) GetAllAuthors(arg0 context.Context, arg1 db.Pagination) ([]db.Author, error) {
+func (m *MockDBStore) GetAllAuthors(arg0 context.Context, arg1 db.Pagination, arg2 db.Sorting) ([]db.Author, db.PageInfo, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAllAuthors", arg0, arg1)
+	ret := m.ctrl.Call(m, "GetAllAuthors", arg0, arg1, arg2)
 	ret0, _ := ret[0].([]db.Author)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret1, _ := ret[1].(db.PageInfo)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
 // GetAllAuthors indicates an expected call of GetAllAuthors.
-func (mr *MockDBStoreMockRecorder) GetAllAuthors(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockDBStoreMockRecorder) GetAllAuthors(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllAuthors", reflect.TypeOf((*MockDBStore)(nil).GetAllAuthors), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllAuthors", reflect.TypeOf((*MockDBStore)(nil).GetAllAuthors), arg0, arg1, arg2)
+}
+
+// GetAllCatalogueIngredients mocks base method.
+func (m *MockDBStore) GetAllCatalogueIngredients(arg0 context.Context, arg1 db.Pagination, arg2 db.Sorting) ([]db.CatalogueIngredient, db.PageInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllCatalogueIngredients", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]db.CatalogueIngredient)
+	ret1, _ := ret[1].(db.PageInfo)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetAllCatalogueIngredients indicates an expected call of GetAllCatalogueIngredients.
+func (mr *MockDBStoreMockRecorder) GetAllCatalogueIngredients(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllCatalogueIngredients", reflect.TypeOf((*MockDBStore)(nil).GetAllCatalogueIngredients), arg0, arg1, arg2)
 }
 
 // GetAllRecipes mocks base method.
-func (m *MockDBStore) GetAllRecipes(arg0 context.Context, arg1 db.Pagination) ([]db.Recipe, error) {
+func (m *MockDBStore) GetAllRecipes(arg0 context.Context, arg1 db.Pagination, arg2 db.RecipeFilter, arg3 db.Sorting) ([]db.Recipe, db.PageInfo, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAllRecipes", arg0, arg1)
+	ret := m.ctrl.Call(m, "GetAllRecipes", arg0, arg1, arg2, arg3)
 	ret0, _ := ret[0].([]db.Recipe)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret1, _ := ret[1].(db.PageInfo)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
 // GetAllRecipes indicates an expected call of GetAllRecipes.
-func (mr *MockDBStoreMockRecorder) GetAllRecipes(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockDBStoreMockRecorder) GetAllRecipes(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllRecipes", reflect.TypeOf((*MockDBStore)(nil).GetAllRecipes), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllRecipes", reflect.TypeOf((*MockDBStore)(nil).GetAllRecipes), arg0, arg1, arg2, arg3)
+}
+
+// GetAllSubstitutions mocks base method.
+func (m *MockDBStore) GetAllSubstitutions(arg0 context.Context, arg1 db.Pagination, arg2 db.SubstitutionFilter, arg3 db.Sorting) ([]db.Substitution, db.PageInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllSubstitutions", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]db.Substitution)
+	ret1, _ := ret[1].(db.PageInfo)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetAllSubstitutions indicates an expected call of GetAllSubstitutions.
+func (mr *MockDBStoreMockRecorder) GetAllSubstitutions(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllSubstitutions", reflect.TypeOf((*MockDBStore)(nil).GetAllSubstitutions), arg0, arg1, arg2, arg3)
 }
 
 // GetAllUsers mocks base method.
-func (m *MockDBStore) GetAllUsers(arg0 context.Context, arg1 db.Pagination) ([]db.User, error) {
+func (m *MockDBStore) GetAllUsers(arg0 context.Context, arg1 db.Pagination, arg2 db.Sorting) ([]db.User, db.PageInfo, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAllUsers", arg0, arg1)
+	ret := m.ctrl.Call(m, "GetAllUsers", arg0, arg1, arg2)
 	ret0, _ := ret[0].([]db.User)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret1, _ := ret[1].(db.PageInfo)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
 // GetAllUsers indicates an expected call of GetAllUsers.
-func (mr *MockDBStoreMockRecorder) GetAllUsers(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockDBStoreMockRecorder) GetAllUsers(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllUsers", reflect.TypeOf((*MockDBStore)(nil).GetAllUsers), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllUsers", reflect.TypeOf((*MockDBStore)(nil).GetAllUsers), arg0, arg1, arg2)
 }
 
 // GetAuthorByID mocks base method.
@@ -201,6 +386,52 @@ func (mr *MockDBStoreMockRecorder) GetAuthorByID(arg0, arg1 interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuthorByID", reflect.TypeOf((*MockDBStore)(nil).GetAuthorByID), arg0, arg1)
 }
 
+// GetCatalogueIngredientByID mocks base method.
+func (m *MockDBStore) GetCatalogueIngredientByID(arg0 context.Context, arg1 string) (db.CatalogueIngredient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCatalogueIngredientByID", arg0, arg1)
+	ret0, _ := ret[0].(db.CatalogueIngredient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCatalogueIngredientByID indicates an expected call of GetCatalogueIngredientByID.
+func (mr *MockDBStoreMockRecorder) GetCatalogueIngredientByID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCatalogueIngredientByID", reflect.TypeOf((*MockDBStore)(nil).GetCatalogueIngredientByID), arg0, arg1)
+}
+
+// GetCatalogueIngredientsByIDs mocks base method.
+func (m *MockDBStore) GetCatalogueIngredientsByIDs(arg0 context.Context, arg1 []string) ([]db.CatalogueIngredient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCatalogueIngredientsByIDs", arg0, arg1)
+	ret0, _ := ret[0].([]db.CatalogueIngredient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCatalogueIngredientsByIDs indicates an expected call of GetCatalogueIngredientsByIDs.
+func (mr *MockDBStoreMockRecorder) GetCatalogueIngredientsByIDs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCatalogueIngredientsByIDs", reflect.TypeOf((*MockDBStore)(nil).GetCatalogueIngredientsByIDs), arg0, arg1)
+}
+
+// GetPendingUsers mocks base method.
+func (m *MockDBStore) GetPendingUsers(arg0 context.Context, arg1 db.Pagination) ([]db.User, db.PageInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPendingUsers", arg0, arg1)
+	ret0, _ := ret[0].([]db.User)
+	ret1, _ := ret[1].(db.PageInfo)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetPendingUsers indicates an expected call of GetPendingUsers.
+func (mr *MockDBStoreMockRecorder) GetPendingUsers(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingUsers", reflect.TypeOf((*MockDBStore)(nil).GetPendingUsers), arg0, arg1)
+}
+
 // GetRecipeByID mocks base method.
 func (m *MockDBStore) GetRecipeByID(arg0 context.Context, arg1 string) (db.Recipe, error) {
 	m.ctrl.T.Helper()
@@ -216,6 +447,69 @@ func (mr *MockDBStoreMockRecorder) GetRecipeByID(arg0, arg1 interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecipeByID", reflect.TypeOf((*MockDBStore)(nil).GetRecipeByID), arg0, arg1)
 }
 
+// GetRecipesByAuthorID mocks base method.
+func (m *MockDBStore) GetRecipesByAuthorID(arg0 context.Context, arg1 string, arg2 db.Pagination, arg3 db.Sorting) ([]db.Recipe, db.PageInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecipesByAuthorID", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]db.Recipe)
+	ret1, _ := ret[1].(db.PageInfo)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetRecipesByAuthorID indicates an expected call of GetRecipesByAuthorID.
+func (mr *MockDBStoreMockRecorder) GetRecipesByAuthorID(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecipesByAuthorID", reflect.TypeOf((*MockDBStore)(nil).GetRecipesByAuthorID), arg0, arg1, arg2, arg3)
+}
+
+// GetRecipesByUserID mocks base method.
+func (m *MockDBStore) GetRecipesByUserID(arg0 context.Context, arg1 string, arg2 db.Pagination, arg3 db.Sorting) ([]db.Recipe, db.PageInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecipesByUserID", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]db.Recipe)
+	ret1, _ := ret[1].(db.PageInfo)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetRecipesByUserID indicates an expected call of GetRecipesByUserID.
+func (mr *MockDBStoreMockRecorder) GetRecipesByUserID(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecipesByUserID", reflect.TypeOf((*MockDBStore)(nil).GetRecipesByUserID), arg0, arg1, arg2, arg3)
+}
+
+// GetReviewByID mocks base method.
+func (m *MockDBStore) GetReviewByID(arg0 context.Context, arg1 string) (db.Review, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReviewByID", arg0, arg1)
+	ret0, _ := ret[0].(db.Review)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReviewByID indicates an expected call of GetReviewByID.
+func (mr *MockDBStoreMockRecorder) GetReviewByID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReviewByID", reflect.TypeOf((*MockDBStore)(nil).GetReviewByID), arg0, arg1)
+}
+
+// GetReviewsByRecipeID mocks base method.
+func (m *MockDBStore) GetReviewsByRecipeID(arg0 context.Context, arg1 string, arg2 db.Pagination, arg3 db.Sorting) ([]db.Review, db.PageInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReviewsByRecipeID", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]db.Review)
+	ret1, _ := ret[1].(db.PageInfo)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetReviewsByRecipeID indicates an expected call of GetReviewsByRecipeID.
+func (mr *MockDBStoreMockRecorder) GetReviewsByRecipeID(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReviewsByRecipeID", reflect.TypeOf((*MockDBStore)(nil).GetReviewsByRecipeID), arg0, arg1, arg2, arg3)
+}
+
 // GetSessionByID mocks base method.
 func (m *MockDBStore) GetSessionByID(arg0 context.Context, arg1 string) (db.Session, error) {
 	m.ctrl.T.Helper()
@@ -231,6 +525,66 @@ func (mr *MockDBStoreMockRecorder) GetSessionByID(arg0, arg1 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSessionByID", reflect.TypeOf((*MockDBStore)(nil).GetSessionByID), arg0, arg1)
 }
 
+// GetSessionsByUserID mocks base method.
+func (m *MockDBStore) GetSessionsByUserID(arg0 context.Context, arg1 string) ([]db.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSessionsByUserID", arg0, arg1)
+	ret0, _ := ret[0].([]db.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSessionsByUserID indicates an expected call of GetSessionsByUserID.
+func (mr *MockDBStoreMockRecorder) GetSessionsByUserID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSessionsByUserID", reflect.TypeOf((*MockDBStore)(nil).GetSessionsByUserID), arg0, arg1)
+}
+
+// GetSubstitutionByID mocks base method.
+func (m *MockDBStore) GetSubstitutionByID(arg0 context.Context, arg1 string) (db.Substitution, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubstitutionByID", arg0, arg1)
+	ret0, _ := ret[0].(db.Substitution)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubstitutionByID indicates an expected call of GetSubstitutionByID.
+func (mr *MockDBStoreMockRecorder) GetSubstitutionByID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubstitutionByID", reflect.TypeOf((*MockDBStore)(nil).GetSubstitutionByID), arg0, arg1)
+}
+
+// GetSubstitutionsByIngredientIDs mocks base method.
+func (m *MockDBStore) GetSubstitutionsByIngredientIDs(arg0 context.Context, arg1 []string) ([]db.Substitution, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubstitutionsByIngredientIDs", arg0, arg1)
+	ret0, _ := ret[0].([]db.Substitution)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubstitutionsByIngredientIDs indicates an expected call of GetSubstitutionsByIngredientIDs.
+func (mr *MockDBStoreMockRecorder) GetSubstitutionsByIngredientIDs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubstitutionsByIngredientIDs", reflect.TypeOf((*MockDBStore)(nil).GetSubstitutionsByIngredientIDs), arg0, arg1)
+}
+
+// GetTagCounts mocks base method.
+func (m *MockDBStore) GetTagCounts(arg0 context.Context) ([]db.TagCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTagCounts", arg0)
+	ret0, _ := ret[0].([]db.TagCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTagCounts indicates an expected call of GetTagCounts.
+func (mr *MockDBStoreMockRecorder) GetTagCounts(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTagCounts", reflect.TypeOf((*MockDBStore)(nil).GetTagCounts), arg0)
+}
+
 // GetUserByEmail mocks base method.
 func (m *MockDBStore) GetUserByEmail(arg0 context.Context, arg1 string) (db.User, error) {
 	m.ctrl.T.Helper()
@@ -261,6 +615,37 @@ func (mr *MockDBStoreMockRecorder) GetUserByID(arg0, arg1 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByID", reflect.TypeOf((*MockDBStore)(nil).GetUserByID), arg0, arg1)
 }
 
+// ImportCatalogueIngredients mocks base method.
+func (m *MockDBStore) ImportCatalogueIngredients(arg0 context.Context, arg1 []db.CatalogueIngredientToCreate) (db.CatalogueImportResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportCatalogueIngredients", arg0, arg1)
+	ret0, _ := ret[0].(db.CatalogueImportResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportCatalogueIngredients indicates an expected call of ImportCatalogueIngredients.
+func (mr *MockDBStoreMockRecorder) ImportCatalogueIngredients(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportCatalogueIngredients", reflect.TypeOf((*MockDBStore)(nil).ImportCatalogueIngredients), arg0, arg1)
+}
+
+// SearchRecipes mocks base method.
+func (m *MockDBStore) SearchRecipes(arg0 context.Context, arg1 db.Pagination, arg2 db.RecipeSearch) ([]db.RecipeSearchResult, db.PageInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchRecipes", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]db.RecipeSearchResult)
+	ret1, _ := ret[1].(db.PageInfo)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// SearchRecipes indicates an expected call of SearchRecipes.
+func (mr *MockDBStoreMockRecorder) SearchRecipes(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchRecipes", reflect.TypeOf((*MockDBStore)(nil).SearchRecipes), arg0, arg1, arg2)
+}
+
 // UpdateAuthorByID mocks base method.
 func (m *MockDBStore) UpdateAuthorByID(arg0 context.Context, arg1 string, arg2 db.AuthorUpdate) (int64, error) {
 	m.ctrl.T.Helper()
@@ -276,6 +661,21 @@ func (mr *MockDBStoreMockRecorder) UpdateAuthorByID(arg0, arg1, arg2 interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAuthorByID", reflect.TypeOf((*MockDBStore)(nil).UpdateAuthorByID), arg0, arg1, arg2)
 }
 
+// UpdateCatalogueIngredientByID mocks base method.
+func (m *MockDBStore) UpdateCatalogueIngredientByID(arg0 context.Context, arg1 string, arg2 db.CatalogueIngredientUpdate) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCatalogueIngredientByID", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCatalogueIngredientByID indicates an expected call of UpdateCatalogueIngredientByID.
+func (mr *MockDBStoreMockRecorder) UpdateCatalogueIngredientByID(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCatalogueIngredientByID", reflect.TypeOf((*MockDBStore)(nil).UpdateCatalogueIngredientByID), arg0, arg1, arg2)
+}
+
 // UpdateRecipeByID mocks base method.
 func (m *MockDBStore) UpdateRecipeByID(arg0 context.Context, arg1 string, arg2 db.RecipeUpdate) (int64, error) {
 	m.ctrl.T.Helper()
@@ -291,6 +691,36 @@ func (mr *MockDBStoreMockRecorder) UpdateRecipeByID(arg0, arg1, arg2 interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRecipeByID", reflect.TypeOf((*MockDBStore)(nil).UpdateRecipeByID), arg0, arg1, arg2)
 }
 
+// UpdateReviewByID mocks base method.
+func (m *MockDBStore) UpdateReviewByID(arg0 context.Context, arg1 string, arg2 db.ReviewUpdate) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateReviewByID", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateReviewByID indicates an expected call of UpdateReviewByID.
+func (mr *MockDBStoreMockRecorder) UpdateReviewByID(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateReviewByID", reflect.TypeOf((*MockDBStore)(nil).UpdateReviewByID), arg0, arg1, arg2)
+}
+
+// UpdateSubstitutionByID mocks base method.
+func (m *MockDBStore) UpdateSubstitutionByID(arg0 context.Context, arg1 string, arg2 db.SubstitutionUpdate) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSubstitutionByID", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateSubstitutionByID indicates an expected call of UpdateSubstitutionByID.
+func (mr *MockDBStoreMockRecorder) UpdateSubstitutionByID(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSubstitutionByID", reflect.TypeOf((*MockDBStore)(nil).UpdateSubstitutionByID), arg0, arg1, arg2)
+}
+
 // UpdateUserByID mocks base method.
 func (m *MockDBStore) UpdateUserByID(arg0 context.Context, arg1 string, arg2 db.User) (int64, error) {
 	m.ctrl.T.Helper()
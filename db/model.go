@@ -1,21 +1,32 @@
 package db
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/rs/zerolog/log"
 	"go.mongodb.org/mongo-driver/bson"
-	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
 )
 
+// Pagination selects one page of a list. Either the page_id of an offset-based page or the
+// cursor, which was returned with the previous page, is required. The cursor is stable, when
+// documents are added or removed between two requests.
 type Pagination struct {
-	PageID   int64 `form:"page_id" json:"page_id" binding:"required,min=1"`
-	PageSize int64 `form:"page_size" json:"page_size" binding:"required,min=0,max=500"`
+	PageID   int64  `form:"page_id" json:"page_id" binding:"required_without=Cursor,excluded_with=Cursor,omitempty,min=1"`
+	PageSize int64  `form:"page_size" json:"page_size" binding:"required,min=0,max=500"`
+	Cursor   string `form:"cursor" json:"cursor"`
 }
 
-func (pagination *Pagination) getFindOptions() *options.FindOptions {
-	findOptions := options.Find()
-	findOptions.SetSkip((pagination.PageID - 1) * pagination.PageSize)
-	findOptions.SetLimit(pagination.PageSize)
-
-	return findOptions
+// PageInfo describes the position of the returned page in the whole list.
+type PageInfo struct {
+	TotalCount int64
+	NextCursor string
 }
 
 func (pagination *Pagination) getSkipStage() bson.M {
@@ -26,6 +37,355 @@ func (pagination *Pagination) getLimitStage() bson.M {
 	return bson.M{"$limit": pagination.PageSize}
 }
 
+// getPageStages returns the stages, which sort the documents of the previous stages and select
+// the requested page. One additional document is requested to find out, if there is a next
+// page. The cursor, the sort and the limit run before the document stages, e.g. lookups and
+// projections, so they are able to use the indexes of the collection and the document stages
+// only complete the documents of the page. This is not possible, if one of the sort keys is
+// computed by the document stages.
+func (pagination *Pagination) getPageStages(sortFields []sortField, computedKeys []string, documentStages ...bson.M) ([]bson.M, error) {
+	isSortedByComputedKey := slices.ContainsFunc(sortFields, func(field sortField) bool {
+		return slices.Contains(computedKeys, field.key)
+	})
+
+	stages := []bson.M{}
+	if isSortedByComputedKey {
+		stages = append(stages, documentStages...)
+	}
+
+	if pagination.Cursor != "" {
+		cursor, err := decodePageCursor(pagination.Cursor, sortFields)
+		if err != nil {
+			return nil, err
+		}
+
+		stages = append(stages, cursor.getMatchStage())
+	}
+
+	stages = append(stages, getSortStage(sortFields))
+	if pagination.Cursor == "" {
+		stages = append(stages, pagination.getSkipStage())
+	}
+	stages = append(stages, bson.M{"$limit": pagination.PageSize + 1})
+
+	if !isSortedByComputedKey {
+		stages = append(stages, documentStages...)
+	}
+
+	return stages, nil
+}
+
+// aggregatePage returns one page of the documents of the collection, which are selected by the
+// filter stages. The total count is retrieved with a separate aggregation of the filter stages,
+// because counting all documents would prevent the page stages from using indexes.
+func aggregatePage[T any](ctx context.Context, collection *mongo.Collection, filterStages []bson.M, pagination Pagination, sortFields []sortField, computedKeys []string, documentStages ...bson.M) ([]T, PageInfo, error) {
+	var pageInfo PageInfo
+
+	pageStages, err := pagination.getPageStages(sortFields, computedKeys, documentStages...)
+	if err != nil {
+		return nil, pageInfo, err
+	}
+
+	cursor, err := collection.Aggregate(ctx, append(slices.Clone(filterStages), pageStages...))
+	if err != nil {
+		return nil, pageInfo, err
+	}
+	defer cursor.Close(ctx)
+
+	var rawItems []bson.Raw
+	if err = cursor.All(ctx, &rawItems); err != nil {
+		return nil, pageInfo, err
+	}
+
+	if pagination.PageSize > 0 && int64(len(rawItems)) > pagination.PageSize {
+		rawItems = rawItems[:pagination.PageSize]
+
+		pageInfo.NextCursor, err = encodePageCursor(sortFields, rawItems[len(rawItems)-1])
+		if err != nil {
+			return nil, pageInfo, err
+		}
+	}
+
+	items := make([]T, 0, len(rawItems))
+	for _, rawItem := range rawItems {
+		var item T
+		if err := bson.Unmarshal(rawItem, &item); err != nil {
+			return nil, pageInfo, err
+		}
+
+		items = append(items, item)
+	}
+
+	pageInfo.TotalCount, err = countFilteredDocuments(ctx, collection, filterStages)
+	if err != nil {
+		return nil, pageInfo, err
+	}
+
+	return items, pageInfo, nil
+}
+
+// countFilteredDocuments counts the documents of the collection, which are selected by the
+// filter stages.
+func countFilteredDocuments(ctx context.Context, collection *mongo.Collection, filterStages []bson.M) (int64, error) {
+	cursor, err := collection.Aggregate(ctx, append(slices.Clone(filterStages), bson.M{"$count": "count"}))
+	if err != nil {
+		return 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var results []struct {
+		Count int64 `bson:"count"`
+	}
+	if err = cursor.All(ctx, &results); err != nil {
+		return 0, err
+	}
+
+	if len(results) == 0 {
+		return 0, nil
+	}
+
+	return results[0].Count, nil
+}
+
+var (
+	RecipeSortKeys = []string{"name", "timeM", "category", "averageRating", "ratingCount", "createdAt", "modifiedAt"}
+	AuthorSortKeys = []string{"name", "firstName", "lastName", "recipeCount", "createdAt", "modifiedAt"}
+	UserSortKeys   = []string{"email", "role", "createdAt", "modifiedAt"}
+	ReviewSortKeys = []string{"rating", "createdAt", "modifiedAt"}
+
+	CatalogueIngredientSortKeys = []string{"name", "defaultUnit", "createdAt", "modifiedAt"}
+	SubstitutionSortKeys        = []string{"ingredientName", "substituteName", "ratio", "createdAt", "modifiedAt"}
+
+	// The computed sort keys are not stored, but calculated by the lookups and projections of a
+	// list, so the documents have to be completed before they can be sorted by them.
+	recipeComputedSortKeys       = []string{"averageRating", "ratingCount"}
+	authorComputedSortKeys       = []string{"recipeCount"}
+	substitutionComputedSortKeys = []string{"ingredientName", "substituteName"}
+)
+
+const (
+	sortOrderAsc  = "asc"
+	sortOrderDesc = "desc"
+)
+
+// Sorting holds the sort keys and their orders. Both can be passed as repeated query
+// parameters or as comma-separated lists. The n-th order belongs to the n-th key and keys
+// without an order are sorted ascending.
+type Sorting struct {
+	Sort  []string `form:"sort" json:"sort"`
+	Order []string `form:"order" json:"order"`
+}
+
+type sortField struct {
+	key   string
+	order int
+}
+
+func splitQueryValues(values []string) []string {
+	var splitValues []string
+	for _, value := range values {
+		for _, splitValue := range strings.Split(value, ",") {
+			if trimmedValue := strings.TrimSpace(splitValue); trimmedValue != "" {
+				splitValues = append(splitValues, trimmedValue)
+			}
+		}
+	}
+
+	return splitValues
+}
+
+// getSortFields validates the sorting against the allowed keys and falls back to the default
+// key, if no sort key is provided.
+func (sorting *Sorting) getSortFields(allowedKeys []string, defaultKey string) ([]sortField, error) {
+	keys := splitQueryValues(sorting.Sort)
+	orders := splitQueryValues(sorting.Order)
+
+	if len(orders) > len(keys) && len(keys) > 0 {
+		return nil, fmt.Errorf("%w: got %d orders for %d sort keys", ErrInvalidSort, len(orders), len(keys))
+	}
+
+	if len(keys) == 0 {
+		keys = []string{defaultKey}
+	}
+
+	sortFields := make([]sortField, 0, len(keys))
+	for i, key := range keys {
+		if !slices.Contains(allowedKeys, key) {
+			return nil, fmt.Errorf("%w: sorting by %s is not supported, use one of %s", ErrInvalidSort, key, strings.Join(allowedKeys, ", "))
+		}
+
+		if slices.ContainsFunc(sortFields, func(field sortField) bool { return field.key == key }) {
+			return nil, fmt.Errorf("%w: sort key %s is provided more than once", ErrInvalidSort, key)
+		}
+
+		order := 1
+		if i < len(orders) {
+			switch strings.ToLower(orders[i]) {
+			case sortOrderAsc:
+			case sortOrderDesc:
+				order = -1
+			default:
+				return nil, fmt.Errorf("%w: order %s is not supported, use %s or %s", ErrInvalidSort, orders[i], sortOrderAsc, sortOrderDesc)
+			}
+		}
+
+		sortFields = append(sortFields, sortField{key: key, order: order})
+	}
+
+	return sortFields, nil
+}
+
+// getSortDocument returns the sort specification of the fields. The _id is always added as the
+// last key, so documents with equal values keep a stable order across pages.
+func getSortDocument(sortFields []sortField) bson.D {
+	sortDocument := bson.D{}
+	for _, field := range sortFields {
+		sortDocument = append(sortDocument, bson.E{Key: field.key, Value: field.order})
+	}
+
+	return append(sortDocument, bson.E{Key: "_id", Value: 1})
+}
+
+type RecipeFilter struct {
+	Name                string         `form:"name" json:"name"`
+	Category            Category       `form:"category" json:"category" binding:"omitempty,recipe_category"`
+	AuthorID            string         `form:"author_id" json:"author_id"`
+	UserID              string         `form:"user_id" json:"user_id"`
+	MaxTimeM            int            `form:"max_time_m" json:"max_time_m" binding:"omitempty,min=1"`
+	Ingredients         []string       `form:"ingredients" json:"ingredients"`
+	ExcludedIngredients []string       `form:"excluded_ingredients" json:"excluded_ingredients"`
+	Tags                []string       `form:"tags" json:"tags"`
+	DietaryLabels       []DietaryLabel `form:"dietary_labels" json:"dietary_labels" binding:"omitempty,dive,dietary_label"`
+	ExcludedAllergens   []Allergen     `form:"excluded_allergens" json:"excluded_allergens" binding:"omitempty,dive,allergen"`
+	IngredientIDs       []string       `form:"ingredient_ids" json:"ingredient_ids"`
+}
+
+// getMatchStage builds the $match stage for the filter. Names of recipes and ingredients are
+// matched case-insensitive and partially, so "flour" also matches "Wheat flour".
+func (filter *RecipeFilter) getMatchStage() (bson.M, error) {
+	match := bson.M{}
+
+	if filter.Name != "" {
+		match["name"] = getContainsRegex(filter.Name)
+	}
+
+	if filter.Category != "" {
+		match["category"] = filter.Category
+	}
+
+	if filter.AuthorID != "" {
+		primitiveAuthorID, err := primitive.ObjectIDFromHex(filter.AuthorID)
+		if err != nil {
+			log.Err(err).Msgf("failed to parse authorID %s to primitive ObjectID", filter.AuthorID)
+			return nil, newInvalidIDError("authorID", filter.AuthorID, err)
+		}
+		match["authorId"] = primitiveAuthorID
+	}
+
+	if filter.UserID != "" {
+		primitiveUserID, err := primitive.ObjectIDFromHex(filter.UserID)
+		if err != nil {
+			log.Err(err).Msgf("failed to parse userID %s to primitive ObjectID", filter.UserID)
+			return nil, newInvalidIDError("userID", filter.UserID, err)
+		}
+		match["userId"] = primitiveUserID
+	}
+
+	if filter.MaxTimeM > 0 {
+		match["timeM"] = bson.M{"$lte": filter.MaxTimeM}
+	}
+
+	ingredientMatch := bson.M{}
+	if len(filter.Ingredients) > 0 {
+		ingredientMatch["$all"] = getContainsRegexes(filter.Ingredients)
+	}
+	if len(filter.ExcludedIngredients) > 0 {
+		ingredientMatch["$nin"] = getContainsRegexes(filter.ExcludedIngredients)
+	}
+	if len(ingredientMatch) > 0 {
+		match["ingredients.name"] = ingredientMatch
+	}
+
+	if len(filter.IngredientIDs) > 0 {
+		primitiveIngredientIDs := bson.A{}
+		for _, ingredientID := range filter.IngredientIDs {
+			primitiveIngredientID, err := primitive.ObjectIDFromHex(ingredientID)
+			if err != nil {
+				log.Err(err).Msgf("failed to parse ingredientID %s to primitive ObjectID", ingredientID)
+				return nil, newInvalidIDError("ingredientID", ingredientID, err)
+			}
+			primitiveIngredientIDs = append(primitiveIngredientIDs, primitiveIngredientID)
+		}
+		match["ingredients.ingredientId"] = bson.M{"$all": primitiveIngredientIDs}
+	}
+
+	if len(filter.Tags) > 0 {
+		match["tags"] = bson.M{"$all": normalizeTags(filter.Tags)}
+	}
+
+	if len(filter.DietaryLabels) > 0 {
+		match["dietaryLabels"] = bson.M{"$all": filter.DietaryLabels}
+	}
+
+	// Recipes, which were created before the allergen detection, have no allergens and could
+	// contain any of them, so they are not matched.
+	if len(filter.ExcludedAllergens) > 0 {
+		match["allergens"] = bson.M{"$exists": true, "$nin": filter.ExcludedAllergens}
+	}
+
+	return bson.M{"$match": match}, nil
+}
+
+// normalizeTags trims and lowercases the tags and removes empty and duplicate tags, so tags are
+// filtered and counted independent of their spelling.
+func normalizeTags(tags []string) []string {
+	normalizedTags := []string{}
+	for _, tag := range tags {
+		tag = normalizeName(tag)
+		if tag != "" && !slices.Contains(normalizedTags, tag) {
+			normalizedTags = append(normalizedTags, tag)
+		}
+	}
+
+	return normalizedTags
+}
+
+// normalizeName trims and lowercases the value and collapses inner whitespace.
+func normalizeName(value string) string {
+	return strings.ToLower(strings.Join(strings.Fields(value), " "))
+}
+
+func getContainsRegex(value string) primitive.Regex {
+	return primitive.Regex{Pattern: regexp.QuoteMeta(value), Options: "i"}
+}
+
+func getContainsRegexes(values []string) bson.A {
+	regexes := bson.A{}
+	for _, value := range values {
+		regexes = append(regexes, getContainsRegex(value))
+	}
+
+	return regexes
+}
+
+// RecipeSearch is a full-text search in the names, ingredients and preparation steps of the
+// recipes. The query supports phrases in double quotes and negations with a leading minus,
+// e.g. `"oat milk" pancakes -banana`.
+type RecipeSearch struct {
+	Query string `form:"q" json:"q" binding:"required,max=200"`
+}
+
+func (search *RecipeSearch) getMatchStage() bson.M {
+	return bson.M{"$match": bson.M{"$text": bson.M{"$search": search.Query}}}
+}
+
+// IngredientAutocomplete completes the beginning of an ingredient name with the names and
+// aliases of the ingredient catalogue.
+type IngredientAutocomplete struct {
+	Query string `form:"q" json:"q" binding:"required,max=100"`
+	Limit int64  `form:"limit" json:"limit" binding:"omitempty,min=1,max=50"`
+}
+
 type Role string
 
 const (
@@ -64,7 +424,7 @@ type Author struct {
 	InstagramURL string `bson:"instagramUrl" json:"instagramUrl,omitempty"`
 	YoutubeURL   string `bson:"youtubeUrl" json:"youtubeUrl,omitempty"`
 	ImageName    string `bson:"imageName" json:"imageName,omitempty"`
-	RecipeCount  int    `bson:"recipeCount" json:"recipeCount,omitempty"`
+	RecipeCount  int    `bson:"recipeCount" json:"recipeCount"`
 	UserID       string `bson:"userId" json:"userId,omitempty"`
 	UserCreated  User   `bson:"userCreated" json:"userCreated"`
 	CreatedAt    int64  `bson:"createdAt" json:"createdAt"`
@@ -75,20 +435,20 @@ type AuthorToCreate struct {
 	FirstName    string `bson:"firstName" json:"firstName,omitempty" example:"Moe"`
 	LastName     string `bson:"lastName" json:"lastName,omitempty" example:"Zarella"`
 	Name         string `bson:"name" json:"name" binding:"required" example:"Moe Zarella"`
-	WebsiteURL   string `bson:"websiteUrl" json:"websiteUrl,omitempty" example:"https://www.moezarella.com"`
-	InstagramURL string `bson:"instagramUrl" json:"instagramUrl,omitempty" example:"https://wwww.instagram.com/moezarella/"`
-	YoutubeURL   string `bson:"youtubeUrl" json:"youtubeUrl,omitempty" example:"https://www.youtube.com/channel/UCy8asdgasdf7RcC6OZffZA"`
+	WebsiteURL   string `bson:"websiteUrl" json:"websiteUrl,omitempty" binding:"omitempty,web_url" example:"https://www.moezarella.com"`
+	InstagramURL string `bson:"instagramUrl" json:"instagramUrl,omitempty" binding:"omitempty,web_url" example:"https://wwww.instagram.com/moezarella/"`
+	YoutubeURL   string `bson:"youtubeUrl" json:"youtubeUrl,omitempty" binding:"omitempty,web_url" example:"https://www.youtube.com/channel/UCy8asdgasdf7RcC6OZffZA"`
 	ImageName    string `bson:"imageName" json:"imageName,omitempty" example:"moezarella.png"`
-	UserID       string `bson:"userId" json:"userId" binding:"required" example:"660c4b99bc1bc4aabe3e6cd1"`
+	UserID       string `bson:"userId" json:"-"`
 } // @name AuthorToCreate
 
 type AuthorUpdate struct {
 	FirstName    string `bson:"firstName" json:"firstName,omitempty" example:"Moe"`
 	LastName     string `bson:"lastName" json:"lastName,omitempty" example:"Zarella"`
 	Name         string `bson:"name" json:"name" example:"Moe Zarella"`
-	WebsiteURL   string `bson:"websiteUrl" json:"websiteUrl,omitempty" example:"https://www.moezarella.com"`
-	InstagramURL string `bson:"instagramUrl" json:"instagramUrl,omitempty" example:"https://wwww.instagram.com/moezarella/"`
-	YoutubeURL   string `bson:"youtubeUrl" json:"youtubeUrl,omitempty" example:"https://www.youtube.com/channel/UCy8asdgasdf7RcC6OZffZA"`
+	WebsiteURL   string `bson:"websiteUrl" json:"websiteUrl,omitempty" binding:"omitempty,web_url" example:"https://www.moezarella.com"`
+	InstagramURL string `bson:"instagramUrl" json:"instagramUrl,omitempty" binding:"omitempty,web_url" example:"https://wwww.instagram.com/moezarella/"`
+	YoutubeURL   string `bson:"youtubeUrl" json:"youtubeUrl,omitempty" binding:"omitempty,web_url" example:"https://www.youtube.com/channel/UCy8asdgasdf7RcC6OZffZA"`
 	ImageName    string `bson:"imageName" json:"imageName,omitempty" example:"moezarella.png"`
 } // @name AuthorUpdate
 
@@ -103,6 +463,47 @@ const (
 	Drink     Category = "drink"
 )
 
+// Categories are all categories, which are supported for recipes.
+var Categories = []Category{Breakfast, Main, Desert, Smoothie, Baby, Drink}
+
+func (category Category) IsValid() bool {
+	return slices.Contains(Categories, category)
+}
+
+// DietaryLabel is a label of the controlled vocabulary for the diets, a recipe is suitable for.
+type DietaryLabel string
+
+const (
+	GlutenFree  DietaryLabel = "gluten-free"
+	NutFree     DietaryLabel = "nut-free"
+	SoyFree     DietaryLabel = "soy-free"
+	Raw         DietaryLabel = "raw"
+	HighProtein DietaryLabel = "high-protein"
+)
+
+// DietaryLabels are all supported dietary labels.
+var DietaryLabels = []DietaryLabel{GlutenFree, NutFree, SoyFree, Raw, HighProtein}
+
+func (label DietaryLabel) IsValid() bool {
+	return slices.Contains(DietaryLabels, label)
+}
+
+type Allergen string
+
+const (
+	Gluten Allergen = "gluten"
+	Nuts   Allergen = "nuts"
+	Soy    Allergen = "soy"
+	Sesame Allergen = "sesame"
+)
+
+// Allergens are all allergens, which are detected in the ingredients of recipes.
+var Allergens = []Allergen{Gluten, Nuts, Soy, Sesame}
+
+func (allergen Allergen) IsValid() bool {
+	return slices.Contains(Allergens, allergen)
+}
+
 type AmountUnit string
 
 const (
@@ -110,58 +511,266 @@ const (
 	Liters      AmountUnit = "l"
 	Milligrams  AmountUnit = "mg"
 	Grams       AmountUnit = "g"
+	Kilograms   AmountUnit = "kg"
 	Tablespoon  AmountUnit = "tbs"
 	Teaspoon    AmountUnit = "tsp"
+	Cup         AmountUnit = "cup"
+	FluidOunce  AmountUnit = "floz"
+	Ounce       AmountUnit = "oz"
+	Pound       AmountUnit = "lb"
+	Pinch       AmountUnit = "pinch"
 	Piece       AmountUnit = "pc"
+	Can         AmountUnit = "can"
+	Clove       AmountUnit = "clove"
+	Bunch       AmountUnit = "bunch"
+	Slice       AmountUnit = "slice"
 )
 
+// AmountUnits are all units, which are supported for the amount of an ingredient.
+var AmountUnits = []AmountUnit{
+	Milliliters, Liters, Milligrams, Grams, Kilograms, Tablespoon, Teaspoon, Cup, FluidOunce,
+	Ounce, Pound, Pinch, Piece, Can, Clove, Bunch, Slice,
+}
+
+func (unit AmountUnit) IsValid() bool {
+	return slices.Contains(AmountUnits, unit)
+}
+
+// CountedUnits are the units of ingredients, which are counted instead of measured. Ingredients
+// without unit are counted as well, e.g. "2 bananas".
+var CountedUnits = []AmountUnit{"", Piece, Can, Clove, Bunch, Slice}
+
+func (unit AmountUnit) IsCounted() bool {
+	return slices.Contains(CountedUnits, unit)
+}
+
+// Ingredient is an ingredient of a recipe. The name is displayed as written in the recipe, while
+// the ingredientId links it to the canonical entry of the ingredient catalogue.
 type Ingredient struct {
-	Name   string     `bson:"name" json:"name" example:"flour"`
-	Amount int        `bson:"amount" json:"amount" example:"100"`
-	Unit   AmountUnit `bson:"unit" json:"unit" example:"g"`
+	Name         string     `bson:"name" json:"name" binding:"required" example:"flour"`
+	Amount       Amount     `bson:"amount" json:"amount" binding:"gte=0" swaggertype:"number" example:"0.5"`
+	Unit         AmountUnit `bson:"unit" json:"unit" binding:"omitempty,amount_unit" example:"cup"`
+	IngredientID string     `bson:"ingredientId,omitempty" json:"ingredientId,omitempty" example:"660c4b99bc1bc4aabe126cd1"`
+}
+
+// MarshalJSON adds the amount as fraction, e.g. "1 1/2", to the ingredient, so clients can
+// display it the way it is written in a recipe.
+func (ingredient Ingredient) MarshalJSON() ([]byte, error) {
+	type ingredientJSON Ingredient
+
+	return json.Marshal(struct {
+		ingredientJSON
+		AmountFraction string `json:"amountFraction,omitempty"`
+	}{
+		ingredientJSON: ingredientJSON(ingredient),
+		AmountFraction: ingredient.Amount.Fraction(),
+	})
 }
 
 type PrepStep struct {
-	Rank        int    `bson:"rank" json:"rank" example:"1"`
-	Description string `bson:"description" json:"description" example:"Dice the onions"`
+	Rank        int    `bson:"rank" json:"rank" binding:"min=1" example:"1"`
+	Description string `bson:"description" json:"description" binding:"required" example:"Dice the onions"`
 }
 
 type Recipe struct {
-	ID          string       `bson:"_id" json:"id"`
-	Name        string       `bson:"name" json:"name"`
-	ImageName   string       `bson:"imageName" json:"imageName,omitempty"`
-	RecipeURL   string       `bson:"recipeUrl" json:"recipeUrl,omitempty"`
-	TimeM       int          `bson:"timeM" json:"timeM"`
-	Category    Category     `bson:"category" json:"category"`
-	Ingredients []Ingredient `bson:"ingredients" json:"ingredients"`
-	PrepSteps   []PrepStep   `bson:"prepSteps" json:"prepSteps"`
-	AuthorID    string       `bson:"authorId" json:"authorId,omitempty" binding:"required"`
-	Author      Author       `bson:"author" json:"author"`
-	UserID      string       `bson:"userId" json:"userId,omitempty"`
-	UserCreated User         `bson:"userCreated" json:"userCreated"`
-	CreatedAt   int64        `bson:"createdAt" json:"createdAt"`
-	ModifiedAt  int64        `bson:"modifiedAt" json:"modifiedAt"`
+	ID            string           `bson:"_id" json:"id"`
+	Name          string           `bson:"name" json:"name"`
+	ImageName     string           `bson:"imageName" json:"imageName,omitempty"`
+	RecipeURL     string           `bson:"recipeUrl" json:"recipeUrl,omitempty"`
+	TimeM         int              `bson:"timeM" json:"timeM"`
+	Servings      int              `bson:"servings" json:"servings,omitempty"`
+	Category      Category         `bson:"category" json:"category"`
+	Tags          []string         `bson:"tags" json:"tags,omitempty"`
+	DietaryLabels []DietaryLabel   `bson:"dietaryLabels" json:"dietaryLabels,omitempty"`
+	Allergens     []Allergen       `bson:"allergens" json:"allergens,omitempty"`
+	Ingredients   []Ingredient     `bson:"ingredients" json:"ingredients"`
+	PrepSteps     []PrepStep       `bson:"prepSteps" json:"prepSteps"`
+	AuthorID      string           `bson:"authorId" json:"authorId,omitempty" binding:"required"`
+	Author        Author           `bson:"author" json:"author"`
+	UserID        string           `bson:"userId" json:"userId,omitempty"`
+	UserCreated   User             `bson:"userCreated" json:"userCreated"`
+	AverageRating float64          `bson:"averageRating" json:"averageRating"`
+	RatingCount   int64            `bson:"ratingCount" json:"ratingCount"`
+	CreatedAt     int64            `bson:"createdAt" json:"createdAt"`
+	ModifiedAt    int64            `bson:"modifiedAt" json:"modifiedAt"`
+	Nutrition     *RecipeNutrition `bson:"-" json:"nutrition,omitempty"`
+}
+
+// Review is the rating of a recipe from 1 to 5 stars with an optional text. Each user can
+// review a recipe once.
+type Review struct {
+	ID          string `bson:"_id" json:"id"`
+	RecipeID    string `bson:"recipeId" json:"recipeId"`
+	Rating      int    `bson:"rating" json:"rating"`
+	Text        string `bson:"text" json:"text,omitempty"`
+	UserID      string `bson:"userId" json:"userId,omitempty"`
+	UserCreated User   `bson:"userCreated" json:"userCreated"`
+	CreatedAt   int64  `bson:"createdAt" json:"createdAt"`
+	ModifiedAt  int64  `bson:"modifiedAt" json:"modifiedAt"`
+}
+
+type ReviewToCreate struct {
+	RecipeID string `bson:"recipeId" json:"-"`
+	Rating   int    `bson:"rating" json:"rating" binding:"required,min=1,max=5" example:"5"`
+	Text     string `bson:"text" json:"text,omitempty" binding:"max=2000" example:"Fluffy and not too sweet"`
+	UserID   string `bson:"userId" json:"-"`
+} // @name ReviewToCreate
+
+// ReviewUpdate is the patch of a review. The fields are pointers, so an empty text clears the
+// text of the review, while a missing text keeps it.
+type ReviewUpdate struct {
+	Rating *int    `bson:"rating" json:"rating,omitempty" binding:"omitempty,min=1,max=5" example:"4"`
+	Text   *string `bson:"text" json:"text,omitempty" binding:"omitempty,max=2000" example:"Even better with blueberries"`
+} // @name ReviewUpdate
+
+// TagCount is a tag with the number of recipes, which are tagged with it.
+type TagCount struct {
+	Name        string `bson:"_id" json:"name" example:"quick"`
+	RecipeCount int64  `bson:"recipeCount" json:"recipeCount" example:"12"`
+} // @name TagCount
+
+// CatalogueIngredient is the canonical entry of an ingredient in the ingredient catalogue.
+// Names and aliases are stored trimmed and lowercase, so "Flour" and "flour" are the same
+// ingredient and "wheat flour" can be added as alias of it.
+type CatalogueIngredient struct {
+	ID           string     `bson:"_id" json:"id" example:"660c4b99bc1bc4aabe126cd1"`
+	Name         string     `bson:"name" json:"name" example:"flour"`
+	Aliases      []string   `bson:"aliases" json:"aliases" example:"wheat flour,all-purpose flour"`
+	DefaultUnit  AmountUnit `bson:"defaultUnit" json:"defaultUnit,omitempty" example:"g"`
+	PieceWeightG float64    `bson:"pieceWeightG" json:"pieceWeightG,omitempty" example:"118"`
+	Nutrients    *Nutrients `bson:"nutrients" json:"nutrients,omitempty"`
+	CreatedAt    int64      `bson:"createdAt" json:"createdAt" example:"1714462120"`
+	ModifiedAt   int64      `bson:"modifiedAt" json:"modifiedAt" example:"1714462120"`
+} // @name CatalogueIngredient
+
+type CatalogueIngredientToCreate struct {
+	Name         string     `bson:"name" json:"name" binding:"required,max=100" example:"flour"`
+	Aliases      []string   `bson:"aliases" json:"aliases,omitempty" binding:"omitempty,max=20,dive,required,max=100" example:"wheat flour,all-purpose flour"`
+	DefaultUnit  AmountUnit `bson:"defaultUnit" json:"defaultUnit,omitempty" binding:"omitempty,amount_unit" example:"g"`
+	PieceWeightG float64    `bson:"pieceWeightG" json:"pieceWeightG,omitempty" binding:"gte=0" example:"118"`
+	Nutrients    *Nutrients `bson:"nutrients" json:"nutrients,omitempty" binding:"omitempty"`
+} // @name CatalogueIngredientToCreate
+
+type CatalogueIngredientUpdate struct {
+	Name         string     `bson:"name" json:"name,omitempty" binding:"omitempty,max=100" example:"flour"`
+	Aliases      []string   `bson:"aliases" json:"aliases,omitempty" binding:"omitempty,max=20,dive,required,max=100" example:"wheat flour,all-purpose flour"`
+	DefaultUnit  AmountUnit `bson:"defaultUnit" json:"defaultUnit,omitempty" binding:"omitempty,amount_unit" example:"g"`
+	PieceWeightG float64    `bson:"pieceWeightG" json:"pieceWeightG,omitempty" binding:"gte=0" example:"118"`
+	Nutrients    *Nutrients `bson:"nutrients" json:"nutrients,omitempty" binding:"omitempty"`
+} // @name CatalogueIngredientUpdate
+
+// CatalogueImportResult counts the catalogue ingredients, which were inserted and updated by an
+// import.
+type CatalogueImportResult struct {
+	InsertedCount int64 `json:"insertedCount" example:"12"`
+	UpdatedCount  int64 `json:"updatedCount" example:"30"`
+} // @name CatalogueImportResult
+
+// Nutrients are the nutritional values of an amount of food. Catalogue ingredients store them per
+// 100 g. The energy is given in kcal, all other values in grams.
+type Nutrients struct {
+	KCal    float64 `bson:"kcal" json:"kcal" binding:"gte=0" example:"364"`
+	Protein float64 `bson:"protein" json:"protein" binding:"gte=0" example:"10.3"`
+	Fat     float64 `bson:"fat" json:"fat" binding:"gte=0" example:"1"`
+	Carbs   float64 `bson:"carbs" json:"carbs" binding:"gte=0" example:"76.3"`
+	Fibre   float64 `bson:"fibre" json:"fibre" binding:"gte=0" example:"2.7"`
+} // @name Nutrients
+
+// RecipeNutrition is the nutrition of a recipe, which is calculated from its ingredients. Missing
+// ingredients have no nutrients in the catalogue or amounts, which can't be weighed, so the
+// nutrition is incomplete, if there are any.
+type RecipeNutrition struct {
+	Total              Nutrients  `json:"total"`
+	PerServing         *Nutrients `json:"perServing,omitempty"`
+	MissingIngredients []string   `json:"missingIngredients,omitempty" example:"vegetable broth"`
+} // @name RecipeNutrition
+
+// Substitution replaces a catalogue ingredient with another one, e.g. egg white with aquafaba.
+// The ratio is the amount of the substitute, which replaces one unit of the ingredient. The
+// amount of the substitute is given in the unit of the substitution or in the unit of the
+// ingredient, if the substitution has no unit.
+type Substitution struct {
+	ID             string     `bson:"_id" json:"id" example:"660c4b99bc1bc4aabe126cd1"`
+	IngredientID   string     `bson:"ingredientId" json:"ingredientId" example:"660c4b99bc1bc4aabe126cd2"`
+	IngredientName string     `bson:"ingredientName" json:"ingredientName" example:"egg white"`
+	SubstituteID   string     `bson:"substituteId" json:"substituteId" example:"660c4b99bc1bc4aabe126cd3"`
+	SubstituteName string     `bson:"substituteName" json:"substituteName" example:"aquafaba"`
+	Ratio          float64    `bson:"ratio" json:"ratio" example:"2"`
+	SourceUnit     AmountUnit `bson:"sourceUnit" json:"sourceUnit,omitempty" example:"pc"`
+	Unit           AmountUnit `bson:"unit" json:"unit,omitempty" example:"tbs"`
+	Notes          string     `bson:"notes" json:"notes,omitempty" example:"Whip it until stiff peaks form"`
+	CreatedAt      int64      `bson:"createdAt" json:"createdAt" example:"1714462120"`
+	ModifiedAt     int64      `bson:"modifiedAt" json:"modifiedAt" example:"1714462120"`
+} // @name Substitution
+
+// SubstitutionToCreate replaces the ingredient by ratio times its amount of the substitute. If
+// the substitute is measured in another unit, the ratio converts amounts in the source unit of
+// the ingredient into amounts in the unit of the substitute, e.g. 1 pc of egg white into 2 tbs
+// of aquafaba.
+type SubstitutionToCreate struct {
+	IngredientID string     `bson:"ingredientId" json:"ingredientId" binding:"required" example:"660c4b99bc1bc4aabe126cd2"`
+	SubstituteID string     `bson:"substituteId" json:"substituteId" binding:"required,nefield=IngredientID" example:"660c4b99bc1bc4aabe126cd3"`
+	Ratio        float64    `bson:"ratio" json:"ratio" binding:"required,gt=0" example:"2"`
+	SourceUnit   AmountUnit `bson:"sourceUnit" json:"sourceUnit,omitempty" binding:"required_with=Unit,omitempty,amount_unit" example:"pc"`
+	Unit         AmountUnit `bson:"unit" json:"unit,omitempty" binding:"omitempty,amount_unit" example:"tbs"`
+	Notes        string     `bson:"notes" json:"notes,omitempty" binding:"max=500" example:"Whip it until stiff peaks form"`
+} // @name SubstitutionToCreate
+
+type SubstitutionUpdate struct {
+	Ratio      float64    `bson:"ratio" json:"ratio,omitempty" binding:"gte=0" example:"2"`
+	SourceUnit AmountUnit `bson:"sourceUnit" json:"sourceUnit,omitempty" binding:"omitempty,amount_unit" example:"pc"`
+	Unit       AmountUnit `bson:"unit" json:"unit,omitempty" binding:"omitempty,amount_unit" example:"tbs"`
+	Notes      string     `bson:"notes" json:"notes,omitempty" binding:"max=500" example:"Whip it until stiff peaks form"`
+} // @name SubstitutionUpdate
+
+// SubstitutionFilter filters the substitutions by the catalogue ingredient, which is replaced.
+type SubstitutionFilter struct {
+	IngredientID string `form:"ingredient_id" json:"ingredient_id"`
+}
+
+// RecipeSubstitution is a substitution for an ingredient of a recipe. The substitute has the
+// amount, which replaces the amount of the ingredient in the recipe.
+type RecipeSubstitution struct {
+	SubstitutionID string     `json:"substitutionId" example:"660c4b99bc1bc4aabe126cd1"`
+	Ingredient     Ingredient `json:"ingredient"`
+	Substitute     Ingredient `json:"substitute"`
+	Ratio          float64    `json:"ratio" example:"2"`
+	Notes          string     `json:"notes,omitempty" example:"Whip it until stiff peaks form"`
+} // @name RecipeSubstitution
+
+// RecipeSearchResult is a recipe, which matches a full-text search, with its relevance.
+type RecipeSearchResult struct {
+	Recipe `bson:",inline"`
+	Score  float64 `bson:"score" json:"score"`
 }
 
 type RecipeToCreate struct {
-	Name        string       `bson:"name" json:"name" example:"Pancakes" binding:"required"`
-	ImageName   string       `bson:"imageName" json:"imageName,omitempty" example:"Pancakes.png"`
-	RecipeURL   string       `bson:"recipeUrl" json:"recipeUrl,omitempty" example:"https://www.allthepancakes.com/pancakes"`
-	TimeM       int          `bson:"timeM" json:"timeM" example:"30"`
-	Category    Category     `bson:"category" json:"category" example:"breakfast"`
-	Ingredients []Ingredient `bson:"ingredients" json:"ingredients"`
-	PrepSteps   []PrepStep   `bson:"prepSteps" json:"prepSteps"`
-	AuthorID    string       `bson:"authorId" json:"authorId,omitempty" binding:"required" example:"660c4b99bc1bc4aabe126cd1"`
-	UserID      string       `bson:"userId" json:"userId,omitempty" binding:"required" example:"660c4b99bc1bc4aabe126cd1"`
+	Name          string         `bson:"name" json:"name" example:"Pancakes" binding:"required"`
+	ImageName     string         `bson:"imageName" json:"imageName,omitempty" example:"Pancakes.png"`
+	RecipeURL     string         `bson:"recipeUrl" json:"recipeUrl,omitempty" binding:"omitempty,web_url" example:"https://www.allthepancakes.com/pancakes"`
+	TimeM         int            `bson:"timeM" json:"timeM" binding:"gte=0" example:"30"`
+	Servings      int            `bson:"servings" json:"servings,omitempty" binding:"omitempty,min=1,max=100" example:"4"`
+	Category      Category       `bson:"category" json:"category" binding:"required,recipe_category" example:"breakfast"`
+	Tags          []string       `bson:"tags" json:"tags,omitempty" binding:"omitempty,max=20,dive,required,max=30" example:"quick,sweet"`
+	DietaryLabels []DietaryLabel `bson:"dietaryLabels" json:"dietaryLabels,omitempty" binding:"omitempty,dive,dietary_label" example:"nut-free,soy-free"`
+	Allergens     []Allergen     `bson:"allergens" json:"-"`
+	Ingredients   []Ingredient   `bson:"ingredients" json:"ingredients" binding:"dive"`
+	PrepSteps     []PrepStep     `bson:"prepSteps" json:"prepSteps" binding:"prep_steps,dive"`
+	AuthorID      string         `bson:"authorId" json:"authorId,omitempty" binding:"required" example:"660c4b99bc1bc4aabe126cd1"`
+	UserID        string         `bson:"userId" json:"-"`
 } // @name RecipeToCreate
 
 type RecipeUpdate struct {
-	Name        string       `bson:"name" json:"name,omitempty" example:"Pancakes"`
-	ImageName   string       `bson:"imageName" json:"imageName,omitempty" example:"Pancakes.png"`
-	RecipeURL   string       `bson:"recipeUrl" json:"recipeUrl,omitempty" example:"https://www.allthepancakes.com/pancakes"`
-	TimeM       int          `bson:"timeM" json:"timeM,omitempty" example:"30"`
-	Category    Category     `bson:"category" json:"category,omitempty" example:"breakfast"`
-	Ingredients []Ingredient `bson:"ingredients" json:"ingredients,omitempty"`
-	PrepSteps   []PrepStep   `bson:"prepSteps" json:"prepSteps,omitempty"`
-	AuthorID    string       `bson:"authorId" json:"authorId,omitempty" example:"660c4b99bc1bc4aabe126cd1"`
+	Name          string         `bson:"name" json:"name,omitempty" example:"Pancakes"`
+	ImageName     string         `bson:"imageName" json:"imageName,omitempty" example:"Pancakes.png"`
+	RecipeURL     string         `bson:"recipeUrl" json:"recipeUrl,omitempty" binding:"omitempty,web_url" example:"https://www.allthepancakes.com/pancakes"`
+	TimeM         int            `bson:"timeM" json:"timeM,omitempty" binding:"gte=0" example:"30"`
+	Servings      int            `bson:"servings" json:"servings,omitempty" binding:"omitempty,min=1,max=100" example:"4"`
+	Category      Category       `bson:"category" json:"category,omitempty" binding:"omitempty,recipe_category" example:"breakfast"`
+	Tags          []string       `bson:"tags" json:"tags,omitempty" binding:"omitempty,max=20,dive,required,max=30" example:"quick,sweet"`
+	DietaryLabels []DietaryLabel `bson:"dietaryLabels" json:"dietaryLabels,omitempty" binding:"omitempty,dive,dietary_label" example:"nut-free,soy-free"`
+	Allergens     []Allergen     `bson:"allergens" json:"-"`
+	Ingredients   []Ingredient   `bson:"ingredients" json:"ingredients,omitempty" binding:"omitempty,dive"`
+	PrepSteps     []PrepStep     `bson:"prepSteps" json:"prepSteps,omitempty" binding:"omitempty,prep_steps,dive"`
+	AuthorID      string         `bson:"authorId" json:"authorId,omitempty" example:"660c4b99bc1bc4aabe126cd1"`
 } // @name RecipeUpdate
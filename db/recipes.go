@@ -3,6 +3,7 @@ package db
 import (
 	"context"
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/rs/zerolog/log"
@@ -13,16 +14,22 @@ import (
 )
 
 var recipeProjectStage = bson.M{"$project": bson.M{
-	"_id":         1,
-	"name":        1,
-	"imageName":   1,
-	"recipeUrl":   1,
-	"timeM":       1,
-	"category":    1,
-	"ingredients": 1,
-	"prepSteps":   1,
-	"createdAt":   1,
-	"modifiedAt":  1,
+	"_id":           1,
+	"name":          1,
+	"imageName":     1,
+	"recipeUrl":     1,
+	"timeM":         1,
+	"servings":      1,
+	"category":      1,
+	"tags":          1,
+	"dietaryLabels": 1,
+	"allergens":     1,
+	"ingredients":   1,
+	"prepSteps":     1,
+	"averageRating": bson.M{"$ifNull": bson.A{bson.M{"$round": bson.A{bson.M{"$avg": "$reviews.rating"}, 1}}, 0}},
+	"ratingCount":   bson.M{"$size": "$reviews"},
+	"createdAt":     1,
+	"modifiedAt":    1,
 	"author": bson.M{
 		"$arrayElemAt": bson.A{
 			bson.M{"$map": bson.M{"input": "$recipeAuthor", "as": "author", "in": bson.M{
@@ -53,47 +60,92 @@ var recipeProjectStage = bson.M{"$project": bson.M{
 },
 }
 
-func (store *MongoDBStore) CreateRecipe(ctx context.Context, recipe RecipeToCreate) (primitive.ObjectID, error) {
-	indexModel := mongo.IndexModel{
-		Keys:    bson.M{"name": 1},
-		Options: options.Index().SetUnique(true),
-	}
+// recipeScoreStage adds the relevance of a recipe for the full-text search. It has to follow
+// the $text match.
+var recipeScoreStage = bson.M{"$addFields": bson.M{"score": bson.M{"$meta": "textScore"}}}
 
-	_, err := store.recipeCollection.Indexes().CreateOne(ctx, indexModel)
-	if err != nil {
-		log.Err(err).Msgf("recipe with name %s already exists", recipe.Name)
-		return primitive.NilObjectID, err
+// getRecipeSearchProjectStage returns the projection of recipes, which keeps the score of the
+// full-text search.
+func getRecipeSearchProjectStage() bson.M {
+	projection := bson.M{"score": 1}
+	for key, value := range recipeProjectStage["$project"].(bson.M) {
+		projection[key] = value
 	}
 
+	return bson.M{"$project": projection}
+}
+
+var recipeIndexModels = []mongo.IndexModel{
+	{
+		Keys:    bson.M{"name": 1},
+		Options: options.Index().SetUnique(true),
+	},
+	// Indexes supporting the filters of GetAllRecipes
+	{Keys: bson.M{"category": 1}},
+	{Keys: bson.M{"authorId": 1}},
+	{Keys: bson.M{"userId": 1}},
+	{Keys: bson.M{"timeM": 1}},
+	{Keys: bson.M{"ingredients.name": 1}},
+	{Keys: bson.M{"ingredients.ingredientId": 1}},
+	{Keys: bson.M{"tags": 1}},
+	{Keys: bson.M{"dietaryLabels": 1}},
+	{Keys: bson.M{"allergens": 1}},
+	// Text index for SearchRecipes. Matches in the name are ranked higher than matches in
+	// the ingredients and the preparation steps.
+	{
+		Keys: bson.D{
+			{Key: "name", Value: "text"},
+			{Key: "ingredients.name", Value: "text"},
+			{Key: "prepSteps.description", Value: "text"},
+		},
+		Options: options.Index().SetName("recipeTextSearch").SetWeights(bson.M{
+			"name":                  10,
+			"ingredients.name":      5,
+			"prepSteps.description": 1,
+		}),
+	},
+}
+
+func (store *MongoDBStore) CreateRecipe(ctx context.Context, recipe RecipeToCreate) (primitive.ObjectID, error) {
 	primitiveAuthorID, err := primitive.ObjectIDFromHex(recipe.AuthorID)
 	if err != nil {
 		log.Err(err).Msgf("failed to parse authorID %s to primitive ObjectID", recipe.AuthorID)
-		return primitive.NilObjectID, err
+		return primitive.NilObjectID, newInvalidIDError("authorID", recipe.AuthorID, err)
 	}
 
 	primitiveUserID, err := primitive.ObjectIDFromHex(recipe.UserID)
 	if err != nil {
 		log.Err(err).Msgf("failed to parse userID %s to primitive ObjectID", recipe.UserID)
+		return primitive.NilObjectID, newInvalidIDError("userID", recipe.UserID, err)
+	}
+
+	ingredients, err := store.linkIngredients(ctx, recipe.Ingredients)
+	if err != nil {
 		return primitive.NilObjectID, err
 	}
 
 	insertData := bson.M{
-		"name":        recipe.Name,
-		"imageName":   recipe.ImageName,
-		"recipeUrl":   recipe.RecipeURL,
-		"timeM":       recipe.TimeM,
-		"category":    recipe.Category,
-		"ingredients": recipe.Ingredients,
-		"prepSteps":   recipe.PrepSteps,
-		"authorId":    primitiveAuthorID,
-		"userId":      primitiveUserID,
-		"createdAt":   time.Now().Unix(),
-		"modifiedAt":  time.Now().Unix(),
+		"name":          recipe.Name,
+		"imageName":     recipe.ImageName,
+		"recipeUrl":     recipe.RecipeURL,
+		"timeM":         recipe.TimeM,
+		"servings":      recipe.Servings,
+		"category":      recipe.Category,
+		"tags":          normalizeTags(recipe.Tags),
+		"dietaryLabels": getDietaryLabels(recipe.DietaryLabels),
+		"allergens":     recipe.Allergens,
+		"ingredients":   ingredients,
+		"prepSteps":     recipe.PrepSteps,
+		"authorId":      primitiveAuthorID,
+		"userId":        primitiveUserID,
+		"createdAt":     time.Now().Unix(),
+		"modifiedAt":    time.Now().Unix(),
 	}
 
 	insertResult, err := store.recipeCollection.InsertOne(ctx, insertData)
 	if err != nil {
-		return primitive.NilObjectID, err
+		log.Err(err).Msgf("failed to insert recipe with name %s", recipe.Name)
+		return primitive.NilObjectID, wrapMongoError(err)
 	}
 
 	recipeID := insertResult.InsertedID.(primitive.ObjectID)
@@ -101,31 +153,80 @@ func (store *MongoDBStore) CreateRecipe(ctx context.Context, recipe RecipeToCrea
 	return recipeID, nil
 }
 
-func (store *MongoDBStore) GetAllRecipes(ctx context.Context, pagination Pagination) ([]Recipe, error) {
+func (store *MongoDBStore) GetAllRecipes(ctx context.Context, pagination Pagination, filter RecipeFilter, sorting Sorting) ([]Recipe, PageInfo, error) {
 	var recipes []Recipe
 
+	matchStage, err := filter.getMatchStage()
+	if err != nil {
+		return recipes, PageInfo{}, err
+	}
+
+	sortFields, err := sorting.getSortFields(RecipeSortKeys, "name")
+	if err != nil {
+		log.Err(err).Msg("failed to get sort fields for recipes")
+		return recipes, PageInfo{}, err
+	}
+
+	recipes, pageInfo, err := aggregatePage[Recipe](ctx, store.recipeCollection, []bson.M{matchStage}, pagination, sortFields, recipeComputedSortKeys, userLookupStage, authorLookupStage, ratingLookupStage, recipeProjectStage)
+	if err != nil {
+		log.Err(err).Msg("failed to aggregate recipe documents")
+		return recipes, PageInfo{}, err
+	}
+
+	return recipes, pageInfo, nil
+}
+
+// GetRecipesByAuthorID returns the recipes of one author with the same shape as GetAllRecipes.
+func (store *MongoDBStore) GetRecipesByAuthorID(ctx context.Context, authorID string, pagination Pagination, sorting Sorting) ([]Recipe, PageInfo, error) {
+	return store.GetAllRecipes(ctx, pagination, RecipeFilter{AuthorID: authorID}, sorting)
+}
+
+// GetRecipesByUserID returns the recipes, which were created by one user, with the same shape
+// as GetAllRecipes.
+func (store *MongoDBStore) GetRecipesByUserID(ctx context.Context, userID string, pagination Pagination, sorting Sorting) ([]Recipe, PageInfo, error) {
+	return store.GetAllRecipes(ctx, pagination, RecipeFilter{UserID: userID}, sorting)
+}
+
+// SearchRecipes returns the recipes, which match the full-text search, ordered by their
+// relevance.
+func (store *MongoDBStore) SearchRecipes(ctx context.Context, pagination Pagination, search RecipeSearch) ([]RecipeSearchResult, PageInfo, error) {
+	var recipes []RecipeSearchResult
+
+	sortFields := []sortField{{key: "score", order: -1}}
+
+	recipes, pageInfo, err := aggregatePage[RecipeSearchResult](ctx, store.recipeCollection, []bson.M{search.getMatchStage(), recipeScoreStage}, pagination, sortFields, recipeComputedSortKeys, userLookupStage, authorLookupStage, ratingLookupStage, getRecipeSearchProjectStage())
+	if err != nil {
+		log.Err(err).Msgf("failed to search recipe documents with query %s", search.Query)
+		return recipes, PageInfo{}, err
+	}
+
+	return recipes, pageInfo, nil
+}
+
+// GetTagCounts returns all tags of the recipes with the number of recipes per tag. The most
+// used tags come first.
+func (store *MongoDBStore) GetTagCounts(ctx context.Context) ([]TagCount, error) {
+	var tagCounts []TagCount
+
 	pipeline := []bson.M{
-		userLookupStage,
-		authorLookupStage,
-		recipeProjectStage,
-		getSortStage("name"),
-		pagination.getSkipStage(),
-		pagination.getLimitStage(),
+		{"$unwind": "$tags"},
+		{"$group": bson.M{"_id": "$tags", "recipeCount": bson.M{"$sum": 1}}},
+		{"$sort": bson.D{{Key: "recipeCount", Value: -1}, {Key: "_id", Value: 1}}},
 	}
 
 	cursor, err := store.recipeCollection.Aggregate(ctx, pipeline)
 	if err != nil {
-		log.Err(err).Msg("failed to aggregate recipe documents")
-		return recipes, err
+		log.Err(err).Msg("failed to aggregate recipe tags")
+		return tagCounts, err
 	}
 	defer cursor.Close(ctx)
 
-	if err = cursor.All(ctx, &recipes); err != nil {
-		log.Err(err).Msg("failed to parse recipe documents")
-		return recipes, err
+	if err := cursor.All(ctx, &tagCounts); err != nil {
+		log.Err(err).Msg("failed to parse tag counts")
+		return tagCounts, err
 	}
 
-	return recipes, nil
+	return tagCounts, nil
 }
 
 func (store *MongoDBStore) GetRecipeByID(ctx context.Context, recipeID string) (Recipe, error) {
@@ -134,13 +235,14 @@ func (store *MongoDBStore) GetRecipeByID(ctx context.Context, recipeID string) (
 	primitiveRecipeID, err := primitive.ObjectIDFromHex(recipeID)
 	if err != nil {
 		log.Err(err).Msgf("failed to parse recipeID %s to primitive ObjectID", recipeID)
-		return recipe, err
+		return recipe, newInvalidIDError("recipeID", recipeID, err)
 	}
 
 	pipeline := []bson.M{
 		{"$match": bson.M{"_id": primitiveRecipeID}}, // TODO: Generic function for this
 		userLookupStage,
 		authorLookupStage,
+		ratingLookupStage,
 		recipeProjectStage,
 		{"$limit": 1},
 	}
@@ -154,12 +256,12 @@ func (store *MongoDBStore) GetRecipeByID(ctx context.Context, recipeID string) (
 
 	if !cursor.Next(ctx) {
 		log.Error().Msgf("failed to find recipe with recipeID %s", recipeID)
-		return recipe, fmt.Errorf("failed to find recipe with recipeID %s", recipeID)
+		return recipe, fmt.Errorf("%w: failed to find recipe with recipeID %s", ErrNotFound, recipeID)
 	}
 
 	if err := cursor.Decode(&recipe); err != nil {
 		log.Err(err).Msg("failed to decode recipe")
-		return recipe, nil
+		return recipe, err
 	}
 
 	return recipe, nil
@@ -169,7 +271,7 @@ func (store *MongoDBStore) UpdateRecipeByID(ctx context.Context, recipeID string
 	primitiveRecipeID, err := primitive.ObjectIDFromHex(recipeID)
 	if err != nil {
 		log.Err(err).Msgf("failed to parse recipeID %s to primitive ObjectID", recipeID)
-		return 0, err
+		return 0, newInvalidIDError("recipeID", recipeID, err)
 	}
 
 	filter := bson.M{
@@ -191,23 +293,44 @@ func (store *MongoDBStore) UpdateRecipeByID(ctx context.Context, recipeID string
 	if recipeUpdate.TimeM != 0 {
 		update["$set"].(bson.M)["timeM"] = recipeUpdate.TimeM
 	}
+	if recipeUpdate.Servings != 0 {
+		update["$set"].(bson.M)["servings"] = recipeUpdate.Servings
+	}
 	if recipeUpdate.Category != "" {
 		update["$set"].(bson.M)["category"] = recipeUpdate.Category
 	}
+	if len(recipeUpdate.Tags) != 0 {
+		update["$set"].(bson.M)["tags"] = normalizeTags(recipeUpdate.Tags)
+	}
+	if len(recipeUpdate.DietaryLabels) != 0 {
+		update["$set"].(bson.M)["dietaryLabels"] = getDietaryLabels(recipeUpdate.DietaryLabels)
+	}
+	if recipeUpdate.Allergens != nil {
+		update["$set"].(bson.M)["allergens"] = recipeUpdate.Allergens
+	}
 	if len(recipeUpdate.Ingredients) != 0 {
-		update["$set"].(bson.M)["ingredients"] = recipeUpdate.Ingredients
+		ingredients, err := store.linkIngredients(ctx, recipeUpdate.Ingredients)
+		if err != nil {
+			return 0, err
+		}
+		update["$set"].(bson.M)["ingredients"] = ingredients
 	}
 	if len(recipeUpdate.PrepSteps) != 0 {
 		update["$set"].(bson.M)["prepSteps"] = recipeUpdate.PrepSteps
 	}
 	if recipeUpdate.AuthorID != "" {
-		update["$set"].(bson.M)["authorID"] = recipeUpdate.AuthorID
+		primitiveAuthorID, err := primitive.ObjectIDFromHex(recipeUpdate.AuthorID)
+		if err != nil {
+			log.Err(err).Msgf("failed to parse authorID %s to primitive ObjectID", recipeUpdate.AuthorID)
+			return 0, newInvalidIDError("authorID", recipeUpdate.AuthorID, err)
+		}
+		update["$set"].(bson.M)["authorId"] = primitiveAuthorID
 	}
 
 	updateResult, err := store.recipeCollection.UpdateOne(ctx, filter, update)
 	if err != nil {
 		log.Err(err).Msgf("failed to update recipe with recipe recipeID %s", recipeID)
-		return 0, err
+		return 0, wrapMongoError(err)
 	}
 
 	if updateResult.MatchedCount < 1 {
@@ -226,7 +349,7 @@ func (store *MongoDBStore) DeleteRecipeByID(ctx context.Context, recipeID string
 	primitiveRecipeID, err := primitive.ObjectIDFromHex(recipeID)
 	if err != nil {
 		log.Err(err).Msgf("failed to parse recipeID %s to primitive ObjectID", recipeID)
-		return 0, err
+		return 0, newInvalidIDError("recipeID", recipeID, err)
 	}
 
 	filter := bson.M{
@@ -242,7 +365,27 @@ func (store *MongoDBStore) DeleteRecipeByID(ctx context.Context, recipeID string
 	deleteCount := deleteResult.DeletedCount
 	if deleteCount < 1 {
 		log.Info().Msgf("recipe with recipeID %s was not deleted", recipeID)
+		return deleteCount, nil
+	}
+
+	// Reviews belong to their recipe, so they are deleted with it.
+	_, err = store.reviewCollection.DeleteMany(ctx, bson.M{"recipeId": primitiveRecipeID})
+	if err != nil {
+		log.Err(err).Msgf("failed to delete reviews of recipe with recipeID %s", recipeID)
+		return deleteCount, err
 	}
 
 	return deleteCount, nil
 }
+
+// getDietaryLabels removes duplicate labels, so they can be stored as set.
+func getDietaryLabels(labels []DietaryLabel) []DietaryLabel {
+	uniqueLabels := []DietaryLabel{}
+	for _, label := range labels {
+		if !slices.Contains(uniqueLabels, label) {
+			uniqueLabels = append(uniqueLabels, label)
+		}
+	}
+
+	return uniqueLabels
+}
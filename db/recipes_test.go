@@ -3,27 +3,27 @@ package db
 import (
 	"context"
 	"fmt"
+	"strings"
 	"sync"
 	"testing"
 	"time"
 
 	"github.com/PfMartin/wegonice-api/util"
 	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
-var categories = []Category{Breakfast, Main, Desert, Smoothie, Baby, Drink}
-var amountUnits = []AmountUnit{Milliliters, Liters, Milligrams, Grams, Tablespoon, Teaspoon, Piece}
-
 func getRandomIngredients(t *testing.T, ingredientsCount int, ingredients *[]Ingredient) {
 	t.Helper()
 
 	for i := 0; i < ingredientsCount; i++ {
-		amountIdx := util.RandomInt(0, int64(len(amountUnits)-1))
+		amountIdx := util.RandomInt(0, int64(len(AmountUnits)-1))
 
 		*ingredients = append(*ingredients, Ingredient{
 			Name:   util.RandomString(6),
-			Amount: int(util.RandomInt(0, 100)),
-			Unit:   amountUnits[amountIdx],
+			Amount: Amount(util.RandomInt(0, 400)) / 4,
+			Unit:   AmountUnits[amountIdx],
 		})
 	}
 }
@@ -71,15 +71,20 @@ func createRandomRecipe(t *testing.T, store *MongoDBStore, userID string, author
 
 	ingredients, prepSteps := getRandomIngredientsAndPrepSteps(t, 10, 10)
 
-	categoryIdx := util.RandomInt(0, int64(len(categories)-1))
-	category := categories[categoryIdx]
+	categoryIdx := util.RandomInt(0, int64(len(Categories)-1))
+	category := Categories[categoryIdx]
 
 	recipe := RecipeToCreate{
-		Name:        util.RandomString(6),
-		ImageName:   util.RandomString(10),
-		RecipeURL:   util.RandomString(10),
-		TimeM:       int(util.RandomInt(0, 180)),
-		Category:    category,
+		Name:      util.RandomString(6),
+		ImageName: util.RandomString(10),
+		RecipeURL: util.RandomString(10),
+		TimeM:     int(util.RandomInt(0, 180)),
+		Servings:  int(util.RandomInt(1, 8)),
+		Category:  category,
+		Tags:      []string{util.RandomString(6)},
+		DietaryLabels: []DietaryLabel{
+			DietaryLabels[util.RandomInt(0, int64(len(DietaryLabels)-1))],
+		},
 		Ingredients: ingredients,
 		PrepSteps:   prepSteps,
 		AuthorID:    authorID,
@@ -93,18 +98,21 @@ func createRandomRecipe(t *testing.T, store *MongoDBStore, userID string, author
 	recipeID := insertedRecipeID.Hex()
 
 	return Recipe{
-		ID:          recipeID,
-		Name:        recipe.Name,
-		ImageName:   recipe.ImageName,
-		RecipeURL:   recipe.RecipeURL,
-		TimeM:       recipe.TimeM,
-		Category:    recipe.Category,
-		Ingredients: recipe.Ingredients,
-		PrepSteps:   recipe.PrepSteps,
-		AuthorID:    authorID,
-		UserID:      userID,
-		CreatedAt:   time.Now().Unix(),
-		ModifiedAt:  time.Now().Unix(),
+		ID:            recipeID,
+		Name:          recipe.Name,
+		ImageName:     recipe.ImageName,
+		RecipeURL:     recipe.RecipeURL,
+		TimeM:         recipe.TimeM,
+		Servings:      recipe.Servings,
+		Category:      recipe.Category,
+		Tags:          recipe.Tags,
+		DietaryLabels: recipe.DietaryLabels,
+		Ingredients:   recipe.Ingredients,
+		PrepSteps:     recipe.PrepSteps,
+		AuthorID:      authorID,
+		UserID:        userID,
+		CreatedAt:     time.Now().Unix(),
+		ModifiedAt:    time.Now().Unix(),
 	}
 }
 
@@ -122,6 +130,7 @@ func TestUnitCreateRecipe(t *testing.T) {
 			ImageName:   recipe.ImageName,
 			RecipeURL:   recipe.RecipeURL,
 			TimeM:       recipe.TimeM,
+			Servings:    recipe.Servings,
 			Category:    recipe.Category,
 			Ingredients: recipe.Ingredients,
 			PrepSteps:   recipe.PrepSteps,
@@ -132,6 +141,16 @@ func TestUnitCreateRecipe(t *testing.T) {
 		_, err := store.CreateRecipe(context.Background(), recipeToCreate)
 		require.Error(t, err)
 	})
+
+	t.Run("Fail with ingredientID of a catalogue ingredient not found", func(t *testing.T) {
+		_, err := store.CreateRecipe(context.Background(), RecipeToCreate{
+			Name:        util.RandomString(10),
+			Ingredients: []Ingredient{{Name: "flour", Amount: 100, Unit: Grams, IngredientID: "659c00751f7178dff690270d"}},
+			AuthorID:    author.ID,
+			UserID:      user.ID,
+		})
+		require.ErrorIs(t, err, ErrNotFound)
+	})
 }
 
 func TestUnitGetAllRecipes(t *testing.T) {
@@ -152,7 +171,7 @@ func TestUnitGetAllRecipes(t *testing.T) {
 
 	t.Run("Gets all recipes with pagination", func(t *testing.T) {
 		ctx := context.Background()
-		recipes, err := store.GetAllRecipes(ctx, pagination)
+		recipes, pageInfo, err := store.GetAllRecipes(ctx, pagination, RecipeFilter{}, Sorting{})
 
 		for _, recipe := range recipes {
 			fmt.Print(recipe.Name + " | ")
@@ -163,6 +182,7 @@ func TestUnitGetAllRecipes(t *testing.T) {
 		require.NotEmpty(t, recipes)
 
 		require.Equal(t, int(pagination.PageSize), len(recipes))
+		require.GreaterOrEqual(t, pageInfo.TotalCount, int64(10))
 
 		for _, recipe := range recipes {
 			require.NotEmpty(t, recipe.Author)
@@ -182,6 +202,309 @@ func TestUnitGetAllRecipes(t *testing.T) {
 			require.Empty(t, recipe.AuthorID)
 		}
 	})
+
+	t.Run("Gets all recipes matching the filter", func(t *testing.T) {
+		filterUser := createRandomUser(t, store)
+		filterAuthor := createRandomAuthor(t, store, filterUser.ID)
+
+		matchingRecipe := createRandomRecipe(t, store, filterUser.ID, filterAuthor.ID)
+		otherRecipe := createRandomRecipe(t, store, filterUser.ID, filterAuthor.ID)
+
+		testCases := []struct {
+			name            string
+			filter          RecipeFilter
+			expectedRecipes []Recipe
+			excludedRecipes []Recipe
+		}{
+			{
+				name:            "Filter by author",
+				filter:          RecipeFilter{AuthorID: filterAuthor.ID},
+				expectedRecipes: []Recipe{matchingRecipe, otherRecipe},
+			},
+			{
+				name:            "Filter by user and name",
+				filter:          RecipeFilter{UserID: filterUser.ID, Name: strings.ToUpper(matchingRecipe.Name[1:4])},
+				expectedRecipes: []Recipe{matchingRecipe},
+				excludedRecipes: []Recipe{otherRecipe},
+			},
+			{
+				name: "Filter by included and excluded ingredients",
+				filter: RecipeFilter{
+					AuthorID:            filterAuthor.ID,
+					Ingredients:         []string{matchingRecipe.Ingredients[0].Name},
+					ExcludedIngredients: []string{otherRecipe.Ingredients[0].Name},
+				},
+				expectedRecipes: []Recipe{matchingRecipe},
+				excludedRecipes: []Recipe{otherRecipe},
+			},
+			{
+				name:            "Filter by category and max time",
+				filter:          RecipeFilter{AuthorID: filterAuthor.ID, Category: matchingRecipe.Category, MaxTimeM: matchingRecipe.TimeM},
+				expectedRecipes: []Recipe{matchingRecipe},
+			},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				recipes, pageInfo, err := store.GetAllRecipes(context.Background(), Pagination{PageID: 1, PageSize: 10}, tc.filter, Sorting{})
+				require.NoError(t, err)
+				require.GreaterOrEqual(t, pageInfo.TotalCount, int64(len(tc.expectedRecipes)))
+
+				var gotIDs []string
+				for _, recipe := range recipes {
+					gotIDs = append(gotIDs, recipe.ID)
+				}
+
+				for _, expectedRecipe := range tc.expectedRecipes {
+					require.Contains(t, gotIDs, expectedRecipe.ID)
+				}
+
+				for _, excludedRecipe := range tc.excludedRecipes {
+					require.NotContains(t, gotIDs, excludedRecipe.ID)
+				}
+			})
+		}
+	})
+
+	t.Run("Gets all recipes sorted by time descending", func(t *testing.T) {
+		sorting := Sorting{Sort: []string{"timeM"}, Order: []string{"desc"}}
+		recipes, _, err := store.GetAllRecipes(context.Background(), pagination, RecipeFilter{}, sorting)
+		require.NoError(t, err)
+		require.NotEmpty(t, recipes)
+
+		for i := 1; i < len(recipes); i++ {
+			require.GreaterOrEqual(t, recipes[i-1].TimeM, recipes[i].TimeM)
+		}
+	})
+
+	t.Run("Gets all recipes with cursor", func(t *testing.T) {
+		ctx := context.Background()
+		sorting := Sorting{Sort: []string{"timeM"}}
+
+		firstPage, firstPageInfo, err := store.GetAllRecipes(ctx, pagination, RecipeFilter{}, sorting)
+		require.NoError(t, err)
+		require.NotEmpty(t, firstPageInfo.NextCursor)
+
+		cursorPagination := Pagination{Cursor: firstPageInfo.NextCursor, PageSize: pagination.PageSize}
+		secondPage, secondPageInfo, err := store.GetAllRecipes(ctx, cursorPagination, RecipeFilter{}, sorting)
+		require.NoError(t, err)
+		require.NotEmpty(t, secondPage)
+		require.Equal(t, firstPageInfo.TotalCount, secondPageInfo.TotalCount)
+
+		offsetPagination := Pagination{PageID: 2, PageSize: pagination.PageSize}
+		offsetPage, _, err := store.GetAllRecipes(ctx, offsetPagination, RecipeFilter{}, sorting)
+		require.NoError(t, err)
+		require.Equal(t, offsetPage, secondPage)
+
+		for _, recipe := range secondPage {
+			require.NotContains(t, firstPage, recipe)
+		}
+	})
+
+	t.Run("Fails with cursor of a different sorting", func(t *testing.T) {
+		_, pageInfo, err := store.GetAllRecipes(context.Background(), pagination, RecipeFilter{}, Sorting{})
+		require.NoError(t, err)
+		require.NotEmpty(t, pageInfo.NextCursor)
+
+		cursorPagination := Pagination{Cursor: pageInfo.NextCursor, PageSize: pagination.PageSize}
+		_, _, err = store.GetAllRecipes(context.Background(), cursorPagination, RecipeFilter{}, Sorting{Sort: []string{"timeM"}})
+		require.ErrorIs(t, err, ErrInvalidCursor)
+	})
+
+	t.Run("Fails with invalid sort key", func(t *testing.T) {
+		_, _, err := store.GetAllRecipes(context.Background(), pagination, RecipeFilter{}, Sorting{Sort: []string{"password"}})
+		require.ErrorIs(t, err, ErrInvalidSort)
+	})
+
+	t.Run("Fails with invalid authorID in the filter", func(t *testing.T) {
+		_, _, err := store.GetAllRecipes(context.Background(), pagination, RecipeFilter{AuthorID: "test"}, Sorting{})
+		require.ErrorIs(t, err, ErrInvalidID)
+	})
+}
+
+func TestUnitGetRecipesByAuthorIDAndUserID(t *testing.T) {
+	store := getMongoDBStore(t)
+
+	user := createRandomUser(t, store)
+	author := createRandomAuthor(t, store, user.ID)
+	otherUser := createRandomUser(t, store)
+	otherAuthor := createRandomAuthor(t, store, otherUser.ID)
+
+	recipe := createRandomRecipe(t, store, user.ID, author.ID)
+	otherRecipe := createRandomRecipe(t, store, otherUser.ID, otherAuthor.ID)
+
+	pagination := Pagination{
+		PageID:   1,
+		PageSize: 10,
+	}
+
+	t.Run("Gets recipes of the author", func(t *testing.T) {
+		recipes, pageInfo, err := store.GetRecipesByAuthorID(context.Background(), author.ID, pagination, Sorting{})
+		require.NoError(t, err)
+		require.Equal(t, int64(1), pageInfo.TotalCount)
+		require.Len(t, recipes, 1)
+
+		require.Equal(t, recipe.ID, recipes[0].ID)
+		require.Equal(t, author.Name, recipes[0].Author.Name)
+	})
+
+	t.Run("Gets recipes of the user", func(t *testing.T) {
+		recipes, pageInfo, err := store.GetRecipesByUserID(context.Background(), otherUser.ID, pagination, Sorting{})
+		require.NoError(t, err)
+		require.Equal(t, int64(1), pageInfo.TotalCount)
+		require.Len(t, recipes, 1)
+
+		require.Equal(t, otherRecipe.ID, recipes[0].ID)
+		require.Equal(t, otherUser.Email, recipes[0].UserCreated.Email)
+	})
+
+	t.Run("Fails with invalid IDs", func(t *testing.T) {
+		_, _, err := store.GetRecipesByAuthorID(context.Background(), "test", pagination, Sorting{})
+		require.ErrorIs(t, err, ErrInvalidID)
+
+		_, _, err = store.GetRecipesByUserID(context.Background(), "test", pagination, Sorting{})
+		require.ErrorIs(t, err, ErrInvalidID)
+	})
+}
+
+func TestUnitSearchRecipes(t *testing.T) {
+	store := getMongoDBStore(t)
+
+	user := createRandomUser(t, store)
+	author := createRandomAuthor(t, store, user.ID)
+
+	recipe := createRandomRecipe(t, store, user.ID, author.ID)
+	otherRecipe := createRandomRecipe(t, store, user.ID, author.ID)
+
+	pagination := Pagination{
+		PageID:   1,
+		PageSize: 10,
+	}
+
+	t.Run("Finds recipe by name with score", func(t *testing.T) {
+		results, pageInfo, err := store.SearchRecipes(context.Background(), pagination, RecipeSearch{Query: recipe.Name})
+		require.NoError(t, err)
+		require.NotEmpty(t, results)
+		require.GreaterOrEqual(t, pageInfo.TotalCount, int64(1))
+
+		require.Equal(t, recipe.ID, results[0].ID)
+		require.Equal(t, recipe.Name, results[0].Name)
+		require.NotEmpty(t, results[0].Author.Name)
+		require.NotEmpty(t, results[0].UserCreated.Email)
+		require.Greater(t, results[0].Score, 0.0)
+
+		for i := 1; i < len(results); i++ {
+			require.GreaterOrEqual(t, results[i-1].Score, results[i].Score)
+		}
+	})
+
+	t.Run("Excludes recipes with negated words", func(t *testing.T) {
+		query := fmt.Sprintf("%s %s -%s", recipe.Name, otherRecipe.Name, otherRecipe.Name)
+		results, _, err := store.SearchRecipes(context.Background(), pagination, RecipeSearch{Query: query})
+		require.NoError(t, err)
+
+		var gotIDs []string
+		for _, result := range results {
+			gotIDs = append(gotIDs, result.ID)
+		}
+
+		require.Contains(t, gotIDs, recipe.ID)
+		require.NotContains(t, gotIDs, otherRecipe.ID)
+	})
+
+	t.Run("Finds recipe by phrase in the preparation steps", func(t *testing.T) {
+		query := fmt.Sprintf("%q", recipe.PrepSteps[0].Description)
+		results, _, err := store.SearchRecipes(context.Background(), pagination, RecipeSearch{Query: query})
+		require.NoError(t, err)
+
+		var gotIDs []string
+		for _, result := range results {
+			gotIDs = append(gotIDs, result.ID)
+		}
+
+		require.Contains(t, gotIDs, recipe.ID)
+	})
+}
+
+func TestUnitGetRecipeSearchProjectStage(t *testing.T) {
+	projection := getRecipeSearchProjectStage()["$project"].(bson.M)
+
+	require.Equal(t, 1, projection["score"])
+	for key, value := range recipeProjectStage["$project"].(bson.M) {
+		require.Equal(t, value, projection[key])
+	}
+	require.NotContains(t, recipeProjectStage["$project"].(bson.M), "score")
+}
+
+func TestUnitRecipeFilterGetMatchStage(t *testing.T) {
+	authorID := primitive.NewObjectID()
+	ingredientID := primitive.NewObjectID()
+
+	testCases := []struct {
+		name          string
+		filter        RecipeFilter
+		expectedStage bson.M
+		expectedErr   error
+	}{
+		{
+			name:          "Empty filter",
+			filter:        RecipeFilter{},
+			expectedStage: bson.M{"$match": bson.M{}},
+		},
+		{
+			name: "Full filter",
+			filter: RecipeFilter{
+				Name:                "pan.cake",
+				Category:            Breakfast,
+				AuthorID:            authorID.Hex(),
+				MaxTimeM:            30,
+				Ingredients:         []string{"flour"},
+				ExcludedIngredients: []string{"peanuts"},
+				Tags:                []string{" Quick ", "quick", "sweet"},
+				DietaryLabels:       []DietaryLabel{NutFree},
+				ExcludedAllergens:   []Allergen{Nuts, Soy},
+				IngredientIDs:       []string{ingredientID.Hex()},
+			},
+			expectedStage: bson.M{"$match": bson.M{
+				"name":     primitive.Regex{Pattern: `pan\.cake`, Options: "i"},
+				"category": Breakfast,
+				"authorId": authorID,
+				"timeM":    bson.M{"$lte": 30},
+				"ingredients.name": bson.M{
+					"$all": bson.A{primitive.Regex{Pattern: "flour", Options: "i"}},
+					"$nin": bson.A{primitive.Regex{Pattern: "peanuts", Options: "i"}},
+				},
+				"tags":                     bson.M{"$all": []string{"quick", "sweet"}},
+				"dietaryLabels":            bson.M{"$all": []DietaryLabel{NutFree}},
+				"allergens":                bson.M{"$exists": true, "$nin": []Allergen{Nuts, Soy}},
+				"ingredients.ingredientId": bson.M{"$all": bson.A{ingredientID}},
+			}},
+		},
+		{
+			name:        "Invalid ingredientID",
+			filter:      RecipeFilter{IngredientIDs: []string{"test"}},
+			expectedErr: ErrInvalidID,
+		},
+		{
+			name:        "Invalid userID",
+			filter:      RecipeFilter{UserID: "test"},
+			expectedErr: ErrInvalidID,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			stage, err := tc.filter.getMatchStage()
+
+			if tc.expectedErr != nil {
+				require.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedStage, stage)
+		})
+	}
 }
 
 func TestUnitGetRecipeByID(t *testing.T) {
@@ -196,6 +519,7 @@ func TestUnitGetRecipeByID(t *testing.T) {
 		name           string
 		recipeID       string
 		hasError       bool
+		expectedErr    error
 		expectedRecipe Recipe
 	}{
 		{
@@ -205,14 +529,16 @@ func TestUnitGetRecipeByID(t *testing.T) {
 			expectedRecipe: createdRecipe,
 		},
 		{
-			name:     "Fail with invalid recipeID",
-			recipeID: "test",
-			hasError: true,
+			name:        "Fail with invalid recipeID",
+			recipeID:    "test",
+			hasError:    true,
+			expectedErr: ErrInvalidID,
 		},
 		{
-			name:     "Fail with recipeID not found",
-			recipeID: "659c00751f717854f690270d",
-			hasError: true,
+			name:        "Fail with recipeID not found",
+			recipeID:    "659c00751f717854f690270d",
+			hasError:    true,
+			expectedErr: ErrNotFound,
 		},
 	}
 
@@ -222,6 +548,7 @@ func TestUnitGetRecipeByID(t *testing.T) {
 
 			if tc.hasError {
 				require.Error(t, err)
+				require.ErrorIs(t, err, tc.expectedErr)
 				return
 			}
 
@@ -266,7 +593,8 @@ func TestUnitUpdateRecipeByID(t *testing.T) {
 		ImageName:   util.RandomString(10),
 		RecipeURL:   util.RandomString(8),
 		TimeM:       int(util.RandomInt(0, 180)),
-		Category:    categories[util.RandomInt(0, int64(len(categories)-1))],
+		Servings:    int(util.RandomInt(1, 8)),
+		Category:    Categories[util.RandomInt(0, int64(len(Categories)-1))],
 		Ingredients: ingredients,
 		PrepSteps:   prepSteps,
 	}
@@ -324,6 +652,7 @@ func TestUnitUpdateRecipeByID(t *testing.T) {
 				ImageName:   recipeUpdate.ImageName,
 				RecipeURL:   recipeUpdate.RecipeURL,
 				TimeM:       recipeUpdate.TimeM,
+				Servings:    recipeUpdate.Servings,
 				Category:    recipeUpdate.Category,
 				Ingredients: recipeUpdate.Ingredients,
 				PrepSteps:   recipeUpdate.PrepSteps,
@@ -336,6 +665,7 @@ func TestUnitUpdateRecipeByID(t *testing.T) {
 			require.Equal(t, expectedRecipe.ImageName, updatedRecipe.ImageName)
 			require.Equal(t, expectedRecipe.RecipeURL, updatedRecipe.RecipeURL)
 			require.Equal(t, expectedRecipe.TimeM, updatedRecipe.TimeM)
+			require.Equal(t, expectedRecipe.Servings, updatedRecipe.Servings)
 			require.Equal(t, expectedRecipe.Category, updatedRecipe.Category)
 			require.Equal(t, expectedRecipe.Ingredients, updatedRecipe.Ingredients)
 			require.Equal(t, expectedRecipe.PrepSteps, updatedRecipe.PrepSteps)
@@ -395,3 +725,34 @@ func TestDeleteRecipeByID(t *testing.T) {
 		})
 	}
 }
+
+func TestUnitNormalizeTags(t *testing.T) {
+	require.Equal(t, []string{"quick", "one pot"}, normalizeTags([]string{" Quick", "one   Pot", "", "quick "}))
+	require.Equal(t, []string{}, normalizeTags(nil))
+}
+
+func TestUnitGetTagCounts(t *testing.T) {
+	store := getMongoDBStore(t)
+	user := createRandomUser(t, store)
+	author := createRandomAuthor(t, store, user.ID)
+
+	tag := util.RandomString(8)
+	for i := 0; i < 3; i++ {
+		recipe := createRandomRecipe(t, store, user.ID, author.ID)
+
+		modifiedCount, err := store.UpdateRecipeByID(context.Background(), recipe.ID, RecipeUpdate{Tags: []string{tag, recipe.Tags[0]}})
+		require.NoError(t, err)
+		require.Equal(t, int64(1), modifiedCount)
+	}
+
+	t.Run("Gets all tags with their recipe counts", func(t *testing.T) {
+		tagCounts, err := store.GetTagCounts(context.Background())
+		require.NoError(t, err)
+		require.NotEmpty(t, tagCounts)
+
+		require.Contains(t, tagCounts, TagCount{Name: tag, RecipeCount: 3})
+		for i := 1; i < len(tagCounts); i++ {
+			require.GreaterOrEqual(t, tagCounts[i-1].RecipeCount, tagCounts[i].RecipeCount)
+		}
+	})
+}
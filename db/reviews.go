@@ -0,0 +1,209 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ratingLookupStage looks up the reviews of a recipe to calculate its average rating and its
+// rating count in the recipeProjectStage. The texts of the reviews are not needed for that.
+var ratingLookupStage = bson.M{"$lookup": bson.M{
+	"from":         "reviews",
+	"localField":   "_id",
+	"foreignField": "recipeId",
+	"pipeline":     bson.A{bson.M{"$project": bson.M{"_id": 0, "rating": 1}}},
+	"as":           "reviews",
+}}
+
+var reviewProjectStage = bson.M{"$project": bson.M{
+	"_id":        1,
+	"recipeId":   1,
+	"rating":     1,
+	"text":       1,
+	"userId":     1,
+	"createdAt":  1,
+	"modifiedAt": 1,
+	"userCreated": bson.M{
+		"$arrayElemAt": bson.A{
+			bson.M{"$map": bson.M{"input": "$user", "as": "userCreated", "in": bson.M{
+				"_id":   "$$userCreated._id",
+				"email": "$$userCreated.email",
+			},
+			},
+			}, 0,
+		},
+	},
+}}
+
+var reviewIndexModels = []mongo.IndexModel{
+	{
+		Keys:    bson.D{{Key: "recipeId", Value: 1}, {Key: "userId", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	},
+	// Index supporting the check for references before deleting a user
+	{Keys: bson.M{"userId": 1}},
+}
+
+func (store *MongoDBStore) CreateReview(ctx context.Context, review ReviewToCreate) (primitive.ObjectID, error) {
+	primitiveRecipeID, err := primitive.ObjectIDFromHex(review.RecipeID)
+	if err != nil {
+		log.Err(err).Msgf("failed to parse recipeID %s to primitive ObjectID", review.RecipeID)
+		return primitive.NilObjectID, newInvalidIDError("recipeID", review.RecipeID, err)
+	}
+
+	primitiveUserID, err := primitive.ObjectIDFromHex(review.UserID)
+	if err != nil {
+		log.Err(err).Msgf("failed to parse userID %s to primitive ObjectID", review.UserID)
+		return primitive.NilObjectID, newInvalidIDError("userID", review.UserID, err)
+	}
+
+	insertData := bson.M{
+		"recipeId":   primitiveRecipeID,
+		"rating":     review.Rating,
+		"text":       review.Text,
+		"userId":     primitiveUserID,
+		"createdAt":  time.Now().Unix(),
+		"modifiedAt": time.Now().Unix(),
+	}
+
+	insertResult, err := store.reviewCollection.InsertOne(ctx, insertData)
+	if err != nil {
+		log.Err(err).Msgf("failed to insert review of recipeID %s by userID %s", review.RecipeID, review.UserID)
+		return primitive.NilObjectID, wrapMongoError(err)
+	}
+
+	reviewID := insertResult.InsertedID.(primitive.ObjectID)
+
+	return reviewID, nil
+}
+
+func (store *MongoDBStore) GetReviewsByRecipeID(ctx context.Context, recipeID string, pagination Pagination, sorting Sorting) ([]Review, PageInfo, error) {
+	var reviews []Review
+
+	primitiveRecipeID, err := primitive.ObjectIDFromHex(recipeID)
+	if err != nil {
+		log.Err(err).Msgf("failed to parse recipeID %s to primitive ObjectID", recipeID)
+		return reviews, PageInfo{}, newInvalidIDError("recipeID", recipeID, err)
+	}
+
+	sortFields, err := sorting.getSortFields(ReviewSortKeys, "createdAt")
+	if err != nil {
+		log.Err(err).Msg("failed to get sort fields for reviews")
+		return reviews, PageInfo{}, err
+	}
+
+	reviews, pageInfo, err := aggregatePage[Review](ctx, store.reviewCollection, []bson.M{{"$match": bson.M{"recipeId": primitiveRecipeID}}}, pagination, sortFields, nil, userLookupStage, reviewProjectStage)
+	if err != nil {
+		log.Err(err).Msg("failed to aggregate review documents")
+		return reviews, PageInfo{}, err
+	}
+
+	return reviews, pageInfo, nil
+}
+
+func (store *MongoDBStore) GetReviewByID(ctx context.Context, reviewID string) (Review, error) {
+	var review Review
+
+	primitiveReviewID, err := primitive.ObjectIDFromHex(reviewID)
+	if err != nil {
+		log.Err(err).Msgf("failed to parse reviewID %s to primitive ObjectID", reviewID)
+		return review, newInvalidIDError("reviewID", reviewID, err)
+	}
+
+	pipeline := []bson.M{
+		{"$match": bson.M{"_id": primitiveReviewID}},
+		userLookupStage,
+		reviewProjectStage,
+		{"$limit": 1},
+	}
+
+	cursor, err := store.reviewCollection.Aggregate(ctx, pipeline)
+	if err != nil {
+		log.Err(err).Msgf("failed to execute pipeline to find review with reviewID %s and its user", reviewID)
+		return review, err
+	}
+	defer cursor.Close(ctx)
+
+	if !cursor.Next(ctx) {
+		log.Error().Msgf("failed to find review with reviewID %s", reviewID)
+		return review, fmt.Errorf("%w: failed to find review with reviewID %s", ErrNotFound, reviewID)
+	}
+
+	if err := cursor.Decode(&review); err != nil {
+		log.Err(err).Msg("failed to decode review")
+		return review, err
+	}
+
+	return review, nil
+}
+
+func (store *MongoDBStore) UpdateReviewByID(ctx context.Context, reviewID string, reviewUpdate ReviewUpdate) (int64, error) {
+	primitiveReviewID, err := primitive.ObjectIDFromHex(reviewID)
+	if err != nil {
+		log.Err(err).Msgf("failed to parse reviewID %s to primitive ObjectID", reviewID)
+		return 0, newInvalidIDError("reviewID", reviewID, err)
+	}
+
+	filter := bson.M{
+		"_id": primitiveReviewID,
+	}
+
+	update := bson.M{
+		"$set": bson.M{"modifiedAt": time.Now().Unix()},
+	}
+	if reviewUpdate.Rating != nil {
+		update["$set"].(bson.M)["rating"] = *reviewUpdate.Rating
+	}
+	if reviewUpdate.Text != nil {
+		update["$set"].(bson.M)["text"] = *reviewUpdate.Text
+	}
+
+	updateResult, err := store.reviewCollection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		log.Err(err).Msgf("failed to update review with reviewID %s", reviewID)
+		return 0, wrapMongoError(err)
+	}
+
+	if updateResult.MatchedCount < 1 {
+		log.Info().Msgf("failed to find review with reviewID %s", reviewID)
+	}
+
+	modifiedCount := updateResult.ModifiedCount
+	if modifiedCount < 1 {
+		log.Info().Msgf("did not update review with reviewID %s", reviewID)
+	}
+
+	return modifiedCount, err
+}
+
+func (store *MongoDBStore) DeleteReviewByID(ctx context.Context, reviewID string) (int64, error) {
+	primitiveReviewID, err := primitive.ObjectIDFromHex(reviewID)
+	if err != nil {
+		log.Err(err).Msgf("failed to parse reviewID %s to primitive ObjectID", reviewID)
+		return 0, newInvalidIDError("reviewID", reviewID, err)
+	}
+
+	filter := bson.M{
+		"_id": primitiveReviewID,
+	}
+
+	deleteResult, err := store.reviewCollection.DeleteOne(ctx, filter)
+	if err != nil {
+		log.Err(err).Msgf("failed to delete review with reviewID %s", reviewID)
+		return 0, err
+	}
+
+	deleteCount := deleteResult.DeletedCount
+	if deleteCount < 1 {
+		log.Info().Msgf("review with reviewID %s was not deleted", reviewID)
+	}
+
+	return deleteCount, nil
+}
@@ -0,0 +1,284 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/PfMartin/wegonice-api/util"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomReview(t *testing.T, store *MongoDBStore, recipeID string, userID string, rating int) Review {
+	t.Helper()
+
+	review := ReviewToCreate{
+		RecipeID: recipeID,
+		Rating:   rating,
+		Text:     util.RandomString(20),
+		UserID:   userID,
+	}
+
+	insertedReviewID, err := store.CreateReview(context.Background(), review)
+	require.NoError(t, err)
+	require.False(t, insertedReviewID.IsZero())
+
+	return Review{
+		ID:       insertedReviewID.Hex(),
+		RecipeID: review.RecipeID,
+		Rating:   review.Rating,
+		Text:     review.Text,
+		UserID:   review.UserID,
+	}
+}
+
+func TestUnitCreateReview(t *testing.T) {
+	store := getMongoDBStore(t)
+	user := createRandomUser(t, store)
+	author := createRandomAuthor(t, store, user.ID)
+	recipe := createRandomRecipe(t, store, user.ID, author.ID)
+
+	_ = createRandomReview(t, store, recipe.ID, user.ID, 4)
+
+	testCases := []struct {
+		name        string
+		review      ReviewToCreate
+		expectedErr error
+	}{
+		{
+			name:        "Fail with second review of the same user",
+			review:      ReviewToCreate{RecipeID: recipe.ID, Rating: 5, UserID: user.ID},
+			expectedErr: ErrDuplicateKey,
+		},
+		{
+			name:        "Fail with invalid recipeID",
+			review:      ReviewToCreate{RecipeID: "test", Rating: 5, UserID: user.ID},
+			expectedErr: ErrInvalidID,
+		},
+		{
+			name:        "Fail with invalid userID",
+			review:      ReviewToCreate{RecipeID: recipe.ID, Rating: 5, UserID: "test"},
+			expectedErr: ErrInvalidID,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := store.CreateReview(context.Background(), tc.review)
+			require.ErrorIs(t, err, tc.expectedErr)
+		})
+	}
+}
+
+func TestUnitGetReviewsByRecipeID(t *testing.T) {
+	store := getMongoDBStore(t)
+	user := createRandomUser(t, store)
+	author := createRandomAuthor(t, store, user.ID)
+	recipe := createRandomRecipe(t, store, user.ID, author.ID)
+
+	for i := 1; i <= 5; i++ {
+		reviewer := createRandomUser(t, store)
+		_ = createRandomReview(t, store, recipe.ID, reviewer.ID, i)
+	}
+
+	pagination := Pagination{
+		PageID:   1,
+		PageSize: 3,
+	}
+
+	t.Run("Gets the reviews of the recipe sorted by rating", func(t *testing.T) {
+		sorting := Sorting{Sort: []string{"rating"}, Order: []string{"desc"}}
+
+		reviews, pageInfo, err := store.GetReviewsByRecipeID(context.Background(), recipe.ID, pagination, sorting)
+		require.NoError(t, err)
+		require.Equal(t, int64(5), pageInfo.TotalCount)
+		require.Len(t, reviews, 3)
+
+		for i, review := range reviews {
+			require.Equal(t, recipe.ID, review.RecipeID)
+			require.Equal(t, 5-i, review.Rating)
+			require.NotEmpty(t, review.UserCreated.Email)
+		}
+	})
+
+	t.Run("Fail with invalid recipeID", func(t *testing.T) {
+		_, _, err := store.GetReviewsByRecipeID(context.Background(), "test", pagination, Sorting{})
+		require.ErrorIs(t, err, ErrInvalidID)
+	})
+}
+
+func TestUnitGetReviewByID(t *testing.T) {
+	store := getMongoDBStore(t)
+	user := createRandomUser(t, store)
+	author := createRandomAuthor(t, store, user.ID)
+	recipe := createRandomRecipe(t, store, user.ID, author.ID)
+
+	createdReview := createRandomReview(t, store, recipe.ID, user.ID, 5)
+
+	testCases := []struct {
+		name        string
+		reviewID    string
+		expectedErr error
+	}{
+		{
+			name:     "Success",
+			reviewID: createdReview.ID,
+		},
+		{
+			name:        "Fail with invalid reviewID",
+			reviewID:    "test",
+			expectedErr: ErrInvalidID,
+		},
+		{
+			name:        "Fail with reviewID not found",
+			reviewID:    "659c00751f7178dff690270d",
+			expectedErr: ErrNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			gotReview, err := store.GetReviewByID(context.Background(), tc.reviewID)
+
+			if tc.expectedErr != nil {
+				require.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, createdReview.ID, gotReview.ID)
+			require.Equal(t, createdReview.RecipeID, gotReview.RecipeID)
+			require.Equal(t, createdReview.Rating, gotReview.Rating)
+			require.Equal(t, createdReview.Text, gotReview.Text)
+			require.Equal(t, createdReview.UserID, gotReview.UserID)
+			require.Equal(t, user.Email, gotReview.UserCreated.Email)
+		})
+	}
+}
+
+func TestUnitUpdateReviewByID(t *testing.T) {
+	store := getMongoDBStore(t)
+	user := createRandomUser(t, store)
+	author := createRandomAuthor(t, store, user.ID)
+	recipe := createRandomRecipe(t, store, user.ID, author.ID)
+
+	createdReview := createRandomReview(t, store, recipe.ID, user.ID, 5)
+
+	rating := 3
+	text := util.RandomString(20)
+	reviewUpdate := ReviewUpdate{
+		Rating: &rating,
+		Text:   &text,
+	}
+
+	modifiedCount, err := store.UpdateReviewByID(context.Background(), createdReview.ID, reviewUpdate)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), modifiedCount)
+
+	gotReview, err := store.GetReviewByID(context.Background(), createdReview.ID)
+	require.NoError(t, err)
+	require.Equal(t, rating, gotReview.Rating)
+	require.Equal(t, text, gotReview.Text)
+
+	t.Run("Clears the text and keeps the rating", func(t *testing.T) {
+		emptyText := ""
+		_, err := store.UpdateReviewByID(context.Background(), createdReview.ID, ReviewUpdate{Text: &emptyText})
+		require.NoError(t, err)
+
+		gotReview, err := store.GetReviewByID(context.Background(), createdReview.ID)
+		require.NoError(t, err)
+		require.Equal(t, rating, gotReview.Rating)
+		require.Empty(t, gotReview.Text)
+	})
+
+	_, err = store.UpdateReviewByID(context.Background(), "test", reviewUpdate)
+	require.ErrorIs(t, err, ErrInvalidID)
+}
+
+func TestUnitDeleteReviewByID(t *testing.T) {
+	store := getMongoDBStore(t)
+	user := createRandomUser(t, store)
+	author := createRandomAuthor(t, store, user.ID)
+	recipe := createRandomRecipe(t, store, user.ID, author.ID)
+
+	createdReview := createRandomReview(t, store, recipe.ID, user.ID, 5)
+
+	testCases := []struct {
+		name        string
+		reviewID    string
+		expectedErr error
+		deleteCount int64
+	}{
+		{
+			name:        "Success",
+			reviewID:    createdReview.ID,
+			deleteCount: 1,
+		},
+		{
+			name:        "Fail with invalid reviewID",
+			reviewID:    "test",
+			expectedErr: ErrInvalidID,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			deleteCount, err := store.DeleteReviewByID(context.Background(), tc.reviewID)
+			require.Equal(t, tc.deleteCount, deleteCount)
+
+			if tc.expectedErr != nil {
+				require.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestUnitRecipeRating(t *testing.T) {
+	store := getMongoDBStore(t)
+	user := createRandomUser(t, store)
+	author := createRandomAuthor(t, store, user.ID)
+	recipe := createRandomRecipe(t, store, user.ID, author.ID)
+
+	gotRecipe, err := store.GetRecipeByID(context.Background(), recipe.ID)
+	require.NoError(t, err)
+	require.Equal(t, 0.0, gotRecipe.AverageRating)
+	require.Equal(t, int64(0), gotRecipe.RatingCount)
+
+	reviewer := createRandomUser(t, store)
+	_ = createRandomReview(t, store, recipe.ID, user.ID, 5)
+	_ = createRandomReview(t, store, recipe.ID, reviewer.ID, 4)
+
+	gotRecipe, err = store.GetRecipeByID(context.Background(), recipe.ID)
+	require.NoError(t, err)
+	require.Equal(t, 4.5, gotRecipe.AverageRating)
+	require.Equal(t, int64(2), gotRecipe.RatingCount)
+
+	t.Run("Sorts recipes by their average rating", func(t *testing.T) {
+		sorting := Sorting{Sort: []string{"averageRating"}, Order: []string{"desc"}}
+
+		recipes, _, err := store.GetAllRecipes(context.Background(), Pagination{PageID: 1, PageSize: 10}, RecipeFilter{}, sorting)
+		require.NoError(t, err)
+
+		for i := 1; i < len(recipes); i++ {
+			require.GreaterOrEqual(t, recipes[i-1].AverageRating, recipes[i].AverageRating)
+		}
+	})
+
+	t.Run("Fail deleting a user with reviews", func(t *testing.T) {
+		_, err := store.DeleteUserByID(context.Background(), reviewer.ID)
+		require.ErrorIs(t, err, ErrReferenced)
+	})
+
+	t.Run("Deletes the reviews with the recipe", func(t *testing.T) {
+		deleteCount, err := store.DeleteRecipeByID(context.Background(), recipe.ID)
+		require.NoError(t, err)
+		require.Equal(t, int64(1), deleteCount)
+
+		reviews, pageInfo, err := store.GetReviewsByRecipeID(context.Background(), recipe.ID, Pagination{PageID: 1, PageSize: 10}, Sorting{})
+		require.NoError(t, err)
+		require.Empty(t, reviews)
+		require.Equal(t, int64(0), pageInfo.TotalCount)
+	})
+}
@@ -0,0 +1,97 @@
+package db
+
+import "math"
+
+type smallerUnit struct {
+	unit   AmountUnit
+	factor float64
+	below  float64
+}
+
+// smallerUnits are used, when a scaled amount gets too small to be handled in its original
+// unit, e.g. 0.25 l are converted to 250 ml.
+var smallerUnits = map[AmountUnit]smallerUnit{
+	Kilograms:  {unit: Grams, factor: 1000, below: 1},
+	Liters:     {unit: Milliliters, factor: 1000, below: 1},
+	Pound:      {unit: Ounce, factor: 16, below: 1},
+	Cup:        {unit: Tablespoon, factor: 16, below: 0.25},
+	Tablespoon: {unit: Teaspoon, factor: 3, below: 1},
+}
+
+// roundingSteps are the steps, to which scaled amounts are rounded for each unit. Counted units
+// are rounded to fractions instead. Metric units, which are missing, are rounded to whole
+// numbers or to one decimal for small amounts.
+var roundingSteps = map[AmountUnit]float64{
+	Kilograms:  0.05,
+	Liters:     0.05,
+	Tablespoon: 0.25,
+	Teaspoon:   0.125,
+	Cup:        0.25,
+	FluidOunce: 0.25,
+	Ounce:      0.25,
+	Pound:      0.25,
+	Pinch:      1,
+}
+
+// ScaleToServings returns a copy of the recipe with the amounts of the ingredients scaled
+// proportionally to the given servings. Recipes without servings are returned unchanged.
+func (recipe Recipe) ScaleToServings(servings int) Recipe {
+	if recipe.Servings <= 0 || servings <= 0 || recipe.Servings == servings {
+		return recipe
+	}
+
+	factor := float64(servings) / float64(recipe.Servings)
+
+	scaledIngredients := make([]Ingredient, 0, len(recipe.Ingredients))
+	for _, ingredient := range recipe.Ingredients {
+		scaledIngredients = append(scaledIngredients, ingredient.scale(factor))
+	}
+
+	recipe.Ingredients = scaledIngredients
+	recipe.Servings = servings
+
+	return recipe
+}
+
+// scale multiplies the amount of the ingredient with the factor and rounds it in a way, which
+// makes sense for its unit. Amounts, which get too small for their unit, are converted to a
+// smaller unit. Ingredients with an amount are never scaled down to zero.
+func (ingredient Ingredient) scale(factor float64) Ingredient {
+	if ingredient.Amount == 0 {
+		return ingredient
+	}
+
+	amount := float64(ingredient.Amount) * factor
+
+	if smaller, ok := smallerUnits[ingredient.Unit]; ok && amount < smaller.below {
+		ingredient.Unit = smaller.unit
+		amount *= smaller.factor
+	}
+
+	ingredient.Amount = RoundAmount(amount, ingredient.Unit)
+
+	return ingredient
+}
+
+// RoundAmount rounds the amount to a step, which makes sense for the unit. Counted amounts are
+// rounded to the closest fraction, e.g. "1/4 can", and only rounded up to a whole one, if they
+// would be rounded down to zero otherwise. Amounts are never rounded down to zero.
+func RoundAmount(amount float64, unit AmountUnit) Amount {
+	if unit.IsCounted() {
+		if roundedAmount := roundToFraction(amount); roundedAmount > 0 {
+			return Amount(roundedAmount)
+		}
+
+		return 1
+	}
+
+	if step, ok := roundingSteps[unit]; ok {
+		return Amount(roundDecimals(max(math.Round(amount/step)*step, step), 3))
+	}
+
+	if amount >= 10 {
+		return Amount(math.Round(amount))
+	}
+
+	return Amount(max(roundDecimals(amount, 1), 0.1))
+}
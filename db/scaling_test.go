@@ -0,0 +1,134 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnitScaleToServings(t *testing.T) {
+	recipe := Recipe{
+		Servings: 4,
+		Ingredients: []Ingredient{
+			{Name: "flour", Amount: 500, Unit: Grams},
+			{Name: "milk", Amount: 1, Unit: Liters},
+			{Name: "eggs", Amount: 3, Unit: Piece},
+			{Name: "sugar", Amount: 1, Unit: Tablespoon},
+			{Name: "salt", Amount: 1, Unit: Teaspoon},
+			{Name: "yeast", Amount: 0.3, Unit: Grams},
+			{Name: "oats", Amount: 2.0 / 3, Unit: Cup},
+			{Name: "water", Amount: 0, Unit: Milliliters},
+			{Name: "bananas", Amount: 2},
+			{Name: "chickpeas", Amount: 0.5, Unit: Can},
+		},
+	}
+
+	testCases := []struct {
+		name                string
+		recipe              Recipe
+		servings            int
+		expectedServings    int
+		expectedIngredients []Ingredient
+	}{
+		{
+			name:             "Scales amounts up",
+			recipe:           recipe,
+			servings:         8,
+			expectedServings: 8,
+			expectedIngredients: []Ingredient{
+				{Name: "flour", Amount: 1000, Unit: Grams},
+				{Name: "milk", Amount: 2, Unit: Liters},
+				{Name: "eggs", Amount: 6, Unit: Piece},
+				{Name: "sugar", Amount: 2, Unit: Tablespoon},
+				{Name: "salt", Amount: 2, Unit: Teaspoon},
+				{Name: "yeast", Amount: 0.6, Unit: Grams},
+				{Name: "oats", Amount: 1.25, Unit: Cup},
+				{Name: "water", Amount: 0, Unit: Milliliters},
+				{Name: "bananas", Amount: 4},
+				{Name: "chickpeas", Amount: 1, Unit: Can},
+			},
+		},
+		{
+			name:             "Scales amounts down and converts to smaller units",
+			recipe:           recipe,
+			servings:         1,
+			expectedServings: 1,
+			expectedIngredients: []Ingredient{
+				{Name: "flour", Amount: 125, Unit: Grams},
+				{Name: "milk", Amount: 250, Unit: Milliliters},
+				{Name: "eggs", Amount: 0.75, Unit: Piece},
+				{Name: "sugar", Amount: 0.75, Unit: Teaspoon},
+				{Name: "salt", Amount: 0.25, Unit: Teaspoon},
+				{Name: "yeast", Amount: 0.1, Unit: Grams},
+				{Name: "oats", Amount: 2.75, Unit: Tablespoon},
+				{Name: "water", Amount: 0, Unit: Milliliters},
+				{Name: "bananas", Amount: 0.5},
+				{Name: "chickpeas", Amount: 0.125, Unit: Can},
+			},
+		},
+		{
+			name:             "Rounds pieces to fractions and other units to common steps",
+			recipe:           recipe,
+			servings:         3,
+			expectedServings: 3,
+			expectedIngredients: []Ingredient{
+				{Name: "flour", Amount: 375, Unit: Grams},
+				{Name: "milk", Amount: 750, Unit: Milliliters},
+				{Name: "eggs", Amount: 2.25, Unit: Piece},
+				{Name: "sugar", Amount: 2.25, Unit: Teaspoon},
+				{Name: "salt", Amount: 0.75, Unit: Teaspoon},
+				{Name: "yeast", Amount: 0.2, Unit: Grams},
+				{Name: "oats", Amount: 0.5, Unit: Cup},
+				{Name: "water", Amount: 0, Unit: Milliliters},
+				{Name: "bananas", Amount: 1.5},
+				{Name: "chickpeas", Amount: 0.375, Unit: Can},
+			},
+		},
+		{
+			name:                "Does not scale a recipe without servings",
+			recipe:              Recipe{Ingredients: recipe.Ingredients},
+			servings:            8,
+			expectedServings:    0,
+			expectedIngredients: recipe.Ingredients,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			scaledRecipe := tc.recipe.ScaleToServings(tc.servings)
+
+			require.Equal(t, tc.expectedServings, scaledRecipe.Servings)
+			require.Equal(t, tc.expectedIngredients, scaledRecipe.Ingredients)
+		})
+	}
+
+	t.Run("Does not modify the original recipe", func(t *testing.T) {
+		_ = recipe.ScaleToServings(8)
+
+		require.Equal(t, 4, recipe.Servings)
+		require.Equal(t, Amount(500), recipe.Ingredients[0].Amount)
+	})
+}
+
+func TestUnitRoundAmount(t *testing.T) {
+	testCases := []struct {
+		name           string
+		amount         float64
+		unit           AmountUnit
+		expectedAmount Amount
+	}{
+		{name: "Keeps a quarter can", amount: 0.25, unit: Can, expectedAmount: 0.25},
+		{name: "Rounds counted amounts below 1 to the closest fraction", amount: 0.26, unit: Piece, expectedAmount: 0.25},
+		{name: "Rounds counted amounts to thirds", amount: 0.34, unit: Clove, expectedAmount: 1.0 / 3},
+		{name: "Rounds unit-less amounts like counted amounts", amount: 1.52, expectedAmount: 1.5},
+		{name: "Rounds counted amounts to whole numbers", amount: 2.02, unit: Piece, expectedAmount: 2},
+		{name: "Rounds tiny counted amounts up to 1", amount: 0.01, unit: Piece, expectedAmount: 1},
+		{name: "Rounds metric amounts", amount: 12.4, unit: Grams, expectedAmount: 12},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.InDelta(t, float64(tc.expectedAmount), float64(RoundAmount(tc.amount, tc.unit)), 0.0001)
+		})
+	}
+}
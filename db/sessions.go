@@ -11,6 +11,7 @@ import (
 
 var sessionProjectStage = bson.M{"$project": bson.M{
 	"_id":          1,
+	"userId":       1,
 	"refreshToken": 1,
 	"userAgent":    1,
 	"isBlocked":    1,
@@ -21,6 +22,7 @@ var sessionProjectStage = bson.M{"$project": bson.M{
 			bson.M{"$map": bson.M{"input": "$user", "as": "user", "in": bson.M{
 				"_id":   "$$user._id",
 				"email": "$$user.email",
+				"role":  "$$user.role",
 			},
 			},
 			}, 0,
@@ -29,8 +31,14 @@ var sessionProjectStage = bson.M{"$project": bson.M{
 }}
 
 func (store *MongoDBStore) CreateSession(ctx context.Context, session Session) (primitive.ObjectID, error) {
+	primitiveUserID, err := primitive.ObjectIDFromHex(session.UserID)
+	if err != nil {
+		log.Err(err).Msgf("failed to parse userID %s to primitive ObjectID", session.UserID)
+		return primitive.NilObjectID, newInvalidIDError("userID", session.UserID, err)
+	}
+
 	insertData := bson.M{
-		"userId":       session.UserID,
+		"userId":       primitiveUserID,
 		"refreshToken": session.RefreshToken,
 		"userAgent":    session.UserAgent,
 		"isBlocked":    session.IsBlocked,
@@ -38,6 +46,17 @@ func (store *MongoDBStore) CreateSession(ctx context.Context, session Session) (
 		"expiresAt":    session.ExpiresAt,
 	}
 
+	// The ID can be set in advance, so the tokens of the session can be bound to it before it is created.
+	if session.ID != "" {
+		primitiveSessionID, err := primitive.ObjectIDFromHex(session.ID)
+		if err != nil {
+			log.Err(err).Msgf("failed to parse sessionID %s to primitive ObjectID", session.ID)
+			return primitive.NilObjectID, newInvalidIDError("sessionID", session.ID, err)
+		}
+
+		insertData["_id"] = primitiveSessionID
+	}
+
 	insertResult, err := store.sessionCollection.InsertOne(ctx, insertData)
 	if err != nil {
 		log.Err(err).Msg("failed to insert session")
@@ -55,7 +74,7 @@ func (store *MongoDBStore) GetSessionByID(ctx context.Context, sessionID string)
 	primitiveSessionID, err := primitive.ObjectIDFromHex(sessionID)
 	if err != nil {
 		log.Err(err).Msgf("failed to parse sessionID %s to primitive ObjectID", sessionID)
-		return session, err
+		return session, newInvalidIDError("sessionID", sessionID, err)
 	}
 
 	pipeline := []bson.M{
@@ -74,13 +93,97 @@ func (store *MongoDBStore) GetSessionByID(ctx context.Context, sessionID string)
 
 	if !cursor.Next(ctx) {
 		log.Error().Msgf("failed to find session with sessionID %s", sessionID)
-		return session, fmt.Errorf("failed to find session with sessionID %s", sessionID)
+		return session, fmt.Errorf("%w: failed to find session with sessionID %s", ErrNotFound, sessionID)
 	}
 
 	if err := cursor.Decode(&session); err != nil {
 		log.Err(err).Msg("failed to decode session")
-		return session, nil
+		return session, err
 	}
 
 	return session, nil
 }
+
+func (store *MongoDBStore) GetSessionsByUserID(ctx context.Context, userID string) ([]Session, error) {
+	var sessions []Session
+
+	primitiveUserID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		log.Err(err).Msgf("failed to parse userID %s to primitive ObjectID", userID)
+		return sessions, newInvalidIDError("userID", userID, err)
+	}
+
+	pipeline := []bson.M{
+		{"$match": bson.M{"userId": primitiveUserID}},
+		userLookupStage,
+		sessionProjectStage,
+		getSortStage([]sortField{{key: "expiresAt", order: 1}}),
+	}
+
+	cursor, err := store.sessionCollection.Aggregate(ctx, pipeline)
+	if err != nil {
+		log.Err(err).Msgf("failed to aggregate session documents of user with userID %s", userID)
+		return sessions, err
+	}
+	defer cursor.Close(ctx)
+
+	if err = cursor.All(ctx, &sessions); err != nil {
+		log.Err(err).Msg("failed to parse session documents")
+		return sessions, err
+	}
+
+	return sessions, nil
+}
+
+func (store *MongoDBStore) BlockSessionByID(ctx context.Context, sessionID string) (int64, error) {
+	primitiveSessionID, err := primitive.ObjectIDFromHex(sessionID)
+	if err != nil {
+		log.Err(err).Msgf("failed to parse sessionID %s to primitive ObjectID", sessionID)
+		return 0, newInvalidIDError("sessionID", sessionID, err)
+	}
+
+	filter := bson.M{
+		"_id": primitiveSessionID,
+	}
+
+	update := bson.M{
+		"$set": bson.M{"isBlocked": true},
+	}
+
+	updateResult, err := store.sessionCollection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		log.Err(err).Msgf("failed to block session with sessionID %s", sessionID)
+		return 0, err
+	}
+
+	if updateResult.MatchedCount < 1 {
+		log.Info().Msgf("could not find session with sessionID %s", sessionID)
+	}
+
+	return updateResult.ModifiedCount, nil
+}
+
+func (store *MongoDBStore) BlockSessionsByUserID(ctx context.Context, userID string) (int64, error) {
+	primitiveUserID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		log.Err(err).Msgf("failed to parse userID %s to primitive ObjectID", userID)
+		return 0, newInvalidIDError("userID", userID, err)
+	}
+
+	filter := bson.M{
+		"userId":    primitiveUserID,
+		"isBlocked": false,
+	}
+
+	update := bson.M{
+		"$set": bson.M{"isBlocked": true},
+	}
+
+	updateResult, err := store.sessionCollection.UpdateMany(ctx, filter, update)
+	if err != nil {
+		log.Err(err).Msgf("failed to block sessions of user with userID %s", userID)
+		return 0, err
+	}
+
+	return updateResult.ModifiedCount, nil
+}
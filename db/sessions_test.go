@@ -59,8 +59,148 @@ func TestUnitGetSessionByID(t *testing.T) {
 		require.NoError(t, err)
 
 		expectedSession := createdSession
-		expectedSession.UserID = ""
+		expectedSession.User = User{
+			ID:    user.ID,
+			Email: user.Email,
+			Role:  user.Role,
+		}
 
 		require.Equal(t, expectedSession, gotSession)
 	})
 }
+
+func TestUnitGetSessionsByUserID(t *testing.T) {
+	store := getMongoDBStore(t)
+
+	user := createRandomUser(t, store)
+	for i := 0; i < 3; i++ {
+		_ = createRandomSession(t, store, user.ID)
+	}
+
+	testCases := []struct {
+		name          string
+		userID        string
+		hasError      bool
+		sessionsCount int
+	}{
+		{
+			name:          "Success",
+			userID:        user.ID,
+			hasError:      false,
+			sessionsCount: 3,
+		},
+		{
+			name:     "Fail with invalid userID",
+			userID:   "test",
+			hasError: true,
+		},
+		{
+			name:          "Success without sessions for userID",
+			userID:        "659c00751f717854f690270d",
+			hasError:      false,
+			sessionsCount: 0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sessions, err := store.GetSessionsByUserID(context.Background(), tc.userID)
+
+			if tc.hasError {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.sessionsCount, len(sessions))
+
+			for _, session := range sessions {
+				require.Equal(t, tc.userID, session.UserID)
+			}
+		})
+	}
+}
+
+func TestUnitBlockSessionByID(t *testing.T) {
+	store := getMongoDBStore(t)
+
+	user := createRandomUser(t, store)
+	createdSession := createRandomSession(t, store, user.ID)
+
+	testCases := []struct {
+		name          string
+		sessionID     string
+		hasError      bool
+		modifiedCount int64
+	}{
+		{
+			name:          "Success",
+			sessionID:     createdSession.ID,
+			hasError:      false,
+			modifiedCount: 1,
+		},
+		{
+			name:          "Fail with invalid sessionID",
+			sessionID:     "test",
+			hasError:      true,
+			modifiedCount: 0,
+		},
+		{
+			name:          "Fail with sessionID not found",
+			sessionID:     "659c00751f717854f690270d",
+			hasError:      false,
+			modifiedCount: 0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			modifiedCount, err := store.BlockSessionByID(context.Background(), tc.sessionID)
+			require.Equal(t, tc.modifiedCount, modifiedCount)
+
+			if tc.hasError {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+
+			if modifiedCount < 1 {
+				return
+			}
+
+			blockedSession, err := store.GetSessionByID(context.Background(), tc.sessionID)
+			require.NoError(t, err)
+			require.True(t, blockedSession.IsBlocked)
+		})
+	}
+}
+
+func TestUnitBlockSessionsByUserID(t *testing.T) {
+	store := getMongoDBStore(t)
+
+	user := createRandomUser(t, store)
+	for i := 0; i < 3; i++ {
+		_ = createRandomSession(t, store, user.ID)
+	}
+
+	t.Run("Blocks all sessions of the user", func(t *testing.T) {
+		ctx := context.Background()
+
+		modifiedCount, err := store.BlockSessionsByUserID(ctx, user.ID)
+		require.NoError(t, err)
+		require.Equal(t, int64(3), modifiedCount)
+
+		sessions, err := store.GetSessionsByUserID(ctx, user.ID)
+		require.NoError(t, err)
+
+		for _, session := range sessions {
+			require.True(t, session.IsBlocked)
+		}
+	})
+
+	t.Run("Fails with invalid userID", func(t *testing.T) {
+		_, err := store.BlockSessionsByUserID(context.Background(), "test")
+		require.Error(t, err)
+	})
+}
@@ -2,40 +2,77 @@ package db
 
 import (
 	"context"
+	"fmt"
+	"time"
 
+	"github.com/rs/zerolog/log"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 )
 
 type DBStore interface {
 	CreateUser(ctx context.Context, user User) (primitive.ObjectID, error)
-	GetAllUsers(ctx context.Context, pagination Pagination) ([]User, error)
+	GetAllUsers(ctx context.Context, pagination Pagination, sorting Sorting) ([]User, PageInfo, error)
 	GetUserByEmail(ctx context.Context, email string) (User, error)
 	GetUserByID(ctx context.Context, userID string) (User, error)
 	UpdateUserByID(ctx context.Context, userID string, userUpdate User) (int64, error)
 	DeleteUserByID(ctx context.Context, userID string) (int64, error)
+	GetPendingUsers(ctx context.Context, pagination Pagination) ([]User, PageInfo, error)
+	ActivateUserByID(ctx context.Context, userID string) (int64, error)
 
 	CreateAuthor(ctx context.Context, author AuthorToCreate) (primitive.ObjectID, error)
-	GetAllAuthors(ctx context.Context, pagination Pagination) ([]Author, error)
+	GetAllAuthors(ctx context.Context, pagination Pagination, sorting Sorting) ([]Author, PageInfo, error)
 	GetAuthorByID(ctx context.Context, authorID string) (Author, error)
 	UpdateAuthorByID(ctx context.Context, authorID string, authorUpdate AuthorUpdate) (int64, error)
 	DeleteAuthorByID(ctx context.Context, authorID string) (int64, error)
 
 	CreateRecipe(ctx context.Context, recipe RecipeToCreate) (primitive.ObjectID, error)
-	GetAllRecipes(ctx context.Context, pagination Pagination) ([]Recipe, error)
+	GetAllRecipes(ctx context.Context, pagination Pagination, filter RecipeFilter, sorting Sorting) ([]Recipe, PageInfo, error)
+	GetRecipesByAuthorID(ctx context.Context, authorID string, pagination Pagination, sorting Sorting) ([]Recipe, PageInfo, error)
+	GetRecipesByUserID(ctx context.Context, userID string, pagination Pagination, sorting Sorting) ([]Recipe, PageInfo, error)
+	SearchRecipes(ctx context.Context, pagination Pagination, search RecipeSearch) ([]RecipeSearchResult, PageInfo, error)
 	GetRecipeByID(ctx context.Context, recipeID string) (Recipe, error)
+	GetTagCounts(ctx context.Context) ([]TagCount, error)
 	UpdateRecipeByID(ctx context.Context, recipeID string, recipeUpdate RecipeUpdate) (int64, error)
 	DeleteRecipeByID(ctx context.Context, recipeID string) (int64, error)
 
+	CreateReview(ctx context.Context, review ReviewToCreate) (primitive.ObjectID, error)
+	GetReviewsByRecipeID(ctx context.Context, recipeID string, pagination Pagination, sorting Sorting) ([]Review, PageInfo, error)
+	GetReviewByID(ctx context.Context, reviewID string) (Review, error)
+	UpdateReviewByID(ctx context.Context, reviewID string, reviewUpdate ReviewUpdate) (int64, error)
+	DeleteReviewByID(ctx context.Context, reviewID string) (int64, error)
+
+	CreateCatalogueIngredient(ctx context.Context, ingredient CatalogueIngredientToCreate) (primitive.ObjectID, error)
+	GetAllCatalogueIngredients(ctx context.Context, pagination Pagination, sorting Sorting) ([]CatalogueIngredient, PageInfo, error)
+	AutocompleteCatalogueIngredients(ctx context.Context, autocomplete IngredientAutocomplete) ([]CatalogueIngredient, error)
+	GetCatalogueIngredientByID(ctx context.Context, ingredientID string) (CatalogueIngredient, error)
+	GetCatalogueIngredientsByIDs(ctx context.Context, ingredientIDs []string) ([]CatalogueIngredient, error)
+	UpdateCatalogueIngredientByID(ctx context.Context, ingredientID string, ingredientUpdate CatalogueIngredientUpdate) (int64, error)
+	DeleteCatalogueIngredientByID(ctx context.Context, ingredientID string) (int64, error)
+	ImportCatalogueIngredients(ctx context.Context, ingredients []CatalogueIngredientToCreate) (CatalogueImportResult, error)
+
+	CreateSubstitution(ctx context.Context, substitution SubstitutionToCreate) (primitive.ObjectID, error)
+	GetAllSubstitutions(ctx context.Context, pagination Pagination, filter SubstitutionFilter, sorting Sorting) ([]Substitution, PageInfo, error)
+	GetSubstitutionByID(ctx context.Context, substitutionID string) (Substitution, error)
+	GetSubstitutionsByIngredientIDs(ctx context.Context, ingredientIDs []string) ([]Substitution, error)
+	UpdateSubstitutionByID(ctx context.Context, substitutionID string, substitutionUpdate SubstitutionUpdate) (int64, error)
+	DeleteSubstitutionByID(ctx context.Context, substitutionID string) (int64, error)
+
 	CreateSession(ctx context.Context, session Session) (primitive.ObjectID, error)
 	GetSessionByID(ctx context.Context, sessionID string) (Session, error)
+	GetSessionsByUserID(ctx context.Context, userID string) ([]Session, error)
+	BlockSessionByID(ctx context.Context, sessionID string) (int64, error)
+	BlockSessionsByUserID(ctx context.Context, userID string) (int64, error)
 }
 
 type MongoDBStore struct {
-	userCollection    *mongo.Collection
-	authorCollection  *mongo.Collection
-	recipeCollection  *mongo.Collection
-	sessionCollection *mongo.Collection
+	userCollection         *mongo.Collection
+	authorCollection       *mongo.Collection
+	recipeCollection       *mongo.Collection
+	reviewCollection       *mongo.Collection
+	ingredientCollection   *mongo.Collection
+	substitutionCollection *mongo.Collection
+	sessionCollection      *mongo.Collection
 }
 
 func NewMongoDBStore(dbName, dbUser, dbPassword, dbURI string) *MongoDBStore {
@@ -43,10 +80,46 @@ func NewMongoDBStore(dbName, dbUser, dbPassword, dbURI string) *MongoDBStore {
 
 	database := client.Database(dbName)
 
-	return &MongoDBStore{
-		userCollection:    database.Collection("users"),
-		authorCollection:  database.Collection("authors"),
-		recipeCollection:  database.Collection("recipes"),
-		sessionCollection: database.Collection("sessions"),
+	store := &MongoDBStore{
+		userCollection:         database.Collection("users"),
+		authorCollection:       database.Collection("authors"),
+		recipeCollection:       database.Collection("recipes"),
+		reviewCollection:       database.Collection("reviews"),
+		ingredientCollection:   database.Collection("ingredients"),
+		substitutionCollection: database.Collection("substitutions"),
+		sessionCollection:      database.Collection("sessions"),
 	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*30)
+	defer cancel()
+
+	if err := store.createIndexes(ctx); err != nil {
+		log.Fatal().Msgf("failed to create indexes: %s", err)
+	}
+
+	return store
+}
+
+// createIndexes creates the indexes of all collections, so they exist for the existing
+// documents, before the first request is handled. Existing indexes are left unchanged.
+func (store *MongoDBStore) createIndexes(ctx context.Context) error {
+	collectionIndexes := []struct {
+		collection  *mongo.Collection
+		indexModels []mongo.IndexModel
+	}{
+		{store.userCollection, userIndexModels},
+		{store.authorCollection, authorIndexModels},
+		{store.recipeCollection, recipeIndexModels},
+		{store.reviewCollection, reviewIndexModels},
+		{store.ingredientCollection, catalogueIngredientIndexModels},
+		{store.substitutionCollection, substitutionIndexModels},
+	}
+
+	for _, collectionIndex := range collectionIndexes {
+		if _, err := collectionIndex.collection.Indexes().CreateMany(ctx, collectionIndex.indexModels); err != nil {
+			return fmt.Errorf("failed to create indexes of collection %s: %w", collectionIndex.collection.Name(), err)
+		}
+	}
+
+	return nil
 }
@@ -0,0 +1,324 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var substitutionIngredientLookupStage = bson.M{"$lookup": bson.M{
+	"from":         "ingredients",
+	"localField":   "ingredientId",
+	"foreignField": "_id",
+	"as":           "ingredient",
+}}
+
+var substitutionSubstituteLookupStage = bson.M{"$lookup": bson.M{
+	"from":         "ingredients",
+	"localField":   "substituteId",
+	"foreignField": "_id",
+	"as":           "substitute",
+}}
+
+var substitutionProjectStage = bson.M{"$project": bson.M{
+	"_id":            1,
+	"ingredientId":   1,
+	"ingredientName": bson.M{"$arrayElemAt": bson.A{"$ingredient.name", 0}},
+	"substituteId":   1,
+	"substituteName": bson.M{"$arrayElemAt": bson.A{"$substitute.name", 0}},
+	"ratio":          1,
+	"sourceUnit":     1,
+	"unit":           1,
+	"notes":          1,
+	"createdAt":      1,
+	"modifiedAt":     1,
+}}
+
+var substitutionIndexModels = []mongo.IndexModel{
+	{
+		Keys:    bson.D{{Key: "ingredientId", Value: 1}, {Key: "substituteId", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	},
+	// Index supporting the check for references before deleting a catalogue ingredient
+	{Keys: bson.M{"substituteId": 1}},
+}
+
+func (store *MongoDBStore) CreateSubstitution(ctx context.Context, substitution SubstitutionToCreate) (primitive.ObjectID, error) {
+	primitiveIngredientID, err := primitive.ObjectIDFromHex(substitution.IngredientID)
+	if err != nil {
+		log.Err(err).Msgf("failed to parse ingredientID %s to primitive ObjectID", substitution.IngredientID)
+		return primitive.NilObjectID, newInvalidIDError("ingredientID", substitution.IngredientID, err)
+	}
+
+	primitiveSubstituteID, err := primitive.ObjectIDFromHex(substitution.SubstituteID)
+	if err != nil {
+		log.Err(err).Msgf("failed to parse substituteID %s to primitive ObjectID", substitution.SubstituteID)
+		return primitive.NilObjectID, newInvalidIDError("substituteID", substitution.SubstituteID, err)
+	}
+
+	insertData := bson.M{
+		"ingredientId": primitiveIngredientID,
+		"substituteId": primitiveSubstituteID,
+		"ratio":        substitution.Ratio,
+		"sourceUnit":   substitution.SourceUnit,
+		"unit":         substitution.Unit,
+		"notes":        substitution.Notes,
+		"createdAt":    time.Now().Unix(),
+		"modifiedAt":   time.Now().Unix(),
+	}
+
+	insertResult, err := store.substitutionCollection.InsertOne(ctx, insertData)
+	if err != nil {
+		log.Err(err).Msgf("failed to insert substitution of ingredientID %s with substituteID %s", substitution.IngredientID, substitution.SubstituteID)
+		return primitive.NilObjectID, wrapMongoError(err)
+	}
+
+	substitutionID := insertResult.InsertedID.(primitive.ObjectID)
+
+	return substitutionID, nil
+}
+
+func (store *MongoDBStore) GetAllSubstitutions(ctx context.Context, pagination Pagination, filter SubstitutionFilter, sorting Sorting) ([]Substitution, PageInfo, error) {
+	var substitutions []Substitution
+
+	match := bson.M{}
+	if filter.IngredientID != "" {
+		primitiveIngredientID, err := primitive.ObjectIDFromHex(filter.IngredientID)
+		if err != nil {
+			log.Err(err).Msgf("failed to parse ingredientID %s to primitive ObjectID", filter.IngredientID)
+			return substitutions, PageInfo{}, newInvalidIDError("ingredientID", filter.IngredientID, err)
+		}
+		match["ingredientId"] = primitiveIngredientID
+	}
+
+	sortFields, err := sorting.getSortFields(SubstitutionSortKeys, "ingredientName")
+	if err != nil {
+		log.Err(err).Msg("failed to get sort fields for substitutions")
+		return substitutions, PageInfo{}, err
+	}
+
+	substitutions, pageInfo, err := aggregatePage[Substitution](ctx, store.substitutionCollection, []bson.M{{"$match": match}}, pagination, sortFields, substitutionComputedSortKeys, substitutionIngredientLookupStage, substitutionSubstituteLookupStage, substitutionProjectStage)
+	if err != nil {
+		log.Err(err).Msg("failed to aggregate substitution documents")
+		return substitutions, PageInfo{}, err
+	}
+
+	return substitutions, pageInfo, nil
+}
+
+func (store *MongoDBStore) GetSubstitutionByID(ctx context.Context, substitutionID string) (Substitution, error) {
+	var substitution Substitution
+
+	primitiveSubstitutionID, err := primitive.ObjectIDFromHex(substitutionID)
+	if err != nil {
+		log.Err(err).Msgf("failed to parse substitutionID %s to primitive ObjectID", substitutionID)
+		return substitution, newInvalidIDError("substitutionID", substitutionID, err)
+	}
+
+	pipeline := []bson.M{
+		{"$match": bson.M{"_id": primitiveSubstitutionID}},
+		substitutionIngredientLookupStage,
+		substitutionSubstituteLookupStage,
+		substitutionProjectStage,
+		{"$limit": 1},
+	}
+
+	cursor, err := store.substitutionCollection.Aggregate(ctx, pipeline)
+	if err != nil {
+		log.Err(err).Msgf("failed to execute pipeline to find substitution with substitutionID %s and its ingredients", substitutionID)
+		return substitution, err
+	}
+	defer cursor.Close(ctx)
+
+	if !cursor.Next(ctx) {
+		log.Error().Msgf("failed to find substitution with substitutionID %s", substitutionID)
+		return substitution, fmt.Errorf("%w: failed to find substitution with substitutionID %s", ErrNotFound, substitutionID)
+	}
+
+	if err := cursor.Decode(&substitution); err != nil {
+		log.Err(err).Msg("failed to decode substitution")
+		return substitution, err
+	}
+
+	return substitution, nil
+}
+
+// GetSubstitutionsByIngredientIDs returns all substitutions, which replace one of the catalogue
+// ingredients. They are sorted by the name of their substitute.
+func (store *MongoDBStore) GetSubstitutionsByIngredientIDs(ctx context.Context, ingredientIDs []string) ([]Substitution, error) {
+	substitutions := []Substitution{}
+
+	primitiveIngredientIDs := bson.A{}
+	for _, ingredientID := range ingredientIDs {
+		primitiveIngredientID, err := primitive.ObjectIDFromHex(ingredientID)
+		if err != nil {
+			log.Err(err).Msgf("failed to parse ingredientID %s to primitive ObjectID", ingredientID)
+			return substitutions, newInvalidIDError("ingredientID", ingredientID, err)
+		}
+		primitiveIngredientIDs = append(primitiveIngredientIDs, primitiveIngredientID)
+	}
+
+	if len(primitiveIngredientIDs) == 0 {
+		return substitutions, nil
+	}
+
+	pipeline := []bson.M{
+		{"$match": bson.M{"ingredientId": bson.M{"$in": primitiveIngredientIDs}}},
+		substitutionIngredientLookupStage,
+		substitutionSubstituteLookupStage,
+		substitutionProjectStage,
+		{"$sort": bson.D{{Key: "substituteName", Value: 1}, {Key: "_id", Value: 1}}},
+	}
+
+	cursor, err := store.substitutionCollection.Aggregate(ctx, pipeline)
+	if err != nil {
+		log.Err(err).Msg("failed to aggregate substitutions by their ingredientIDs")
+		return substitutions, err
+	}
+	defer cursor.Close(ctx)
+
+	if err = cursor.All(ctx, &substitutions); err != nil {
+		log.Err(err).Msg("failed to parse substitution documents")
+		return substitutions, err
+	}
+
+	return substitutions, nil
+}
+
+func (store *MongoDBStore) UpdateSubstitutionByID(ctx context.Context, substitutionID string, substitutionUpdate SubstitutionUpdate) (int64, error) {
+	primitiveSubstitutionID, err := primitive.ObjectIDFromHex(substitutionID)
+	if err != nil {
+		log.Err(err).Msgf("failed to parse substitutionID %s to primitive ObjectID", substitutionID)
+		return 0, newInvalidIDError("substitutionID", substitutionID, err)
+	}
+
+	filter := bson.M{
+		"_id": primitiveSubstitutionID,
+	}
+
+	update := bson.M{
+		"$set": bson.M{"modifiedAt": time.Now().Unix()},
+	}
+	if substitutionUpdate.Ratio != 0 {
+		update["$set"].(bson.M)["ratio"] = substitutionUpdate.Ratio
+	}
+	if substitutionUpdate.SourceUnit != "" {
+		update["$set"].(bson.M)["sourceUnit"] = substitutionUpdate.SourceUnit
+	}
+	if substitutionUpdate.Unit != "" {
+		update["$set"].(bson.M)["unit"] = substitutionUpdate.Unit
+	}
+	if substitutionUpdate.Notes != "" {
+		update["$set"].(bson.M)["notes"] = substitutionUpdate.Notes
+	}
+
+	updateResult, err := store.substitutionCollection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		log.Err(err).Msgf("failed to update substitution with substitutionID %s", substitutionID)
+		return 0, wrapMongoError(err)
+	}
+
+	if updateResult.MatchedCount < 1 {
+		log.Info().Msgf("failed to find substitution with substitutionID %s", substitutionID)
+	}
+
+	modifiedCount := updateResult.ModifiedCount
+	if modifiedCount < 1 {
+		log.Info().Msgf("did not update substitution with substitutionID %s", substitutionID)
+	}
+
+	return modifiedCount, err
+}
+
+func (store *MongoDBStore) DeleteSubstitutionByID(ctx context.Context, substitutionID string) (int64, error) {
+	primitiveSubstitutionID, err := primitive.ObjectIDFromHex(substitutionID)
+	if err != nil {
+		log.Err(err).Msgf("failed to parse substitutionID %s to primitive ObjectID", substitutionID)
+		return 0, newInvalidIDError("substitutionID", substitutionID, err)
+	}
+
+	filter := bson.M{
+		"_id": primitiveSubstitutionID,
+	}
+
+	deleteResult, err := store.substitutionCollection.DeleteOne(ctx, filter)
+	if err != nil {
+		log.Err(err).Msgf("failed to delete substitution with substitutionID %s", substitutionID)
+		return 0, err
+	}
+
+	deleteCount := deleteResult.DeletedCount
+	if deleteCount < 1 {
+		log.Info().Msgf("substitution with substitutionID %s was not deleted", substitutionID)
+	}
+
+	return deleteCount, nil
+}
+
+// GetSubstitutions returns the substitutions, which are applicable for the ingredients of the
+// recipe. Ingredients are matched with the substitutions by their catalogue ingredient, so
+// ingredients, which are not linked to the catalogue, have no substitutions. Substitutions,
+// whose ratio doesn't apply to the unit of the ingredient, are skipped.
+func (recipe Recipe) GetSubstitutions(substitutions []Substitution) []RecipeSubstitution {
+	recipeSubstitutions := []RecipeSubstitution{}
+	for _, ingredient := range recipe.Ingredients {
+		if ingredient.IngredientID == "" {
+			continue
+		}
+
+		for _, substitution := range substitutions {
+			if substitution.IngredientID != ingredient.IngredientID || !substitution.appliesTo(ingredient) {
+				continue
+			}
+
+			recipeSubstitutions = append(recipeSubstitutions, RecipeSubstitution{
+				SubstitutionID: substitution.ID,
+				Ingredient:     ingredient,
+				Substitute:     substitution.substitute(ingredient),
+				Ratio:          substitution.Ratio,
+				Notes:          substitution.Notes,
+			})
+		}
+	}
+
+	return recipeSubstitutions
+}
+
+// appliesTo reports, if the ratio of the substitution is able to replace the amount of the
+// ingredient. A substitute in its own unit requires the amount in the source unit of the ratio,
+// e.g. 200 g of cream can't be replaced by a ratio, which turns 1 ml of cream into 2 tbs.
+// Ingredients without amount are always replaceable.
+func (substitution Substitution) appliesTo(ingredient Ingredient) bool {
+	if substitution.Unit == "" || ingredient.Amount == 0 {
+		return true
+	}
+
+	return ingredient.Unit == substitution.SourceUnit
+}
+
+// substitute returns the substitute, which replaces the amount of the ingredient. Ingredients
+// without amount, e.g. "salt to taste", are replaced by the substitute without amount.
+func (substitution Substitution) substitute(ingredient Ingredient) Ingredient {
+	unit := substitution.Unit
+	if unit == "" {
+		unit = ingredient.Unit
+	}
+
+	substitute := Ingredient{
+		Name:         substitution.SubstituteName,
+		Unit:         unit,
+		IngredientID: substitution.SubstituteID,
+	}
+
+	if ingredient.Amount != 0 {
+		substitute.Amount = RoundAmount(float64(ingredient.Amount)*substitution.Ratio, unit)
+	}
+
+	return substitute
+}
@@ -0,0 +1,303 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/PfMartin/wegonice-api/util"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func createRandomSubstitution(t *testing.T, store *MongoDBStore) Substitution {
+	t.Helper()
+
+	ingredient := createRandomCatalogueIngredient(t, store)
+	substitute := createRandomCatalogueIngredient(t, store)
+
+	substitution := SubstitutionToCreate{
+		IngredientID: ingredient.ID,
+		SubstituteID: substitute.ID,
+		Ratio:        2,
+		SourceUnit:   Piece,
+		Unit:         Tablespoon,
+		Notes:        util.RandomString(20),
+	}
+
+	insertedSubstitutionID, err := store.CreateSubstitution(context.Background(), substitution)
+	require.NoError(t, err)
+	require.False(t, insertedSubstitutionID.IsZero())
+
+	return Substitution{
+		ID:             insertedSubstitutionID.Hex(),
+		IngredientID:   ingredient.ID,
+		IngredientName: ingredient.Name,
+		SubstituteID:   substitute.ID,
+		SubstituteName: substitute.Name,
+		Ratio:          substitution.Ratio,
+		SourceUnit:     substitution.SourceUnit,
+		Unit:           substitution.Unit,
+		Notes:          substitution.Notes,
+	}
+}
+
+func TestUnitCreateSubstitution(t *testing.T) {
+	store := getMongoDBStore(t)
+
+	substitution := createRandomSubstitution(t, store)
+
+	testCases := []struct {
+		name         string
+		substitution SubstitutionToCreate
+		expectedErr  error
+	}{
+		{
+			name:         "Fail with existing substitution",
+			substitution: SubstitutionToCreate{IngredientID: substitution.IngredientID, SubstituteID: substitution.SubstituteID, Ratio: 1},
+			expectedErr:  ErrDuplicateKey,
+		},
+		{
+			name:         "Fail with invalid ingredientID",
+			substitution: SubstitutionToCreate{IngredientID: "test", SubstituteID: substitution.SubstituteID, Ratio: 1},
+			expectedErr:  ErrInvalidID,
+		},
+		{
+			name:         "Fail with invalid substituteID",
+			substitution: SubstitutionToCreate{IngredientID: substitution.IngredientID, SubstituteID: "test", Ratio: 1},
+			expectedErr:  ErrInvalidID,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := store.CreateSubstitution(context.Background(), tc.substitution)
+			require.ErrorIs(t, err, tc.expectedErr)
+		})
+	}
+}
+
+func TestUnitGetAllSubstitutions(t *testing.T) {
+	store := getMongoDBStore(t)
+
+	var substitutions []Substitution
+	for i := 0; i < 10; i++ {
+		substitutions = append(substitutions, createRandomSubstitution(t, store))
+	}
+
+	pagination := Pagination{
+		PageID:   1,
+		PageSize: 5,
+	}
+
+	t.Run("Gets all substitutions sorted by the name of the replaced ingredient", func(t *testing.T) {
+		gotSubstitutions, pageInfo, err := store.GetAllSubstitutions(context.Background(), pagination, SubstitutionFilter{}, Sorting{})
+		require.NoError(t, err)
+		require.Equal(t, int(pagination.PageSize), len(gotSubstitutions))
+		require.GreaterOrEqual(t, pageInfo.TotalCount, int64(10))
+
+		for i := 1; i < len(gotSubstitutions); i++ {
+			require.LessOrEqual(t, gotSubstitutions[i-1].IngredientName, gotSubstitutions[i].IngredientName)
+		}
+	})
+
+	t.Run("Filters the substitutions by the replaced ingredient", func(t *testing.T) {
+		filter := SubstitutionFilter{IngredientID: substitutions[0].IngredientID}
+
+		gotSubstitutions, pageInfo, err := store.GetAllSubstitutions(context.Background(), pagination, filter, Sorting{})
+		require.NoError(t, err)
+		require.Equal(t, int64(1), pageInfo.TotalCount)
+		require.Equal(t, substitutions[0].ID, gotSubstitutions[0].ID)
+		require.Equal(t, substitutions[0].SubstituteName, gotSubstitutions[0].SubstituteName)
+	})
+
+	t.Run("Fail with invalid ingredientID", func(t *testing.T) {
+		_, _, err := store.GetAllSubstitutions(context.Background(), pagination, SubstitutionFilter{IngredientID: "test"}, Sorting{})
+		require.ErrorIs(t, err, ErrInvalidID)
+	})
+}
+
+func TestUnitGetSubstitutionByID(t *testing.T) {
+	store := getMongoDBStore(t)
+
+	createdSubstitution := createRandomSubstitution(t, store)
+
+	testCases := []struct {
+		name           string
+		substitutionID string
+		expectedErr    error
+	}{
+		{
+			name:           "Success",
+			substitutionID: createdSubstitution.ID,
+		},
+		{
+			name:           "Fail with invalid substitutionID",
+			substitutionID: "test",
+			expectedErr:    ErrInvalidID,
+		},
+		{
+			name:           "Fail with substitutionID not found",
+			substitutionID: "659c00751f7178dff690270d",
+			expectedErr:    ErrNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			gotSubstitution, err := store.GetSubstitutionByID(context.Background(), tc.substitutionID)
+
+			if tc.expectedErr != nil {
+				require.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.NotZero(t, gotSubstitution.CreatedAt)
+
+			gotSubstitution.CreatedAt = 0
+			gotSubstitution.ModifiedAt = 0
+			require.Equal(t, createdSubstitution, gotSubstitution)
+		})
+	}
+}
+
+func TestUnitGetSubstitutionsByIngredientIDs(t *testing.T) {
+	store := getMongoDBStore(t)
+
+	firstSubstitution := createRandomSubstitution(t, store)
+	secondSubstitution := createRandomSubstitution(t, store)
+
+	substitutions, err := store.GetSubstitutionsByIngredientIDs(context.Background(), []string{firstSubstitution.IngredientID, secondSubstitution.IngredientID})
+	require.NoError(t, err)
+	require.Len(t, substitutions, 2)
+
+	substitutions, err = store.GetSubstitutionsByIngredientIDs(context.Background(), []string{})
+	require.NoError(t, err)
+	require.Empty(t, substitutions)
+
+	_, err = store.GetSubstitutionsByIngredientIDs(context.Background(), []string{"test"})
+	require.ErrorIs(t, err, ErrInvalidID)
+}
+
+func TestUnitUpdateSubstitutionByID(t *testing.T) {
+	store := getMongoDBStore(t)
+
+	createdSubstitution := createRandomSubstitution(t, store)
+
+	substitutionUpdate := SubstitutionUpdate{
+		Ratio: 0.5,
+		Unit:  Cup,
+		Notes: util.RandomString(20),
+	}
+
+	modifiedCount, err := store.UpdateSubstitutionByID(context.Background(), createdSubstitution.ID, substitutionUpdate)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), modifiedCount)
+
+	gotSubstitution, err := store.GetSubstitutionByID(context.Background(), createdSubstitution.ID)
+	require.NoError(t, err)
+	require.Equal(t, substitutionUpdate.Ratio, gotSubstitution.Ratio)
+	require.Equal(t, substitutionUpdate.Unit, gotSubstitution.Unit)
+	require.Equal(t, substitutionUpdate.Notes, gotSubstitution.Notes)
+
+	_, err = store.UpdateSubstitutionByID(context.Background(), "test", substitutionUpdate)
+	require.ErrorIs(t, err, ErrInvalidID)
+}
+
+func TestUnitDeleteSubstitutionByID(t *testing.T) {
+	store := getMongoDBStore(t)
+
+	createdSubstitution := createRandomSubstitution(t, store)
+
+	_, err := store.DeleteCatalogueIngredientByID(context.Background(), createdSubstitution.SubstituteID)
+	require.ErrorIs(t, err, ErrReferenced)
+
+	testCases := []struct {
+		name           string
+		substitutionID string
+		expectedErr    error
+		deleteCount    int64
+	}{
+		{
+			name:           "Success",
+			substitutionID: createdSubstitution.ID,
+			deleteCount:    1,
+		},
+		{
+			name:           "Fail with invalid substitutionID",
+			substitutionID: "test",
+			expectedErr:    ErrInvalidID,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			deleteCount, err := store.DeleteSubstitutionByID(context.Background(), tc.substitutionID)
+			require.Equal(t, tc.deleteCount, deleteCount)
+
+			if tc.expectedErr != nil {
+				require.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestUnitGetSubstitutions(t *testing.T) {
+	eggWhiteID := primitive.NewObjectID().Hex()
+	creamID := primitive.NewObjectID().Hex()
+	aquafabaID := primitive.NewObjectID().Hex()
+	cashewCreamID := primitive.NewObjectID().Hex()
+	oatCreamID := primitive.NewObjectID().Hex()
+	coconutMilkID := primitive.NewObjectID().Hex()
+
+	substitutions := []Substitution{
+		{ID: "1", IngredientID: eggWhiteID, SubstituteID: aquafabaID, SubstituteName: "aquafaba", Ratio: 2, SourceUnit: Piece, Unit: Tablespoon, Notes: "Whip it"},
+		{ID: "2", IngredientID: creamID, SubstituteID: cashewCreamID, SubstituteName: "cashew cream", Ratio: 1},
+		{ID: "3", IngredientID: creamID, SubstituteID: oatCreamID, SubstituteName: "oat cream", Ratio: 1.5},
+		// The ratio applies to grams of cream, so it can't replace the milliliters of the recipe.
+		{ID: "4", IngredientID: creamID, SubstituteID: coconutMilkID, SubstituteName: "coconut milk", Ratio: 2, SourceUnit: Grams, Unit: Tablespoon},
+	}
+
+	recipe := Recipe{
+		Ingredients: []Ingredient{
+			{Name: "Egg whites", Amount: 3, Unit: Piece, IngredientID: eggWhiteID},
+			{Name: "Cream", Amount: 200, Unit: Milliliters, IngredientID: creamID},
+			{Name: "Egg white", Unit: Piece, IngredientID: eggWhiteID},
+			{Name: "Sugar", Amount: 50, Unit: Grams},
+		},
+	}
+
+	require.Equal(t, []RecipeSubstitution{
+		{
+			SubstitutionID: "1",
+			Ingredient:     recipe.Ingredients[0],
+			Substitute:     Ingredient{Name: "aquafaba", Amount: 6, Unit: Tablespoon, IngredientID: aquafabaID},
+			Ratio:          2,
+			Notes:          "Whip it",
+		},
+		{
+			SubstitutionID: "2",
+			Ingredient:     recipe.Ingredients[1],
+			Substitute:     Ingredient{Name: "cashew cream", Amount: 200, Unit: Milliliters, IngredientID: cashewCreamID},
+			Ratio:          1,
+		},
+		{
+			SubstitutionID: "3",
+			Ingredient:     recipe.Ingredients[1],
+			Substitute:     Ingredient{Name: "oat cream", Amount: 300, Unit: Milliliters, IngredientID: oatCreamID},
+			Ratio:          1.5,
+		},
+		{
+			SubstitutionID: "1",
+			Ingredient:     recipe.Ingredients[2],
+			Substitute:     Ingredient{Name: "aquafaba", Unit: Tablespoon, IngredientID: aquafabaID},
+			Ratio:          2,
+			Notes:          "Whip it",
+		},
+	}, recipe.GetSubstitutions(substitutions))
+
+	require.Equal(t, []RecipeSubstitution{}, Recipe{}.GetSubstitutions(substitutions))
+}
@@ -19,18 +19,14 @@ var userLookupStage = bson.M{"$lookup": bson.M{
 	"as":           "user",
 }}
 
-func (store *MongoDBStore) CreateUser(ctx context.Context, user User) (primitive.ObjectID, error) {
-	indexModel := mongo.IndexModel{
+var userIndexModels = []mongo.IndexModel{
+	{
 		Keys:    bson.M{"email": 1},
 		Options: options.Index().SetUnique(true),
-	}
-
-	_, err := store.userCollection.Indexes().CreateOne(ctx, indexModel)
-	if err != nil {
-		log.Err(err).Msgf("user with email %s already exists", user.Email)
-		return primitive.NilObjectID, err
-	}
+	},
+}
 
+func (store *MongoDBStore) CreateUser(ctx context.Context, user User) (primitive.ObjectID, error) {
 	hashedPassword, err := util.HashPassword(user.Password)
 	if err != nil {
 		log.Err(err).Msgf("failed to hash password")
@@ -53,7 +49,7 @@ func (store *MongoDBStore) CreateUser(ctx context.Context, user User) (primitive
 	insertResult, err := store.userCollection.InsertOne(ctx, insertData)
 	if err != nil {
 		log.Err(err).Msgf("failed to insert user with email %s", user.Email)
-		return primitive.NilObjectID, err
+		return primitive.NilObjectID, wrapMongoError(err)
 	}
 
 	userID := insertResult.InsertedID.(primitive.ObjectID)
@@ -61,25 +57,22 @@ func (store *MongoDBStore) CreateUser(ctx context.Context, user User) (primitive
 	return userID, nil
 }
 
-func (store *MongoDBStore) GetAllUsers(ctx context.Context, pagination Pagination) ([]User, error) {
+func (store *MongoDBStore) GetAllUsers(ctx context.Context, pagination Pagination, sorting Sorting) ([]User, PageInfo, error) {
 	var users []User
 
-	findOptions := pagination.getFindOptions()
-	findOptions.SetSort(bson.M{"email": 1})
-
-	cursor, err := store.userCollection.Find(ctx, bson.M{}, findOptions)
+	sortFields, err := sorting.getSortFields(UserSortKeys, "email")
 	if err != nil {
-		log.Err(err).Msg("failed to find user documents")
-		return users, err
+		log.Err(err).Msg("failed to get sort fields for users")
+		return users, PageInfo{}, err
 	}
-	defer cursor.Close(ctx)
 
-	if err = cursor.All(ctx, &users); err != nil {
-		log.Err(err).Msg("failed to parse user documents")
-		return users, err
+	users, pageInfo, err := aggregatePage[User](ctx, store.userCollection, nil, pagination, sortFields, nil)
+	if err != nil {
+		log.Err(err).Msg("failed to aggregate user documents")
+		return users, PageInfo{}, err
 	}
 
-	return users, nil
+	return users, pageInfo, nil
 }
 
 func (store *MongoDBStore) GetUserByEmail(ctx context.Context, email string) (User, error) {
@@ -91,7 +84,7 @@ func (store *MongoDBStore) GetUserByEmail(ctx context.Context, email string) (Us
 
 	if err := store.userCollection.FindOne(ctx, filter).Decode(&user); err != nil {
 		log.Err(err).Msgf("failed to find user with email %s", email)
-		return user, err
+		return user, wrapMongoError(err)
 	}
 
 	return user, nil
@@ -103,7 +96,7 @@ func (store *MongoDBStore) GetUserByID(ctx context.Context, userID string) (User
 	primitiveUserID, err := primitive.ObjectIDFromHex(userID)
 	if err != nil {
 		log.Err(err).Msgf("failed to parse userID %s to primitive ObjectID", userID)
-		return user, err
+		return user, newInvalidIDError("userID", userID, err)
 	}
 
 	filter := bson.M{
@@ -112,7 +105,7 @@ func (store *MongoDBStore) GetUserByID(ctx context.Context, userID string) (User
 
 	if err = store.userCollection.FindOne(ctx, filter).Decode(&user); err != nil {
 		log.Err(err).Msgf("failed to find user with userID %s", userID)
-		return user, err
+		return user, wrapMongoError(err)
 	}
 
 	return user, nil
@@ -122,7 +115,7 @@ func (store *MongoDBStore) UpdateUserByID(ctx context.Context, userID string, us
 	primitiveUserID, err := primitive.ObjectIDFromHex(userID)
 	if err != nil {
 		log.Err(err).Msgf("failed to parse userID %s to primitive ObjectID", userID)
-		return 0, err
+		return 0, newInvalidIDError("userID", userID, err)
 	}
 
 	filter := bson.M{
@@ -148,7 +141,7 @@ func (store *MongoDBStore) UpdateUserByID(ctx context.Context, userID string, us
 	updateResult, err := store.userCollection.UpdateOne(ctx, filter, update)
 	if err != nil {
 		log.Err(err).Msgf("failed to update user with user userID %s", userID)
-		return 0, err
+		return 0, wrapMongoError(err)
 	}
 
 	if updateResult.MatchedCount < 1 {
@@ -167,7 +160,7 @@ func (store *MongoDBStore) DeleteUserByID(ctx context.Context, userID string) (i
 	primitiveUserID, err := primitive.ObjectIDFromHex(userID)
 	if err != nil {
 		log.Err(err).Msgf("failed to parse userID %s to primitive ObjectID", userID)
-		return 0, err
+		return 0, newInvalidIDError("userID", userID, err)
 	}
 
 	if err = checkReferencesOfDocument(ctx, store.recipeCollection, "userId", primitiveUserID); err != nil {
@@ -178,6 +171,10 @@ func (store *MongoDBStore) DeleteUserByID(ctx context.Context, userID string) (i
 		return 0, err
 	}
 
+	if err = checkReferencesOfDocument(ctx, store.reviewCollection, "userId", primitiveUserID); err != nil {
+		return 0, err
+	}
+
 	filter := bson.M{
 		"_id": primitiveUserID,
 	}
@@ -191,7 +188,67 @@ func (store *MongoDBStore) DeleteUserByID(ctx context.Context, userID string) (i
 	deleteCount := deleteResult.DeletedCount
 	if deleteCount < 1 {
 		log.Info().Msgf("user with userID %s was not deleted", userID)
+		return deleteCount, nil
+	}
+
+	// Sessions of a deleted user must not be usable anymore, so they are deleted with it.
+	_, err = store.sessionCollection.DeleteMany(ctx, bson.M{"userId": primitiveUserID})
+	if err != nil {
+		log.Err(err).Msgf("failed to delete sessions of user with userID %s", userID)
+		return deleteCount, err
 	}
 
 	return deleteCount, nil
 }
+
+// GetPendingUsers returns the users, which are not activated yet. They are sorted by their
+// registration, so the admins approve the oldest registrations first.
+func (store *MongoDBStore) GetPendingUsers(ctx context.Context, pagination Pagination) ([]User, PageInfo, error) {
+	var users []User
+
+	sortFields := []sortField{{key: "createdAt", order: 1}}
+
+	users, pageInfo, err := aggregatePage[User](ctx, store.userCollection, []bson.M{{"$match": bson.M{"isActive": false}}}, pagination, sortFields, nil)
+	if err != nil {
+		log.Err(err).Msg("failed to aggregate pending user documents")
+		return users, PageInfo{}, err
+	}
+
+	return users, pageInfo, nil
+}
+
+func (store *MongoDBStore) ActivateUserByID(ctx context.Context, userID string) (int64, error) {
+	primitiveUserID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		log.Err(err).Msgf("failed to parse userID %s to primitive ObjectID", userID)
+		return 0, newInvalidIDError("userID", userID, err)
+	}
+
+	filter := bson.M{
+		"_id": primitiveUserID,
+	}
+
+	update := bson.M{
+		"$set": bson.M{
+			"isActive":   true,
+			"modifiedAt": time.Now().Unix(),
+		},
+	}
+
+	updateResult, err := store.userCollection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		log.Err(err).Msgf("failed to activate user with userID %s", userID)
+		return 0, err
+	}
+
+	if updateResult.MatchedCount < 1 {
+		log.Info().Msgf("could not find user with userID %s", userID)
+	}
+
+	modifiedCount := updateResult.ModifiedCount
+	if modifiedCount < 1 {
+		log.Info().Msgf("did not activate user with userID %s", userID)
+	}
+
+	return modifiedCount, nil
+}
@@ -51,7 +51,7 @@ func TestUnitCreateUser(t *testing.T) {
 		user := createRandomUser(t, store)
 
 		_, err := store.CreateUser(context.Background(), user)
-		require.Error(t, err) // Duplicate user error
+		require.ErrorIs(t, err, ErrDuplicateKey)
 	})
 }
 
@@ -69,9 +69,10 @@ func TestUnitGetAllUsers(t *testing.T) {
 
 	t.Run("Gets all users with pagination", func(t *testing.T) {
 		ctx := context.Background()
-		users, err := store.GetAllUsers(ctx, pagination)
+		users, pageInfo, err := store.GetAllUsers(ctx, pagination, Sorting{})
 		require.NoError(t, err)
 		require.NotEmpty(t, users)
+		require.GreaterOrEqual(t, pageInfo.TotalCount, int64(10))
 
 		require.Equal(t, int(pagination.PageSize), len(users))
 	})
@@ -86,6 +87,7 @@ func TestUnitGetUserByID(t *testing.T) {
 		name         string
 		userID       string
 		hasError     bool
+		expectedErr  error
 		expectedUser User
 	}{
 		{
@@ -95,14 +97,16 @@ func TestUnitGetUserByID(t *testing.T) {
 			expectedUser: createdUser,
 		},
 		{
-			name:     "Fail with invalid userID",
-			userID:   "test",
-			hasError: true,
+			name:        "Fail with invalid userID",
+			userID:      "test",
+			hasError:    true,
+			expectedErr: ErrInvalidID,
 		},
 		{
-			name:     "Fail with userID not found",
-			userID:   "659c00751f717854f690270d",
-			hasError: true,
+			name:        "Fail with userID not found",
+			userID:      "659c00751f717854f690270d",
+			hasError:    true,
+			expectedErr: ErrNotFound,
 		},
 	}
 
@@ -112,6 +116,7 @@ func TestUnitGetUserByID(t *testing.T) {
 
 			if tc.hasError {
 				require.Error(t, err)
+				require.ErrorIs(t, err, tc.expectedErr)
 				return
 			}
 
@@ -322,3 +327,95 @@ func TestUnitDeleteUserByID(t *testing.T) {
 		})
 	}
 }
+
+func TestUnitGetPendingUsers(t *testing.T) {
+	store := getMongoDBStore(t)
+
+	for i := 0; i < 5; i++ {
+		_ = createRandomUser(t, store)
+	}
+
+	pagination := Pagination{
+		PageID:   1,
+		PageSize: 5,
+	}
+
+	t.Run("Gets pending users with pagination", func(t *testing.T) {
+		users, pageInfo, err := store.GetPendingUsers(context.Background(), pagination)
+		require.NoError(t, err)
+		require.Equal(t, int(pagination.PageSize), len(users))
+		require.GreaterOrEqual(t, pageInfo.TotalCount, int64(5))
+
+		for _, user := range users {
+			require.False(t, user.IsActive)
+		}
+
+		for i := 1; i < len(users); i++ {
+			require.LessOrEqual(t, users[i-1].CreatedAt, users[i].CreatedAt)
+		}
+	})
+
+	t.Run("Gets the next page of pending users with the cursor", func(t *testing.T) {
+		_, pageInfo, err := store.GetPendingUsers(context.Background(), Pagination{PageID: 1, PageSize: 2})
+		require.NoError(t, err)
+		require.NotEmpty(t, pageInfo.NextCursor)
+
+		users, _, err := store.GetPendingUsers(context.Background(), Pagination{PageSize: 2, Cursor: pageInfo.NextCursor})
+		require.NoError(t, err)
+		require.Len(t, users, 2)
+	})
+}
+
+func TestUnitActivateUserByID(t *testing.T) {
+	store := getMongoDBStore(t)
+
+	createdUser := createRandomUser(t, store)
+
+	testCases := []struct {
+		name          string
+		userID        string
+		hasError      bool
+		modifiedCount int64
+	}{
+		{
+			name:          "Success",
+			userID:        createdUser.ID,
+			hasError:      false,
+			modifiedCount: 1,
+		},
+		{
+			name:          "Fail with invalid userID",
+			userID:        "test",
+			hasError:      true,
+			modifiedCount: 0,
+		},
+		{
+			name:          "Fail with userID not found",
+			userID:        "659c00751f717854f690270d",
+			hasError:      false,
+			modifiedCount: 0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			modifiedCount, err := store.ActivateUserByID(context.Background(), tc.userID)
+			require.Equal(t, tc.modifiedCount, modifiedCount)
+
+			if tc.hasError {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+
+			if modifiedCount < 1 {
+				return
+			}
+
+			activatedUser, err := store.GetUserByID(context.Background(), tc.userID)
+			require.NoError(t, err)
+			require.True(t, activatedUser.IsActive)
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package nutrition
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/PfMartin/wegonice-api/db"
+)
+
+var ErrInvalidDataset = errors.New("invalid nutrient dataset")
+
+// dataset contains the nutrients of common ingredients per 100 g. The values are rounded averages
+// of public food composition tables. Aliases are separated by "|" and the piece weight is the
+// weight in grams of one piece, clove, slice, can or bunch of the ingredient.
+//
+//go:embed data/nutrients.csv
+var dataset []byte
+
+var datasetHeader = []string{"name", "aliases", "defaultUnit", "pieceWeightG", "kcal", "protein", "fat", "carbs", "fibre"}
+
+// LoadDataset parses the bundled nutrient dataset into catalogue ingredients, which can be
+// imported into the ingredient catalogue.
+func LoadDataset() ([]db.CatalogueIngredientToCreate, error) {
+	return parseDataset(dataset)
+}
+
+func parseDataset(data []byte) ([]db.CatalogueIngredientToCreate, error) {
+	records, err := csv.NewReader(bytes.NewReader(data)).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidDataset, err)
+	}
+
+	if len(records) == 0 || !slices.Equal(records[0], datasetHeader) {
+		return nil, fmt.Errorf("%w: header must be %s", ErrInvalidDataset, strings.Join(datasetHeader, ","))
+	}
+
+	ingredients := make([]db.CatalogueIngredientToCreate, 0, len(records)-1)
+	for i, record := range records[1:] {
+		ingredient, err := parseRecord(record)
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: %w", ErrInvalidDataset, i+2, err)
+		}
+
+		ingredients = append(ingredients, ingredient)
+	}
+
+	return ingredients, nil
+}
+
+func parseRecord(record []string) (db.CatalogueIngredientToCreate, error) {
+	var ingredient db.CatalogueIngredientToCreate
+
+	ingredient.Name = strings.TrimSpace(record[0])
+	if ingredient.Name == "" {
+		return ingredient, errors.New("missing name")
+	}
+
+	if record[1] != "" {
+		ingredient.Aliases = strings.Split(record[1], "|")
+	}
+
+	ingredient.DefaultUnit = db.AmountUnit(record[2])
+	if ingredient.DefaultUnit != "" && !ingredient.DefaultUnit.IsValid() {
+		return ingredient, fmt.Errorf("unsupported default unit %s", ingredient.DefaultUnit)
+	}
+
+	values := make([]float64, 0, len(record)-3)
+	for j, field := range record[3:] {
+		if field == "" {
+			values = append(values, 0)
+			continue
+		}
+
+		value, err := strconv.ParseFloat(field, 64)
+		if err != nil || value < 0 {
+			return ingredient, fmt.Errorf("invalid %s %q", datasetHeader[j+3], field)
+		}
+		values = append(values, value)
+	}
+
+	ingredient.PieceWeightG = values[0]
+	ingredient.Nutrients = &db.Nutrients{
+		KCal:    values[1],
+		Protein: values[2],
+		Fat:     values[3],
+		Carbs:   values[4],
+		Fibre:   values[5],
+	}
+
+	return ingredient, nil
+}
@@ -0,0 +1,92 @@
+package nutrition
+
+import (
+	"testing"
+
+	"github.com/PfMartin/wegonice-api/db"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnitLoadDataset(t *testing.T) {
+	ingredients, err := LoadDataset()
+	require.NoError(t, err)
+	require.NotEmpty(t, ingredients)
+
+	names := map[string]bool{}
+	for _, ingredient := range ingredients {
+		require.False(t, names[ingredient.Name], "duplicate ingredient %s", ingredient.Name)
+		names[ingredient.Name] = true
+
+		require.True(t, ingredient.DefaultUnit.IsValid(), "unsupported default unit of %s", ingredient.Name)
+		require.NotNil(t, ingredient.Nutrients)
+		require.LessOrEqual(t, ingredient.Nutrients.Protein+ingredient.Nutrients.Fat+ingredient.Nutrients.Carbs, 100.0, ingredient.Name)
+	}
+}
+
+func TestUnitParseDataset(t *testing.T) {
+	header := "name,aliases,defaultUnit,pieceWeightG,kcal,protein,fat,carbs,fibre\n"
+
+	testCases := []struct {
+		name                string
+		data                string
+		expectedIngredients []db.CatalogueIngredientToCreate
+		hasError            bool
+	}{
+		{
+			name: "Success",
+			data: header + "banana,bananas|plantain,pc,118,89,1.1,0.3,22.8,2.6\nsalt,,,,0,0,0,0,0\n",
+			expectedIngredients: []db.CatalogueIngredientToCreate{
+				{
+					Name:         "banana",
+					Aliases:      []string{"bananas", "plantain"},
+					DefaultUnit:  db.Piece,
+					PieceWeightG: 118,
+					Nutrients:    &db.Nutrients{KCal: 89, Protein: 1.1, Fat: 0.3, Carbs: 22.8, Fibre: 2.6},
+				},
+				{
+					Name:      "salt",
+					Nutrients: &db.Nutrients{},
+				},
+			},
+		},
+		{
+			name:     "Fail with wrong header",
+			data:     "name,kcal\nbanana,89\n",
+			hasError: true,
+		},
+		{
+			name:     "Fail with missing field",
+			data:     header + "banana,,pc,118,89,1.1,0.3,22.8\n",
+			hasError: true,
+		},
+		{
+			name:     "Fail with unsupported unit",
+			data:     header + "banana,,handful,118,89,1.1,0.3,22.8,2.6\n",
+			hasError: true,
+		},
+		{
+			name:     "Fail with negative value",
+			data:     header + "banana,,pc,118,-89,1.1,0.3,22.8,2.6\n",
+			hasError: true,
+		},
+		{
+			name:     "Fail with missing name",
+			data:     header + ",,pc,118,89,1.1,0.3,22.8,2.6\n",
+			hasError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ingredients, err := parseDataset([]byte(tc.data))
+
+			if tc.hasError {
+				require.ErrorIs(t, err, ErrInvalidDataset)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedIngredients, ingredients)
+		})
+	}
+}
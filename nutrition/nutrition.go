@@ -0,0 +1,99 @@
+// Package nutrition calculates the nutrition of recipes from the nutrients of the ingredient
+// catalogue.
+package nutrition
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/PfMartin/wegonice-api/conversion"
+	"github.com/PfMartin/wegonice-api/db"
+)
+
+var ErrUnknownPieceWeight = errors.New("unknown piece weight")
+
+// pinchWeightG is the weight of a pinch of salt or spices in grams.
+const pinchWeightG = 0.3
+
+// Calculate sums the nutrients of the ingredients of a recipe and divides them by its servings.
+// Ingredients, which are not linked to a catalogue ingredient with nutrients or whose amount
+// can't be converted to grams, are listed as missing.
+func Calculate(recipe db.Recipe, catalogueIngredients []db.CatalogueIngredient) db.RecipeNutrition {
+	catalogue := map[string]db.CatalogueIngredient{}
+	for _, catalogueIngredient := range catalogueIngredients {
+		catalogue[catalogueIngredient.ID] = catalogueIngredient
+	}
+
+	var total db.Nutrients
+	var missingIngredients []string
+	for _, ingredient := range recipe.Ingredients {
+		catalogueIngredient, ok := catalogue[ingredient.IngredientID]
+		if !ok || catalogueIngredient.Nutrients == nil {
+			missingIngredients = append(missingIngredients, ingredient.Name)
+			continue
+		}
+
+		grams, err := getGrams(ingredient, catalogueIngredient)
+		if err != nil {
+			missingIngredients = append(missingIngredients, ingredient.Name)
+			continue
+		}
+
+		total = addNutrients(total, *catalogueIngredient.Nutrients, grams/100)
+	}
+
+	nutrition := db.RecipeNutrition{
+		Total:              roundNutrients(total),
+		MissingIngredients: missingIngredients,
+	}
+
+	if recipe.Servings > 0 {
+		perServing := roundNutrients(addNutrients(db.Nutrients{}, total, 1/float64(recipe.Servings)))
+		nutrition.PerServing = &perServing
+	}
+
+	return nutrition
+}
+
+// getGrams returns the weight of the amount of the ingredient. Counted units are weighed with the
+// piece weight, all other units are converted with the density of the catalogue ingredient.
+func getGrams(ingredient db.Ingredient, catalogueIngredient db.CatalogueIngredient) (float64, error) {
+	amount := float64(ingredient.Amount)
+
+	if ingredient.Unit == db.Pinch {
+		return amount * pinchWeightG, nil
+	}
+
+	// Counted units are weighed with the piece weight of the catalogue ingredient.
+	if ingredient.Unit.IsCounted() {
+		if catalogueIngredient.PieceWeightG <= 0 {
+			return 0, fmt.Errorf("%w: %s", ErrUnknownPieceWeight, catalogueIngredient.Name)
+		}
+
+		return amount * catalogueIngredient.PieceWeightG, nil
+	}
+
+	return conversion.Convert(amount, ingredient.Unit, db.Grams, catalogueIngredient.Name)
+}
+
+func addNutrients(total db.Nutrients, nutrients db.Nutrients, factor float64) db.Nutrients {
+	return db.Nutrients{
+		KCal:    total.KCal + nutrients.KCal*factor,
+		Protein: total.Protein + nutrients.Protein*factor,
+		Fat:     total.Fat + nutrients.Fat*factor,
+		Carbs:   total.Carbs + nutrients.Carbs*factor,
+		Fibre:   total.Fibre + nutrients.Fibre*factor,
+	}
+}
+
+// roundNutrients rounds the energy to whole kcal and all other values to one decimal.
+func roundNutrients(nutrients db.Nutrients) db.Nutrients {
+	return db.Nutrients{
+		KCal:    math.Round(nutrients.KCal),
+		Protein: math.Round(nutrients.Protein*10) / 10,
+		Fat:     math.Round(nutrients.Fat*10) / 10,
+		Carbs:   math.Round(nutrients.Carbs*10) / 10,
+		Fibre:   math.Round(nutrients.Fibre*10) / 10,
+	}
+}
@@ -0,0 +1,69 @@
+package nutrition
+
+import (
+	"testing"
+
+	"github.com/PfMartin/wegonice-api/db"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnitCalculate(t *testing.T) {
+	catalogueIngredients := []db.CatalogueIngredient{
+		{ID: "flour", Name: "flour", Nutrients: &db.Nutrients{KCal: 364, Protein: 10.3, Fat: 1, Carbs: 76.3, Fibre: 2.7}},
+		{ID: "banana", Name: "banana", PieceWeightG: 118, Nutrients: &db.Nutrients{KCal: 89, Protein: 1.1, Fat: 0.3, Carbs: 22.8, Fibre: 2.6}},
+		{ID: "oil", Name: "olive oil", Nutrients: &db.Nutrients{KCal: 884, Fat: 100}},
+		{ID: "salt", Name: "salt", Nutrients: &db.Nutrients{}},
+		{ID: "spinach", Name: "spinach", Nutrients: &db.Nutrients{KCal: 23, Protein: 2.9, Fat: 0.4, Carbs: 3.6, Fibre: 2.2}},
+		{ID: "vanilla", Name: "vanilla"},
+	}
+
+	testCases := []struct {
+		name              string
+		recipe            db.Recipe
+		expectedNutrition db.RecipeNutrition
+	}{
+		{
+			name: "Weighed, counted and converted ingredients with servings",
+			recipe: db.Recipe{
+				Servings: 2,
+				Ingredients: []db.Ingredient{
+					{Name: "Flour", Amount: 0.2, Unit: db.Kilograms, IngredientID: "flour"},
+					{Name: "Bananas", Amount: 2, IngredientID: "banana"},
+					{Name: "Olive oil", Amount: 1, Unit: db.Tablespoon, IngredientID: "oil"},
+					{Name: "Salt", Amount: 1, Unit: db.Pinch, IngredientID: "salt"},
+				},
+			},
+			expectedNutrition: db.RecipeNutrition{
+				Total:      db.Nutrients{KCal: 1058, Protein: 23.2, Fat: 16.3, Carbs: 206.4, Fibre: 11.5},
+				PerServing: &db.Nutrients{KCal: 529, Protein: 11.6, Fat: 8.2, Carbs: 103.2, Fibre: 5.8},
+			},
+		},
+		{
+			name: "Missing ingredients without servings",
+			recipe: db.Recipe{
+				Ingredients: []db.Ingredient{
+					{Name: "Flour", Amount: 100, Unit: db.Grams, IngredientID: "flour"},
+					{Name: "Water", Amount: 200, Unit: db.Milliliters},
+					{Name: "Vanilla", Amount: 1, Unit: db.Teaspoon, IngredientID: "vanilla"},
+					{Name: "Spinach", Amount: 1, Unit: db.Cup, IngredientID: "spinach"},
+					{Name: "Flour", Amount: 1, Unit: db.Piece, IngredientID: "flour"},
+				},
+			},
+			expectedNutrition: db.RecipeNutrition{
+				Total:              db.Nutrients{KCal: 364, Protein: 10.3, Fat: 1, Carbs: 76.3, Fibre: 2.7},
+				MissingIngredients: []string{"Water", "Vanilla", "Spinach", "Flour"},
+			},
+		},
+		{
+			name:              "No ingredients",
+			recipe:            db.Recipe{Servings: 4},
+			expectedNutrition: db.RecipeNutrition{PerServing: &db.Nutrients{}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expectedNutrition, Calculate(tc.recipe, catalogueIngredients))
+		})
+	}
+}
@@ -3,6 +3,6 @@ package token
 import "time"
 
 type Maker interface {
-	CreateToken(userID string, duration time.Duration) (string, *Payload, error)
-	VerifyToken(token string) (*Payload, error)
+	CreateToken(userID string, role string, sessionID string, tokenType TokenType, duration time.Duration) (string, *Payload, error)
+	VerifyToken(token string, tokenType TokenType) (*Payload, error)
 }
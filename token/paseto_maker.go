@@ -27,8 +27,8 @@ func NewPasetoMaker(symmetricKey string) (Maker, error) {
 	return maker, nil
 }
 
-func (maker *PasetoMaker) CreateToken(userID string, duration time.Duration) (string, *Payload, error) {
-	payload, err := NewPayload(userID, duration)
+func (maker *PasetoMaker) CreateToken(userID string, role string, sessionID string, tokenType TokenType, duration time.Duration) (string, *Payload, error) {
+	payload, err := NewPayload(userID, role, sessionID, tokenType, duration)
 	if err != nil {
 		return "", payload, err
 	}
@@ -38,7 +38,7 @@ func (maker *PasetoMaker) CreateToken(userID string, duration time.Duration) (st
 	return token, payload, err
 }
 
-func (maker *PasetoMaker) VerifyToken(token string) (*Payload, error) {
+func (maker *PasetoMaker) VerifyToken(token string, tokenType TokenType) (*Payload, error) {
 	payload := &Payload{}
 	err := maker.paseto.Decrypt(token, maker.symmetricKey, payload, nil)
 	if err != nil {
@@ -51,5 +51,9 @@ func (maker *PasetoMaker) VerifyToken(token string) (*Payload, error) {
 		return nil, err
 	}
 
+	if payload.TokenType != tokenType {
+		return nil, ErrTokenType
+	}
+
 	return payload, nil
 }
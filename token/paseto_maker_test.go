@@ -13,22 +13,27 @@ func TestUnitPasetoMaker(t *testing.T) {
 	require.NoError(t, err)
 
 	userID := util.RandomString(10)
+	role := util.RandomString(5)
+	sessionID := util.RandomString(10)
 	duration := time.Minute
 
 	issuedAt := time.Now()
 	expiredAt := issuedAt.Add(duration)
 
-	token, payload, err := maker.CreateToken(userID, duration)
+	token, payload, err := maker.CreateToken(userID, role, sessionID, AccessToken, duration)
 	require.NoError(t, err)
 	require.NotEmpty(t, payload)
 	require.NotEmpty(t, token)
 
-	payload, err = maker.VerifyToken(token)
+	payload, err = maker.VerifyToken(token, AccessToken)
 	require.NoError(t, err)
 	require.NotEmpty(t, payload)
 
 	require.NotZero(t, payload.ID)
 	require.Equal(t, userID, payload.UserID)
+	require.Equal(t, role, payload.Role)
+	require.Equal(t, sessionID, payload.SessionID)
+	require.Equal(t, AccessToken, payload.TokenType)
 	require.WithinDuration(t, issuedAt, time.Unix(payload.IssuedAt, 0), time.Second)
 	require.WithinDuration(t, expiredAt, time.Unix(payload.ExpiresAt, 0), time.Second)
 }
@@ -37,13 +42,28 @@ func TestUnitExpiredPasetoToken(t *testing.T) {
 	maker, err := NewPasetoMaker(util.RandomString(32))
 	require.NoError(t, err)
 
-	token, payload, err := maker.CreateToken(util.RandomString(10), -time.Minute)
+	token, payload, err := maker.CreateToken(util.RandomString(10), util.RandomString(5), util.RandomString(10), AccessToken, -time.Minute)
 	require.NoError(t, err)
 	require.NotEmpty(t, payload)
 	require.NotEmpty(t, token)
 
-	payload, err = maker.VerifyToken(token)
+	payload, err = maker.VerifyToken(token, AccessToken)
 	require.Error(t, err)
 	require.EqualError(t, err, ErrExpiredToken.Error())
 	require.Nil(t, payload)
 }
+
+func TestUnitWrongTypePasetoToken(t *testing.T) {
+	maker, err := NewPasetoMaker(util.RandomString(32))
+	require.NoError(t, err)
+
+	token, payload, err := maker.CreateToken(util.RandomString(10), util.RandomString(5), util.RandomString(10), RefreshToken, time.Minute)
+	require.NoError(t, err)
+	require.NotEmpty(t, payload)
+	require.NotEmpty(t, token)
+
+	payload, err = maker.VerifyToken(token, AccessToken)
+	require.Error(t, err)
+	require.EqualError(t, err, ErrTokenType.Error())
+	require.Nil(t, payload)
+}
@@ -10,16 +10,29 @@ import (
 var (
 	ErrExpiredToken = fmt.Errorf("token has expired")
 	ErrInvalidToken = fmt.Errorf("token is invalid")
+	ErrTokenType    = fmt.Errorf("token has the wrong type")
+)
+
+// TokenType is the purpose of a token. Access tokens authorize requests and refresh tokens
+// are only used to issue new access tokens, so one can not be used in place of the other.
+type TokenType string
+
+const (
+	AccessToken  TokenType = "access"
+	RefreshToken TokenType = "refresh"
 )
 
 type Payload struct {
 	ID        uuid.UUID `json:"id"`
 	UserID    string    `json:"userId"`
+	Role      string    `json:"role"`
+	SessionID string    `json:"sessionId"`
+	TokenType TokenType `json:"tokenType"`
 	IssuedAt  int64     `json:"issuedAt"`
 	ExpiresAt int64     `json:"expiresAt"`
 }
 
-func NewPayload(userID string, duration time.Duration) (*Payload, error) {
+func NewPayload(userID string, role string, sessionID string, tokenType TokenType, duration time.Duration) (*Payload, error) {
 	tokenID, err := uuid.NewRandom()
 	if err != nil {
 		return nil, err
@@ -28,6 +41,9 @@ func NewPayload(userID string, duration time.Duration) (*Payload, error) {
 	payload := &Payload{
 		ID:        tokenID,
 		UserID:    userID,
+		Role:      role,
+		SessionID: sessionID,
+		TokenType: tokenType,
 		IssuedAt:  time.Now().Unix(),
 		ExpiresAt: time.Now().Add(duration).Unix(),
 	}
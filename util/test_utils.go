@@ -32,3 +32,7 @@ func RandomString(n int) string {
 func RandomEmail() string {
 	return fmt.Sprintf("%s@%s.com", RandomString(6), RandomString(4))
 }
+
+func RandomURL() string {
+	return fmt.Sprintf("https://www.%s.com/%s", RandomString(8), RandomString(6))
+}